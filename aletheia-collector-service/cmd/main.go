@@ -1,6 +1,7 @@
 package main
 
 import (
+	"aletheia-collector-service/internal/api/checkIn"
 	"aletheia-collector-service/internal/api/errorEvent"
 	"aletheia-collector-service/internal/api/health"
 	"aletheia-collector-service/internal/api/messageEvent"
@@ -44,21 +45,25 @@ func main() {
 	resourceKafkaRepository := resourceEvent.NewResourceRepository(kafkaProducer, cfg.Kafka.ResourceTopic, rdb)
 	errorKafkaRepository := errorEvent.NewErrorRepository(kafkaProducer, cfg.Kafka.ErrorTopic)
 	messageKafkaRepository := messageEvent.NewMessageRepository(kafkaProducer, cfg.Kafka.MessageTopic)
+	checkInKafkaRepository := checkIn.NewCheckInRepository(kafkaProducer, cfg.Kafka.CheckInTopic)
 
 	// Инициализируем usecase
 	resourceUC := resourceEvent.NewUseCase(resourceKafkaRepository, cfg.RateLimiting.MaxRequestsPerMinute)
 	errorUC := errorEvent.NewUseCase(errorKafkaRepository)
 	messageUC := messageEvent.NewUseCase(messageKafkaRepository)
+	checkInUC := checkIn.NewUseCase(checkInKafkaRepository)
 
 	// Инициализируем HTTP handler
 	resourceHandler := resourceEvent.NewHandler(resourceUC)
 	errorHandler := errorEvent.NewHandler(errorUC)
 	messageHandler := messageEvent.NewHandler(messageUC)
+	checkInHandler := checkIn.NewHandler(checkInUC)
 
 	// Регистрируем маршруты
 	http.HandleFunc("/api/v1/resource", middleware.AuthMiddleware(resourceHandler.SendResourceEvent))
 	http.HandleFunc("/api/v1/error", middleware.AuthMiddleware(errorHandler.SendErrorEvent))
 	http.HandleFunc("/api/v1/event", middleware.AuthMiddleware(messageHandler.SendMessageEvent)) // todo потом поправить, чтобы в sdk и тут былло одинаково
+	http.HandleFunc("/api/v1/checkin", middleware.AuthMiddleware(checkInHandler.SendCheckIn))
 	http.HandleFunc("/health", health.Health)
 
 	// Запуск HTTP сервера с использованием порта из конфигурации
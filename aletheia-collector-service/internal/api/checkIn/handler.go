@@ -0,0 +1,67 @@
+package checkIn
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+type Handler struct {
+	useCase UseCase
+}
+
+func NewHandler(uc UseCase) *Handler {
+	return &Handler{useCase: uc}
+}
+
+func (h *Handler) SendCheckIn(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, CheckInResponse{
+			Success:      false,
+			ErrorMessage: "Only POST is allowed",
+		})
+		return
+	}
+
+	var ci CheckIn
+	if err := json.NewDecoder(r.Body).Decode(&ci); err != nil {
+		errMsg := fmt.Sprintf("failed to parse check-in: %v", err)
+		log.Println(errMsg)
+		writeJSON(w, http.StatusBadRequest, CheckInResponse{Success: false, ErrorMessage: errMsg})
+		return
+	}
+	if err := validate(ci); err != nil {
+		writeJSON(w, http.StatusBadRequest, CheckInResponse{Success: false, ErrorMessage: err.Error()})
+		return
+	}
+
+	userID := r.Header.Get("X-User-Id")
+
+	if err := h.useCase.ProcessCheckIn(ci, userID); err != nil {
+		errMsg := fmt.Sprintf("failed to process check-in: %v", err)
+		log.Println(errMsg)
+		writeJSON(w, http.StatusInternalServerError, CheckInResponse{Success: false, ErrorMessage: errMsg})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, CheckInResponse{Success: true})
+}
+
+func validate(ci CheckIn) error {
+	if ci.ProjectID == "" || ci.MonitorSlug == "" {
+		return fmt.Errorf("project_id and monitor_slug are required")
+	}
+	switch ci.Status {
+	case StatusInProgress, StatusOk, StatusError:
+		return nil
+	default:
+		return fmt.Errorf("unsupported status %q", ci.Status)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, resp CheckInResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,22 @@
+package checkIn
+
+// Статусы check-in, которые принимает коллектор.
+const (
+	StatusInProgress = "in_progress"
+	StatusOk         = "ok"
+	StatusError      = "error"
+)
+
+type CheckIn struct {
+	ProjectID   string `json:"project_id"`
+	MonitorSlug string `json:"monitor_slug"`
+	Status      string `json:"status"`
+	ServiceName string `json:"service_name"`
+	Environment string `json:"environment"`
+	Timestamp   string `json:"timestamp"`
+}
+
+type CheckInResponse struct {
+	Success      bool   `json:"success"`
+	ErrorMessage string `json:"error_message"`
+}
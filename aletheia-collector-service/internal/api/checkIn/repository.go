@@ -0,0 +1,51 @@
+package checkIn
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+type Repository interface {
+	SendCheckIn(key []byte, value []byte) (partition int32, offset int64, err error)
+}
+
+type checkInRepository struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewCheckInRepository(producer sarama.SyncProducer, topic string) Repository {
+	return &checkInRepository{producer: producer, topic: topic}
+}
+
+func (kr *checkInRepository) SendCheckIn(key []byte, value []byte) (int32, int64, error) {
+	msg := &sarama.ProducerMessage{
+		Topic: kr.topic,
+		Key:   sarama.ByteEncoder(key),
+		Value: sarama.ByteEncoder(value),
+	}
+	partition, offset, err := kr.producer.SendMessage(msg)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to send check-in to Kafka: %w", err)
+	}
+	return partition, offset, nil
+}
+
+// BuildKafkaMessage дополняет check-in пользователем и временем приёма.
+// По received_at движок считает пропуски: часы клиента могут врать.
+func BuildKafkaMessage(ci CheckIn, userID string) ([]byte, error) {
+	data := map[string]interface{}{
+		"project_id":   ci.ProjectID,
+		"monitor_slug": ci.MonitorSlug,
+		"status":       ci.Status,
+		"service_name": ci.ServiceName,
+		"environment":  ci.Environment,
+		"timestamp":    ci.Timestamp,
+		"received_at":  time.Now().UTC().Format(time.RFC3339Nano),
+		"user_id":      userID,
+	}
+	return json.Marshal(data)
+}
@@ -0,0 +1,35 @@
+package checkIn
+
+import (
+	"fmt"
+)
+
+type UseCase interface {
+	ProcessCheckIn(ci CheckIn, userID string) error
+}
+
+type useCaseImpl struct {
+	repo Repository
+}
+
+func NewUseCase(repo Repository) UseCase {
+	return &useCaseImpl{repo: repo}
+}
+
+func (uc *useCaseImpl) ProcessCheckIn(ci CheckIn, userID string) error {
+	// Ключ по монитору: check-in'ы одного монитора попадают в одну партицию и обрабатываются по порядку
+	key := ci.ProjectID + ":" + ci.MonitorSlug
+
+	value, err := BuildKafkaMessage(ci, userID)
+	if err != nil {
+		return fmt.Errorf("failed to build message for check-in: %v", err)
+	}
+
+	partition, offset, err := uc.repo.SendCheckIn([]byte(key), value)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("[CheckIn] Sent to topic partition=%d, offset=%d\n", partition, offset)
+	return nil
+}
@@ -23,6 +23,7 @@ type Config struct {
 		ResourceTopic string `envconfig:"KAFKA_RESOURCE_TOPIC" default:"kafka-resource-usage-topic"`
 		ErrorTopic    string `envconfig:"KAFKA_ERROR_TOPIC" default:"kafka-error-topic"`
 		MessageTopic  string `envconfig:"KAFKA_MESSAGE_TOPIC" default:"kafka-message-topic"`
+		CheckInTopic  string `envconfig:"KAFKA_CHECKIN_TOPIC" default:"kafka-checkin-topic"`
 	} `envconfig:"KAFKA"`
 	// Server
 	Server struct {
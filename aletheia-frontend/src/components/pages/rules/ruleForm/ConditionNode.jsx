@@ -22,6 +22,9 @@ function ConditionNode({
                 >
                     <option value="AND">AND</option>
                     <option value="OR">OR</option>
+                    <option value="NOT">NOT</option>
+                    <option value="XOR">XOR</option>
+                    <option value="NONE_OF">NONE_OF</option>
                 </select>
             </div>
             {node.conditions.map((condition, index) => (
@@ -12,6 +12,7 @@ type Client struct {
 	config      *config.AletheiaConfig
 	eventSvc    *services.EventService
 	resourceSvc *services.ResourceService
+	checkInSvc  *services.CheckInService
 	userID      string
 	userInfo    map[string]interface{}
 	tags        map[string]string
@@ -34,6 +35,7 @@ func NewClient(cfg config.AletheiaConfig) (*Client, error) {
 		config:      &cfg,
 		eventSvc:    services.NewEventService(cfg, tp, cfg.Logger),
 		resourceSvc: services.NewResourceService(cfg, tp, cfg.Logger),
+		checkInSvc:  services.NewCheckInService(cfg, tp, cfg.Logger),
 		tags:        make(map[string]string),
 		customField: make(map[string]interface{}),
 	}, nil
@@ -56,6 +58,13 @@ func (c *Client) CaptureException(err error, eventMessage string, eventType stri
 	c.eventSvc.CaptureException(err, eventMessage, eventType, context, c.tags, c.userID, c.userInfo, c.customField)
 }
 
+// CheckIn сообщает о запуске cron-задачи монитора monitorSlug.
+// in_progress – задача стартовала, ok/error – задача завершилась.
+// Если ok не пришёл вовремя по расписанию монитора, движок отправит алерт о пропущенном запуске.
+func (c *Client) CheckIn(monitorSlug string, status models.CheckInStatus) error {
+	return c.checkInSvc.CheckIn(monitorSlug, status)
+}
+
 func (c *Client) SetUser(userID string, info map[string]interface{}) {
 	c.userID = userID
 	c.userInfo = info
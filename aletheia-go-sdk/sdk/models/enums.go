@@ -17,3 +17,12 @@ const (
 	EventMessage       EventType = "MESSAGE"
 	EventResourceUsage EventType = "RESOURCE_USAGE"
 )
+
+// CheckInStatus – статус запуска задачи, о котором сообщает check-in.
+type CheckInStatus string
+
+const (
+	CheckInInProgress CheckInStatus = "in_progress"
+	CheckInOk         CheckInStatus = "ok"
+	CheckInError      CheckInStatus = "error"
+)
@@ -51,3 +51,13 @@ type ErrorEvent struct {
 	ContextJson  string                 `json:"context_json"`
 	Fields       map[string]interface{} `json:"fields"`
 }
+
+// CheckInRequest – отметка cron-задачи для монитора monitor_slug.
+type CheckInRequest struct {
+	ProjectId   string `json:"project_id"`
+	MonitorSlug string `json:"monitor_slug"`
+	Status      string `json:"status"`
+	ServiceName string `json:"service_name"`
+	Environment string `json:"environment"`
+	Timestamp   string `json:"timestamp"`
+}
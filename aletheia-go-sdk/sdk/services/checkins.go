@@ -0,0 +1,51 @@
+package services
+
+import (
+	"aletheia-go-sdk/sdk/config"
+	"aletheia-go-sdk/sdk/models"
+	"aletheia-go-sdk/sdk/transport"
+	"fmt"
+	"time"
+)
+
+type CheckInService struct {
+	transport *transport.HTTPTransport
+	config    *config.AletheiaConfig
+	logger    config.Logger
+}
+
+func NewCheckInService(cfg config.AletheiaConfig, tp *transport.HTTPTransport, logger config.Logger) *CheckInService {
+	return &CheckInService{
+		transport: tp,
+		config:    &cfg,
+		logger:    logger,
+	}
+}
+
+// CheckIn отправляет отметку монитора. В отличие от событий ошибка возвращается вызывающему:
+// потерянный check-in превратится в ложный алерт о пропущенном запуске.
+func (s *CheckInService) CheckIn(monitorSlug string, status models.CheckInStatus) error {
+	if monitorSlug == "" {
+		return fmt.Errorf("monitor slug is required")
+	}
+	switch status {
+	case models.CheckInInProgress, models.CheckInOk, models.CheckInError:
+	default:
+		return fmt.Errorf("unsupported check-in status %q", status)
+	}
+
+	req := models.CheckInRequest{
+		ProjectId:   s.config.ProjectId,
+		MonitorSlug: monitorSlug,
+		Status:      string(status),
+		ServiceName: s.config.ServiceName,
+		Environment: s.config.Environment,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+
+	if err := s.transport.SendEvent(req); err != nil {
+		s.logger.Errorf("Failed to send check-in %s: %v", monitorSlug, err)
+		return err
+	}
+	return nil
+}
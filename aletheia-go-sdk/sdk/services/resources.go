@@ -6,10 +6,14 @@ import (
 	"aletheia-go-sdk/sdk/transport"
 	"aletheia-go-sdk/sdk/utils"
 	"github.com/sony/gobreaker"
+	"os"
 	"runtime"
 	"time"
 )
 
+// InstanceTag – тег с идентификатором экземпляра сервиса в ресурсных событиях.
+const InstanceTag = "instance"
+
 type ResourceService struct {
 	transport      *transport.HTTPTransport
 	config         *config.AletheiaConfig
@@ -62,7 +66,7 @@ func (s *ResourceService) StartMonitoring(
 		}
 	}
 
-	s.monitoringTags = tags
+	s.monitoringTags = withInstanceTag(tags)
 	ticker := time.NewTicker(interval)
 
 	go func() {
@@ -121,6 +125,21 @@ func (s *ResourceService) StopMonitoring() {
 	close(s.stopChan)
 }
 
+// withInstanceTag добавляет тег instance=<hostname>, если пользователь не задал его сам.
+// По нему rule engine отличает экземпляры одного сервиса.
+func withInstanceTag(tags map[string]string) map[string]string {
+	result := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		result[k] = v
+	}
+	if _, ok := result[InstanceTag]; !ok {
+		if hostname, err := os.Hostname(); err == nil {
+			result[InstanceTag] = hostname
+		}
+	}
+	return result
+}
+
 func getRuntimeMetrics() (uint64, int) {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
@@ -70,6 +70,9 @@ func (t *HTTPTransport) SendEvent(event interface{}) error {
 	case models.ErrorEvent:
 		url = fmt.Sprintf("%s/api/v1/error", t.config.CollectorAddress)
 		bodyBytes, err = json.Marshal(req)
+	case models.CheckInRequest:
+		url = fmt.Sprintf("%s/api/v1/checkin", t.config.CollectorAddress)
+		bodyBytes, err = json.Marshal(req)
 
 	default:
 		return fmt.Errorf("unsupported event type")
@@ -48,4 +48,102 @@ type Projects interface {
 	// @tg http-path=/project/:projectID
 	// @tg http-headers=userId|X-User-Id
 	UpdateProject(ctx context.Context, project *v1.UpdateProjectRequest, projectID string, userId int64) (status bool, err error)
+
+	// GetMonitors
+	// @tg summary=`Получить мониторы cron-задач`
+	// @tg desc=`Возвращает мониторы check-in проекта`
+	// @tg http-method=GET
+	// @tg http-path=/project/:projectID/monitors
+	// @tg http-headers=userId|X-User-Id
+	GetMonitors(ctx context.Context, projectID string, userId int64) (items v1.MonitorsResponse, err error)
+
+	// CreateMonitor
+	// @tg summary=`Создать монитор`
+	// @tg desc=`Создать монитор cron-задачи: расписание cron или интервал, grace-период и часовой пояс`
+	// @tg http-method=POST
+	// @tg http-path=/project/:projectID/monitor
+	// @tg http-headers=userId|X-User-Id
+	CreateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, userId int64) (status bool, err error)
+
+	// UpdateMonitor
+	// @tg summary=`Обновить монитор`
+	// @tg desc=`Обновить монитор cron-задачи`
+	// @tg http-method=PUT
+	// @tg http-path=/project/:projectID/monitor/:monitorID
+	// @tg http-headers=userId|X-User-Id
+	UpdateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, monitorID string, userId int64) (status bool, err error)
+
+	// DeleteMonitor
+	// @tg summary=`Удалить монитор`
+	// @tg desc=`Удалить монитор cron-задачи`
+	// @tg http-method=DELETE
+	// @tg http-path=/project/:projectID/monitor/:monitorID
+	// @tg http-headers=userId|X-User-Id
+	DeleteMonitor(ctx context.Context, projectID string, monitorID string, userId int64) (status bool, err error)
+
+	// GetMaintenanceWindows
+	// @tg summary=`Получить окна обслуживания`
+	// @tg desc=`Возвращает окна обслуживания проекта, во время которых алерты не отправляются`
+	// @tg http-method=GET
+	// @tg http-path=/project/:projectID/maintenance-windows
+	// @tg http-headers=userId|X-User-Id
+	GetMaintenanceWindows(ctx context.Context, projectID string, userId int64) (items v1.MaintenanceWindowsResponse, err error)
+
+	// CreateMaintenanceWindow
+	// @tg summary=`Создать окно обслуживания`
+	// @tg desc=`Создать окно обслуживания: разовый интервал (деплой) или расписание (ночные батчи)`
+	// @tg http-method=POST
+	// @tg http-path=/project/:projectID/maintenance-window
+	// @tg http-headers=userId|X-User-Id
+	CreateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, userId int64) (status bool, err error)
+
+	// UpdateMaintenanceWindow
+	// @tg summary=`Обновить окно обслуживания`
+	// @tg desc=`Обновить окно обслуживания проекта`
+	// @tg http-method=PUT
+	// @tg http-path=/project/:projectID/maintenance-window/:windowID
+	// @tg http-headers=userId|X-User-Id
+	UpdateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, windowID string, userId int64) (status bool, err error)
+
+	// DeleteMaintenanceWindow
+	// @tg summary=`Удалить окно обслуживания`
+	// @tg desc=`Удалить окно обслуживания проекта`
+	// @tg http-method=DELETE
+	// @tg http-path=/project/:projectID/maintenance-window/:windowID
+	// @tg http-headers=userId|X-User-Id
+	DeleteMaintenanceWindow(ctx context.Context, projectID string, windowID string, userId int64) (status bool, err error)
+
+	// GetSilences
+	// @tg summary=`Получить silences`
+	// @tg desc=`Возвращает активные silences проекта и истёкшие за последнюю неделю`
+	// @tg http-method=GET
+	// @tg http-path=/project/:projectID/silences
+	// @tg http-headers=userId|X-User-Id
+	GetSilences(ctx context.Context, projectID string, userId int64) (items v1.SilencesResponse, err error)
+
+	// CreateSilence
+	// @tg summary=`Создать silence`
+	// @tg desc=`Заглушить алерты по условиям (правило, сервис, окружение, error_message, теги) до ends_at или на duration_sec`
+	// @tg http-method=POST
+	// @tg http-path=/project/:projectID/silence
+	// @tg http-headers=userId|X-User-Id
+	CreateSilence(ctx context.Context, silence *v1.SilenceRequest, projectID string, userId int64) (status bool, err error)
+
+	// ExpireSilence
+	// @tg summary=`Завершить silence`
+	// @tg desc=`Завершить действующий silence досрочно`
+	// @tg http-method=DELETE
+	// @tg http-path=/project/:projectID/silence/:silenceID
+	// @tg http-headers=userId|X-User-Id
+	ExpireSilence(ctx context.Context, projectID string, silenceID string, userId int64) (status bool, err error)
+
+	// GetAlertTransitions
+	// @tg summary=`Получить историю состояний алертов`
+	// @tg desc=`Возвращает переходы состояний алертов проекта (pending, firing, resolved, inactive) за последние pastHours часов (по умолчанию 24), новые первыми. ruleId – только переходы одного правила`
+	// @tg http-method=GET
+	// @tg http-path=/project/:projectID/alert-transitions
+	// @tg http-headers=userId|X-User-Id
+	// @tg http-args=`pastHours|pastHours`
+	// @tg http-args=`ruleId|ruleId`
+	GetAlertTransitions(ctx context.Context, projectID string, pastHours int, ruleId string, userId int64) (items v1.AlertTransitionsResponse, err error)
 }
@@ -54,4 +54,11 @@ type Rules interface {
 	// @tg http-path=/rules/update
 	// @tg http-headers=userId|X-User-Id
 	UpdateRuleById(ctx context.Context, userId int64, request v1.UpdateRuleRequest) (status bool, err error)
+	// TestRule
+	// @tg summary=`Проверить правило на примере события`
+	// @tg desc=`Dry-run правила: результат и трассировка по каждому узлу и условию, без отправки алертов`
+	// @tg http-method=POST
+	// @tg http-path=/rules/test
+	// @tg http-headers=userId|X-User-Id
+	TestRule(ctx context.Context, userId int64, request v1.TestRuleRequest) (result *v1.TestRuleResponse, err error)
 }
@@ -18,8 +18,10 @@ type MeResponse struct {
 	Username string `json:"username"`
 }
 type UsedRule struct {
-	RuleId   string `json:"rule_id"`
-	RuleName string `json:"rule_name"`
+	RuleId     string `json:"rule_id"`
+	RuleName   string `json:"rule_name"`
+	Suppressed bool   `json:"suppressed,omitempty"` // сработало, но алерт подавлен cooldown
+	SilenceID  string `json:"silence_id,omitempty"` // сработало, но алерт заглушён silence
 }
 
 type UsedAction struct {
@@ -111,6 +113,18 @@ type RuleDetailResponse struct {
 	Description *string  `json:"description,omitempty"`
 	RootNode    Node     `json:"root_node"`
 	Actions     []Action `json:"actions"`
+	// CooldownSec – после срабатывания правило не шлёт алерты указанное число секунд (0 – без ограничений)
+	CooldownSec int `json:"cooldown_sec,omitempty"`
+	// DedupKey – поля события через "+", по которым считается cooldown (например, "error_message+service_name")
+	DedupKey string `json:"dedup_key,omitempty"`
+	// ForSamples, ForMinutes – ресурсное правило переходит в firing, только если условие держится
+	// указанное число сэмплов подряд и минут (0 – срабатывает сразу)
+	ForSamples int `json:"for_samples,omitempty"`
+	ForMinutes int `json:"for_minutes,omitempty"`
+	// Correlation – шаги, окно и условия корреляционного правила (только для ruleType = "correlation")
+	Correlation *CorrelationSpec `json:"correlation,omitempty"`
+	// Schedule – когда правило активно (только для ruleType errors и resources); нет расписания – активно всегда
+	Schedule *RuleSchedule `json:"schedule,omitempty"`
 }
 
 type Node struct {
@@ -150,6 +164,113 @@ type ServiceCreateRequest struct {
 	ResourceRules []int  `json:"resourceRules"`
 }
 
+// Monitor – монитор cron-задачи, о запусках которой SDK сообщает через check-in.
+type Monitor struct {
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	// ScheduleType – "cron" (CronExpr) или "interval" (IntervalSec)
+	ScheduleType string `json:"schedule_type"`
+	CronExpr     string `json:"cron_expr,omitempty"`
+	IntervalSec  int    `json:"interval_sec,omitempty"`
+	// GraceSec – сколько ждать check-in после запланированного запуска, прежде чем считать его пропущенным
+	GraceSec int `json:"grace_sec"`
+	// Timezone – IANA-зона, в которой считается CronExpr (по умолчанию UTC)
+	Timezone string   `json:"timezone"`
+	Actions  []Action `json:"actions"`
+	Enabled  bool     `json:"enabled"`
+}
+
+type MonitorsResponse struct {
+	Monitors []Monitor `json:"monitors"`
+}
+
+// MonitorRequest – входной JSON для создания и обновления монитора.
+type MonitorRequest struct {
+	Slug         string   `json:"slug"`
+	Name         string   `json:"name"`
+	ScheduleType string   `json:"schedule_type"`
+	CronExpr     string   `json:"cron_expr,omitempty"`
+	IntervalSec  int      `json:"interval_sec,omitempty"`
+	GraceSec     int      `json:"grace_sec"`
+	Timezone     string   `json:"timezone"`
+	Actions      []Action `json:"actions"`
+	Enabled      *bool    `json:"enabled,omitempty"`
+}
+
+// Silence – временное заглушение алертов проекта: сработавшее правило, подходящее под matchers,
+// не отправляет действия до ends_at, а срабатывание пишется в лог с ID silence.
+type Silence struct {
+	ID        string          `json:"id"`
+	Matchers  SilenceMatchers `json:"matchers"`
+	StartsAt  time.Time       `json:"starts_at"`
+	EndsAt    time.Time       `json:"ends_at"`
+	CreatedBy string          `json:"created_by"`
+	Comment   string          `json:"comment"`
+	Active    bool            `json:"active"`
+}
+
+// SilenceMatchers – условия silence, все заданные должны совпасть.
+// ErrorMessage – регулярное выражение, Tags – теги события "key=value".
+type SilenceMatchers struct {
+	RuleID       string            `json:"rule_id,omitempty"`
+	ServiceName  string            `json:"service_name,omitempty"`
+	Environment  string            `json:"environment,omitempty"`
+	ErrorMessage string            `json:"error_message,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+type SilencesResponse struct {
+	Silences []Silence `json:"silences"`
+}
+
+// AlertTransition – переход состояния алерта движка ресурсов: pending, firing, resolved или inactive.
+type AlertTransition struct {
+	Timestamp   time.Time `json:"timestamp"`
+	RuleID      string    `json:"rule_id"`
+	RuleName    string    `json:"rule_name"`
+	ServiceName string    `json:"service_name"`
+	Environment string    `json:"environment"`
+	Instance    string    `json:"instance"`
+	State       string    `json:"state"`
+}
+
+type AlertTransitionsResponse struct {
+	Transitions []AlertTransition `json:"transitions"`
+}
+
+// SilenceRequest – входной JSON для создания silence. Срок задаётся ends_at или duration_sec,
+// автором silence становится пользователь запроса.
+type SilenceRequest struct {
+	Matchers    SilenceMatchers `json:"matchers"`
+	EndsAt      *time.Time      `json:"ends_at,omitempty"`
+	DurationSec int             `json:"duration_sec,omitempty"`
+	Comment     string          `json:"comment"`
+}
+
+// MaintenanceWindow – окно обслуживания проекта: алерты не отправляются, срабатывания пишутся в лог.
+// Разовое окно задаётся starts_at/ends_at (деплой), повторяющееся – schedule (ночные батчи).
+type MaintenanceWindow struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	StartsAt *time.Time    `json:"starts_at,omitempty"`
+	EndsAt   *time.Time    `json:"ends_at,omitempty"`
+	Schedule *RuleSchedule `json:"schedule,omitempty"`
+}
+
+type MaintenanceWindowsResponse struct {
+	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows"`
+}
+
+// MaintenanceWindowRequest – входной JSON для создания и обновления окна обслуживания.
+// Нужен либо интервал starts_at/ends_at, либо schedule.
+type MaintenanceWindowRequest struct {
+	Name     string        `json:"name"`
+	StartsAt *time.Time    `json:"starts_at,omitempty"`
+	EndsAt   *time.Time    `json:"ends_at,omitempty"`
+	Schedule *RuleSchedule `json:"schedule,omitempty"`
+}
+
 type DeleteRuleRequest struct {
 	RuleId   string `json:"ruleId"`
 	RuleType string `json:"ruleType"`
@@ -161,6 +282,18 @@ type CreateRuleRequest struct {
 	RuleType        string   `json:"ruleType"`
 	RootNode        Node     `json:"root_node"`
 	Actions         []Action `json:"actions"`
+	// CooldownSec – после срабатывания правило не шлёт алерты указанное число секунд (0 – без ограничений)
+	CooldownSec int `json:"cooldown_sec,omitempty"`
+	// DedupKey – поля события через "+", по которым считается cooldown (например, "error_message+service_name")
+	DedupKey string `json:"dedup_key,omitempty"`
+	// ForSamples, ForMinutes – ресурсное правило переходит в firing, только если условие держится
+	// указанное число сэмплов подряд и минут (0 – срабатывает сразу)
+	ForSamples int `json:"for_samples,omitempty"`
+	ForMinutes int `json:"for_minutes,omitempty"`
+	// Correlation – шаги, окно и условия корреляционного правила (только для ruleType = "correlation")
+	Correlation *CorrelationSpec `json:"correlation,omitempty"`
+	// Schedule – когда правило активно (только для ruleType errors и resources); нет расписания – активно всегда
+	Schedule *RuleSchedule `json:"schedule,omitempty"`
 }
 
 type UpdateRuleRequest struct {
@@ -170,6 +303,81 @@ type UpdateRuleRequest struct {
 	RuleType        string   `json:"ruleType"`
 	RootNode        Node     `json:"root_node"`
 	Actions         []Action `json:"actions"`
+	// CooldownSec – после срабатывания правило не шлёт алерты указанное число секунд (0 – без ограничений)
+	CooldownSec int `json:"cooldown_sec,omitempty"`
+	// DedupKey – поля события через "+", по которым считается cooldown (например, "error_message+service_name")
+	DedupKey string `json:"dedup_key,omitempty"`
+	// ForSamples, ForMinutes – ресурсное правило переходит в firing, только если условие держится
+	// указанное число сэмплов подряд и минут (0 – срабатывает сразу)
+	ForSamples int `json:"for_samples,omitempty"`
+	ForMinutes int `json:"for_minutes,omitempty"`
+	// Correlation – шаги, окно и условия корреляционного правила (только для ruleType = "correlation")
+	Correlation *CorrelationSpec `json:"correlation,omitempty"`
+	// Schedule – когда правило активно (только для ruleType errors и resources); нет расписания – активно всегда
+	Schedule *RuleSchedule `json:"schedule,omitempty"`
+}
+
+// CorrelationSpec – корреляционное правило: несколько событий проекта из потоков ошибок и ресурсов.
+// kind = "sequence" – шаги по порядку в пределах window_sec, "co_occurrence" – все шаги в окне в любом порядке.
+// Для co_occurrence можно потребовать min_distinct разных значений поля distinct_by (например, tags.instance).
+type CorrelationSpec struct {
+	ProjectId   string            `json:"projectId"`
+	Kind        string            `json:"kind"`
+	WindowSec   int               `json:"window_sec"`
+	Steps       []CorrelationStep `json:"steps"`
+	DistinctBy  string            `json:"distinct_by,omitempty"`
+	MinDistinct int               `json:"min_distinct,omitempty"`
+}
+
+// CorrelationStep – шаг корреляционного правила: условие на событие движка engine ("errors" или "resources").
+// Пустой service_name – событие любого сервиса проекта.
+type CorrelationStep struct {
+	Name        string `json:"name"`
+	Engine      string `json:"engine"`
+	ServiceName string `json:"service_name,omitempty"`
+	RootNode    Node   `json:"root_node"`
+}
+
+// RuleSchedule – дни недели и часы в часовом поясе IANA, когда правило активно (или действует окно обслуживания).
+// End раньше Start – интервал через полночь ("22:00"–"06:00"), End равен Start – весь день.
+// Пустой days – каждый день, пустой timezone – UTC.
+type RuleSchedule struct {
+	Timezone string   `json:"timezone"`
+	Days     []string `json:"days,omitempty"` // mon, tue, wed, thu, fri, sat, sun
+	Start    string   `json:"start"`          // "09:00"
+	End      string   `json:"end"`            // "18:00"
+}
+
+// TestRuleRequest – dry-run правила на примере события.
+// Проверяется root_node из запроса, а если его нет – сохранённое правило ruleId.
+type TestRuleRequest struct {
+	RuleId   string                 `json:"ruleId,omitempty"`
+	RuleType string                 `json:"ruleType"`
+	RootNode *Node                  `json:"root_node,omitempty"`
+	Event    map[string]interface{} `json:"event"`
+}
+
+// TestRuleResponse – результат dry-run: сработало ли правило и почему.
+type TestRuleResponse struct {
+	Matched bool      `json:"matched"`
+	Trace   NodeTrace `json:"trace"`
+}
+
+// NodeTrace – итог узла логического дерева и трассировка его условий и подузлов.
+type NodeTrace struct {
+	Operator   string           `json:"operator"`
+	Result     bool             `json:"result"`
+	Conditions []ConditionTrace `json:"conditions"`
+	Children   []NodeTrace      `json:"children"`
+}
+
+// ConditionTrace – значение поля в событии (actual), ожидаемое значение из правила (expected) и результат условия.
+type ConditionTrace struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Expected interface{} `json:"expected"`
+	Actual   interface{} `json:"actual"`
+	Result   bool        `json:"result"`
 }
 
 type RuleByIdRequest struct {
@@ -56,8 +56,10 @@ func serializeUsedRules(rules []logs_errors.UsedRule) []types.UsedRule {
 	result := make([]types.UsedRule, len(rules))
 	for i, rule := range rules {
 		result[i] = types.UsedRule{
-			RuleId:   rule.RuleId,
-			RuleName: rule.RuleName,
+			RuleId:     rule.RuleId,
+			RuleName:   rule.RuleName,
+			Suppressed: rule.Suppressed,
+			SilenceID:  rule.SilenceId,
 		}
 	}
 	return result
@@ -0,0 +1,57 @@
+package projects
+
+import (
+	types "aletheia-public-api/interfaces/types/v1"
+	transitionsRepo "aletheia-public-api/internal/dataproviders/timescale/repositories/alert_transitions"
+	"context"
+	"fmt"
+)
+
+const (
+	// defaultTransitionsPastHours – период истории алертов, если pastHours не задан
+	defaultTransitionsPastHours = 24
+	// maxTransitionsPastHours – переходы старше недели в истории не нужны
+	maxTransitionsPastHours = 7 * 24
+)
+
+// AlertTransitionsUsecase описывает чтение истории состояний алертов проекта.
+type AlertTransitionsUsecase interface {
+	GetAlertTransitions(ctx context.Context, userId int64, projectID string, pastHours int, ruleID string) ([]types.AlertTransition, error)
+}
+
+type alertTransitionsUsecase struct {
+	transitionsRepo transitionsRepo.Provider
+}
+
+// NewAlertTransitionsUsecase создаёт usecase истории алертов. Переходы пишет движок ресурсов в TimescaleDB.
+func NewAlertTransitionsUsecase(provider transitionsRepo.Provider) AlertTransitionsUsecase {
+	return &alertTransitionsUsecase{transitionsRepo: provider}
+}
+
+func (uc *alertTransitionsUsecase) GetAlertTransitions(ctx context.Context, userId int64, projectID string, pastHours int, ruleID string) ([]types.AlertTransition, error) {
+	if pastHours == 0 {
+		pastHours = defaultTransitionsPastHours
+	}
+	if pastHours < 0 || pastHours > maxTransitionsPastHours {
+		return nil, fmt.Errorf("pastHours must be between 1 and %d", maxTransitionsPastHours)
+	}
+
+	transitions, err := uc.transitionsRepo.GetTransitions(ctx, userId, projectID, pastHours, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.AlertTransition, 0, len(transitions))
+	for _, t := range transitions {
+		result = append(result, types.AlertTransition{
+			Timestamp:   t.Timestamp,
+			RuleID:      t.RuleId,
+			RuleName:    t.RuleName,
+			ServiceName: t.ServiceName,
+			Environment: t.Environment,
+			Instance:    t.Instance,
+			State:       t.State,
+		})
+	}
+	return result, nil
+}
@@ -4,18 +4,27 @@ import (
 	v1 "aletheia-public-api/interfaces/types/v1"
 	"aletheia-public-api/internal/api/v1/events"
 	"aletheia-public-api/internal/dataproviders/postgres"
+	maintenanceRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/maintenance_windows"
+	monitorsRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/monitors"
 	projectsRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/projects"
+	"aletheia-public-api/internal/dataproviders/postgres/repositories/rule_changes"
+	silencesRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/silences"
 	"aletheia-public-api/internal/dataproviders/timescale"
+	transitionsRepo "aletheia-public-api/internal/dataproviders/timescale/repositories/alert_transitions"
 	"aletheia-public-api/internal/dataproviders/timescale/repositories/logs_errors"
 	"context"
 	"fmt"
 )
 
 type Projects struct {
-	projectUsecase   ProjectsUsecase
-	eventsUsecase    events.EventsUsecase
-	serializer       ProjectSerializer
-	eventsSerializer events.Serializer
+	projectUsecase     ProjectsUsecase
+	monitorsUsecase    MonitorsUsecase
+	maintenanceUsecase MaintenanceWindowsUsecase
+	silencesUsecase    SilencesUsecase
+	transitionsUsecase AlertTransitionsUsecase
+	eventsUsecase      events.EventsUsecase
+	serializer         ProjectSerializer
+	eventsSerializer   events.Serializer
 }
 
 // NewProjects создаёт новый обработчик, инициализируя usecase и сериализаторы.
@@ -23,17 +32,21 @@ type Projects struct {
 func NewProjects() *Projects {
 	pgConn := postgres.GlobalInstance
 	provider := projectsRepo.NewProvider(pgConn)
-	usecase := NewProjectsUsecase(provider)
+	usecase := NewProjectsUsecase(provider, rule_changes.NewProvider(pgConn))
 	serializer := NewProjectSerializer()
 	logsErrorRepo := logs_errors.NewProvider(timescale.GlobalInstance)
 	eventsUsecase := events.NewEventsUsecase(logsErrorRepo)
 	eventsSerializer := events.NewSerializer()
 
 	return &Projects{
-		projectUsecase:   usecase,
-		serializer:       serializer,
-		eventsSerializer: eventsSerializer,
-		eventsUsecase:    eventsUsecase,
+		projectUsecase:     usecase,
+		monitorsUsecase:    NewMonitorsUsecase(monitorsRepo.NewProvider(pgConn)),
+		maintenanceUsecase: NewMaintenanceWindowsUsecase(maintenanceRepo.NewProvider(pgConn)),
+		silencesUsecase:    NewSilencesUsecase(silencesRepo.NewProvider(pgConn), rule_changes.NewProvider(pgConn)),
+		transitionsUsecase: NewAlertTransitionsUsecase(transitionsRepo.NewProvider(timescale.GlobalInstance)),
+		serializer:         serializer,
+		eventsSerializer:   eventsSerializer,
+		eventsUsecase:      eventsUsecase,
 	}
 }
 
@@ -104,3 +117,95 @@ func (p *Projects) UpdateProject(ctx context.Context, project *v1.UpdateProjectR
 	}
 	return true, nil
 }
+
+// GetMonitors возвращает мониторы cron-задач проекта.
+func (p *Projects) GetMonitors(ctx context.Context, projectID string, userId int64) (v1.MonitorsResponse, error) {
+	monitors, err := p.monitorsUsecase.GetMonitors(ctx, userId, projectID)
+	if err != nil {
+		return v1.MonitorsResponse{}, err
+	}
+	return v1.MonitorsResponse{Monitors: monitors}, nil
+}
+
+func (p *Projects) CreateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, userId int64) (status bool, err error) {
+	if err = p.monitorsUsecase.CreateMonitor(ctx, userId, projectID, monitor); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *Projects) UpdateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, monitorID string, userId int64) (status bool, err error) {
+	if err = p.monitorsUsecase.UpdateMonitor(ctx, userId, projectID, monitorID, monitor); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *Projects) DeleteMonitor(ctx context.Context, projectID string, monitorID string, userId int64) (status bool, err error) {
+	if err = p.monitorsUsecase.DeleteMonitor(ctx, userId, projectID, monitorID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetMaintenanceWindows возвращает окна обслуживания проекта.
+func (p *Projects) GetMaintenanceWindows(ctx context.Context, projectID string, userId int64) (v1.MaintenanceWindowsResponse, error) {
+	windows, err := p.maintenanceUsecase.GetMaintenanceWindows(ctx, userId, projectID)
+	if err != nil {
+		return v1.MaintenanceWindowsResponse{}, err
+	}
+	return v1.MaintenanceWindowsResponse{MaintenanceWindows: windows}, nil
+}
+
+func (p *Projects) CreateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, userId int64) (status bool, err error) {
+	if err = p.maintenanceUsecase.CreateMaintenanceWindow(ctx, userId, projectID, window); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *Projects) UpdateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, windowID string, userId int64) (status bool, err error) {
+	if err = p.maintenanceUsecase.UpdateMaintenanceWindow(ctx, userId, projectID, windowID, window); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *Projects) DeleteMaintenanceWindow(ctx context.Context, projectID string, windowID string, userId int64) (status bool, err error) {
+	if err = p.maintenanceUsecase.DeleteMaintenanceWindow(ctx, userId, projectID, windowID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetSilences возвращает silences проекта: активные и истёкшие за последнюю неделю.
+func (p *Projects) GetSilences(ctx context.Context, projectID string, userId int64) (v1.SilencesResponse, error) {
+	silences, err := p.silencesUsecase.GetSilences(ctx, userId, projectID)
+	if err != nil {
+		return v1.SilencesResponse{}, err
+	}
+	return v1.SilencesResponse{Silences: silences}, nil
+}
+
+func (p *Projects) CreateSilence(ctx context.Context, silence *v1.SilenceRequest, projectID string, userId int64) (status bool, err error) {
+	if err = p.silencesUsecase.CreateSilence(ctx, userId, projectID, silence); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *Projects) ExpireSilence(ctx context.Context, projectID string, silenceID string, userId int64) (status bool, err error) {
+	if err = p.silencesUsecase.ExpireSilence(ctx, userId, projectID, silenceID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetAlertTransitions возвращает историю состояний алертов проекта за последние pastHours часов.
+func (p *Projects) GetAlertTransitions(ctx context.Context, projectID string, pastHours int, ruleId string, userId int64) (v1.AlertTransitionsResponse, error) {
+	transitions, err := p.transitionsUsecase.GetAlertTransitions(ctx, userId, projectID, pastHours, ruleId)
+	if err != nil {
+		return v1.AlertTransitionsResponse{}, err
+	}
+	return v1.AlertTransitionsResponse{Transitions: transitions}, nil
+}
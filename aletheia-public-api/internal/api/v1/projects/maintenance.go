@@ -0,0 +1,118 @@
+package projects
+
+import (
+	types "aletheia-public-api/interfaces/types/v1"
+	"aletheia-public-api/internal/api/v1/rules"
+	maintenanceRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/maintenance_windows"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// MaintenanceWindowsUsecase описывает работу с окнами обслуживания проекта.
+type MaintenanceWindowsUsecase interface {
+	GetMaintenanceWindows(ctx context.Context, userId int64, projectID string) ([]types.MaintenanceWindow, error)
+	CreateMaintenanceWindow(ctx context.Context, userId int64, projectID string, window *types.MaintenanceWindowRequest) error
+	UpdateMaintenanceWindow(ctx context.Context, userId int64, projectID, windowID string, window *types.MaintenanceWindowRequest) error
+	DeleteMaintenanceWindow(ctx context.Context, userId int64, projectID, windowID string) error
+}
+
+type maintenanceWindowsUsecase struct {
+	maintenanceRepo maintenanceRepo.Provider
+}
+
+// NewMaintenanceWindowsUsecase создаёт usecase окон обслуживания. Движки перечитывают окна
+// проекта сами (кэш на 30 секунд), поэтому уведомление об изменении не публикуется.
+func NewMaintenanceWindowsUsecase(provider maintenanceRepo.Provider) MaintenanceWindowsUsecase {
+	return &maintenanceWindowsUsecase{maintenanceRepo: provider}
+}
+
+func (uc *maintenanceWindowsUsecase) GetMaintenanceWindows(ctx context.Context, userId int64, projectID string) ([]types.MaintenanceWindow, error) {
+	windows, err := uc.maintenanceRepo.GetMaintenanceWindows(ctx, userId, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.MaintenanceWindow, 0, len(windows))
+	for _, w := range windows {
+		mw := types.MaintenanceWindow{
+			ID:   w.ID,
+			Name: w.Name,
+		}
+		if w.StartsAt.Valid {
+			mw.StartsAt = &w.StartsAt.Time
+		}
+		if w.EndsAt.Valid {
+			mw.EndsAt = &w.EndsAt.Time
+		}
+		if len(w.Schedule) > 0 {
+			var schedule types.RuleSchedule
+			if err := json.Unmarshal(w.Schedule, &schedule); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal schedule of maintenance window %s: %w", w.ID, err)
+			}
+			mw.Schedule = &schedule
+		}
+		result = append(result, mw)
+	}
+	return result, nil
+}
+
+func (uc *maintenanceWindowsUsecase) CreateMaintenanceWindow(ctx context.Context, userId int64, projectID string, window *types.MaintenanceWindowRequest) error {
+	w, err := buildMaintenanceWindow(userId, projectID, window)
+	if err != nil {
+		return err
+	}
+	return uc.maintenanceRepo.CreateMaintenanceWindow(ctx, w)
+}
+
+func (uc *maintenanceWindowsUsecase) UpdateMaintenanceWindow(ctx context.Context, userId int64, projectID, windowID string, window *types.MaintenanceWindowRequest) error {
+	w, err := buildMaintenanceWindow(userId, projectID, window)
+	if err != nil {
+		return err
+	}
+	w.ID = windowID
+	return uc.maintenanceRepo.UpdateMaintenanceWindow(ctx, w)
+}
+
+func (uc *maintenanceWindowsUsecase) DeleteMaintenanceWindow(ctx context.Context, userId int64, projectID, windowID string) error {
+	return uc.maintenanceRepo.DeleteMaintenanceWindow(ctx, userId, projectID, windowID)
+}
+
+// buildMaintenanceWindow проверяет запрос и приводит его к модели репозитория.
+// Окно задаётся интервалом starts_at/ends_at, расписанием или и тем и другим.
+func buildMaintenanceWindow(userId int64, projectID string, req *types.MaintenanceWindowRequest) (maintenanceRepo.MaintenanceWindow, error) {
+	if req == nil {
+		return maintenanceRepo.MaintenanceWindow{}, fmt.Errorf("maintenance window is nil")
+	}
+	if (req.StartsAt == nil) != (req.EndsAt == nil) {
+		return maintenanceRepo.MaintenanceWindow{}, fmt.Errorf("starts_at and ends_at must be set together")
+	}
+	if req.StartsAt == nil && req.Schedule == nil {
+		return maintenanceRepo.MaintenanceWindow{}, fmt.Errorf("either starts_at/ends_at or schedule is required")
+	}
+	if req.StartsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return maintenanceRepo.MaintenanceWindow{}, fmt.Errorf("ends_at must be after starts_at")
+	}
+	if err := rules.ValidateSchedule(req.Schedule); err != nil {
+		return maintenanceRepo.MaintenanceWindow{}, err
+	}
+
+	w := maintenanceRepo.MaintenanceWindow{
+		ProjectId: projectID,
+		UserId:    userId,
+		Name:      req.Name,
+	}
+	if req.StartsAt != nil {
+		w.StartsAt = sql.NullTime{Time: *req.StartsAt, Valid: true}
+		w.EndsAt = sql.NullTime{Time: *req.EndsAt, Valid: true}
+	}
+	if req.Schedule != nil {
+		raw, err := json.Marshal(req.Schedule)
+		if err != nil {
+			return maintenanceRepo.MaintenanceWindow{}, fmt.Errorf("failed to marshal schedule: %w", err)
+		}
+		w.Schedule = raw
+	}
+	return w, nil
+}
@@ -0,0 +1,165 @@
+package projects
+
+import (
+	types "aletheia-public-api/interfaces/types/v1"
+	monitorsRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/monitors"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	ScheduleCron     = "cron"
+	ScheduleInterval = "interval"
+
+	// minMonitorIntervalSec – планировщик движка проверяет мониторы раз в 30 секунд,
+	// интервалы короче минуты он не различит
+	minMonitorIntervalSec = 60
+)
+
+var (
+	monitorSlugRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	// monitorCronParser – тот же разбор, что у планировщика движка: стандартный cron из 5 полей,
+	// дескрипторы (@daily, @hourly, ...) и префикс CRON_TZ=
+	monitorCronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+)
+
+// MonitorsUsecase описывает работу с мониторами cron-задач проекта.
+type MonitorsUsecase interface {
+	GetMonitors(ctx context.Context, userId int64, projectID string) ([]types.Monitor, error)
+	CreateMonitor(ctx context.Context, userId int64, projectID string, monitor *types.MonitorRequest) error
+	UpdateMonitor(ctx context.Context, userId int64, projectID, monitorID string, monitor *types.MonitorRequest) error
+	DeleteMonitor(ctx context.Context, userId int64, projectID, monitorID string) error
+}
+
+type monitorsUsecase struct {
+	monitorsRepo monitorsRepo.Provider
+}
+
+// NewMonitorsUsecase создаёт usecase мониторов. Движок перечитывает мониторы сам,
+// поэтому уведомление об изменении не публикуется.
+func NewMonitorsUsecase(provider monitorsRepo.Provider) MonitorsUsecase {
+	return &monitorsUsecase{monitorsRepo: provider}
+}
+
+func (uc *monitorsUsecase) GetMonitors(ctx context.Context, userId int64, projectID string) ([]types.Monitor, error) {
+	monitors, err := uc.monitorsRepo.GetMonitors(ctx, userId, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Monitor, 0, len(monitors))
+	for _, m := range monitors {
+		var actions []types.Action
+		if len(m.Actions) > 0 {
+			if err := json.Unmarshal(m.Actions, &actions); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal actions of monitor %s: %w", m.ID, err)
+			}
+		}
+		result = append(result, types.Monitor{
+			ID:           m.ID,
+			Slug:         m.Slug,
+			Name:         m.Name,
+			ScheduleType: m.ScheduleType,
+			CronExpr:     m.CronExpr,
+			IntervalSec:  m.IntervalSec,
+			GraceSec:     m.GraceSec,
+			Timezone:     m.Timezone,
+			Actions:      actions,
+			Enabled:      m.Enabled,
+		})
+	}
+	return result, nil
+}
+
+func (uc *monitorsUsecase) CreateMonitor(ctx context.Context, userId int64, projectID string, monitor *types.MonitorRequest) error {
+	m, err := buildMonitor(userId, projectID, monitor)
+	if err != nil {
+		return err
+	}
+	return uc.monitorsRepo.CreateMonitor(ctx, m)
+}
+
+func (uc *monitorsUsecase) UpdateMonitor(ctx context.Context, userId int64, projectID, monitorID string, monitor *types.MonitorRequest) error {
+	m, err := buildMonitor(userId, projectID, monitor)
+	if err != nil {
+		return err
+	}
+	m.ID = monitorID
+	return uc.monitorsRepo.UpdateMonitor(ctx, m)
+}
+
+func (uc *monitorsUsecase) DeleteMonitor(ctx context.Context, userId int64, projectID, monitorID string) error {
+	return uc.monitorsRepo.DeleteMonitor(ctx, userId, projectID, monitorID)
+}
+
+// buildMonitor проверяет запрос и приводит его к модели репозитория.
+func buildMonitor(userId int64, projectID string, req *types.MonitorRequest) (monitorsRepo.Monitor, error) {
+	if req == nil {
+		return monitorsRepo.Monitor{}, fmt.Errorf("monitor is nil")
+	}
+	if !monitorSlugRe.MatchString(req.Slug) {
+		return monitorsRepo.Monitor{}, fmt.Errorf("invalid monitor slug %q: expected lowercase letters, digits, '-' or '_'", req.Slug)
+	}
+	if req.GraceSec < 0 {
+		return monitorsRepo.Monitor{}, fmt.Errorf("grace_sec must not be negative")
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return monitorsRepo.Monitor{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	m := monitorsRepo.Monitor{
+		ProjectId:    projectID,
+		UserId:       userId,
+		Slug:         req.Slug,
+		Name:         req.Name,
+		ScheduleType: req.ScheduleType,
+		GraceSec:     req.GraceSec,
+		Timezone:     timezone,
+		Enabled:      req.Enabled == nil || *req.Enabled,
+	}
+
+	switch req.ScheduleType {
+	case ScheduleCron:
+		if err := validateCronExpr(req.CronExpr); err != nil {
+			return monitorsRepo.Monitor{}, err
+		}
+		m.CronExpr = strings.TrimSpace(req.CronExpr)
+	case ScheduleInterval:
+		if req.IntervalSec < minMonitorIntervalSec {
+			return monitorsRepo.Monitor{}, fmt.Errorf("interval_sec must be at least %d", minMonitorIntervalSec)
+		}
+		m.IntervalSec = req.IntervalSec
+	default:
+		return monitorsRepo.Monitor{}, fmt.Errorf("invalid schedule_type %q: expected %q or %q", req.ScheduleType, ScheduleCron, ScheduleInterval)
+	}
+
+	actions := req.Actions
+	if actions == nil {
+		actions = []types.Action{}
+	}
+	raw, err := json.Marshal(actions)
+	if err != nil {
+		return monitorsRepo.Monitor{}, fmt.Errorf("failed to marshal actions: %w", err)
+	}
+	m.Actions = raw
+	return m, nil
+}
+
+// validateCronExpr разбирает выражение парсером движка: монитор, сохранённый API, движок сможет запланировать.
+func validateCronExpr(expr string) error {
+	if _, err := monitorCronParser.Parse(strings.TrimSpace(expr)); err != nil {
+		return fmt.Errorf("invalid cron_expr %q: %w", expr, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package projects
+
+import (
+	types "aletheia-public-api/interfaces/types/v1"
+	"aletheia-public-api/internal/dataproviders/postgres/repositories/rule_changes"
+	silencesRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/silences"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// silenceMaxDuration – silence дольше месяца почти всегда забытый: такой шум лучше убрать в правиле
+const silenceMaxDuration = 30 * 24 * time.Hour
+
+// SilencesUsecase описывает работу с silences проекта.
+type SilencesUsecase interface {
+	GetSilences(ctx context.Context, userId int64, projectID string) ([]types.Silence, error)
+	CreateSilence(ctx context.Context, userId int64, projectID string, silence *types.SilenceRequest) error
+	ExpireSilence(ctx context.Context, userId int64, projectID, silenceID string) error
+}
+
+type silencesUsecase struct {
+	silencesRepo silencesRepo.Provider
+	ruleChanges  rule_changes.Provider
+}
+
+// NewSilencesUsecase создаёт usecase silences. Движки кешируют активные silences проекта,
+// после создания или истечения silence они получают уведомление и сбрасывают кеш проекта.
+func NewSilencesUsecase(provider silencesRepo.Provider, ruleChanges rule_changes.Provider) SilencesUsecase {
+	return &silencesUsecase{silencesRepo: provider, ruleChanges: ruleChanges}
+}
+
+func (uc *silencesUsecase) GetSilences(ctx context.Context, userId int64, projectID string) ([]types.Silence, error) {
+	silences, err := uc.silencesRepo.GetSilences(ctx, userId, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]types.Silence, 0, len(silences))
+	for _, s := range silences {
+		var matchers types.SilenceMatchers
+		if err := json.Unmarshal(s.Matchers, &matchers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal matchers of silence %s: %w", s.ID, err)
+		}
+		result = append(result, types.Silence{
+			ID:        s.ID,
+			Matchers:  matchers,
+			StartsAt:  s.StartsAt,
+			EndsAt:    s.EndsAt,
+			CreatedBy: s.CreatedBy,
+			Comment:   s.Comment,
+			Active:    !now.Before(s.StartsAt) && now.Before(s.EndsAt),
+		})
+	}
+	return result, nil
+}
+
+func (uc *silencesUsecase) CreateSilence(ctx context.Context, userId int64, projectID string, silence *types.SilenceRequest) error {
+	s, err := buildSilence(userId, projectID, silence, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := uc.silencesRepo.CreateSilence(ctx, s); err != nil {
+		return err
+	}
+	uc.publishSilencesChange(ctx, projectID)
+	return nil
+}
+
+func (uc *silencesUsecase) ExpireSilence(ctx context.Context, userId int64, projectID, silenceID string) error {
+	if err := uc.silencesRepo.ExpireSilence(ctx, userId, projectID, silenceID); err != nil {
+		return err
+	}
+	uc.publishSilencesChange(ctx, projectID)
+	return nil
+}
+
+// publishSilencesChange просит оба движка сбросить кеш silences проекта.
+// Ошибка только логируется: в худшем случае кеш истечёт по TTL.
+func (uc *silencesUsecase) publishSilencesChange(ctx context.Context, projectID string) {
+	if err := uc.ruleChanges.PublishSilences(ctx, projectID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("projectId", projectID).Msg("failed to publish silences change")
+	}
+}
+
+// buildSilence проверяет запрос и приводит его к модели репозитория.
+func buildSilence(userId int64, projectID string, req *types.SilenceRequest, now time.Time) (silencesRepo.Silence, error) {
+	if req == nil {
+		return silencesRepo.Silence{}, fmt.Errorf("silence is nil")
+	}
+	if err := validateSilenceMatchers(req.Matchers); err != nil {
+		return silencesRepo.Silence{}, err
+	}
+	if strings.TrimSpace(req.Comment) == "" {
+		return silencesRepo.Silence{}, fmt.Errorf("comment is required")
+	}
+
+	var endsAt time.Time
+	switch {
+	case req.EndsAt != nil && req.DurationSec != 0:
+		return silencesRepo.Silence{}, fmt.Errorf("either ends_at or duration_sec must be set, not both")
+	case req.EndsAt != nil:
+		endsAt = *req.EndsAt
+	case req.DurationSec > 0:
+		endsAt = now.Add(time.Duration(req.DurationSec) * time.Second)
+	default:
+		return silencesRepo.Silence{}, fmt.Errorf("ends_at or positive duration_sec is required")
+	}
+	if !endsAt.After(now) {
+		return silencesRepo.Silence{}, fmt.Errorf("ends_at must be in the future")
+	}
+	if endsAt.Sub(now) > silenceMaxDuration {
+		return silencesRepo.Silence{}, fmt.Errorf("silence must not last longer than %s", silenceMaxDuration)
+	}
+
+	matchers, err := json.Marshal(req.Matchers)
+	if err != nil {
+		return silencesRepo.Silence{}, fmt.Errorf("failed to marshal matchers: %w", err)
+	}
+	return silencesRepo.Silence{
+		ProjectId: projectID,
+		UserId:    userId,
+		Matchers:  matchers,
+		EndsAt:    endsAt,
+		CreatedBy: strconv.FormatInt(userId, 10),
+		Comment:   req.Comment,
+	}, nil
+}
+
+// validateSilenceMatchers требует хотя бы одно условие: заглушить весь проект – задача окна обслуживания.
+func validateSilenceMatchers(m types.SilenceMatchers) error {
+	if m.RuleID == "" && m.ServiceName == "" && m.Environment == "" && m.ErrorMessage == "" && len(m.Tags) == 0 {
+		return fmt.Errorf("silence must have at least one matcher")
+	}
+	if m.ErrorMessage != "" {
+		if _, err := regexp.Compile(m.ErrorMessage); err != nil {
+			return fmt.Errorf("invalid error_message pattern %q: %w", m.ErrorMessage, err)
+		}
+	}
+	for k := range m.Tags {
+		if k == "" {
+			return fmt.Errorf("silence tag matcher must have a key")
+		}
+	}
+	return nil
+}
@@ -3,7 +3,10 @@ package projects
 import (
 	types "aletheia-public-api/interfaces/types/v1"
 	projectsRepo "aletheia-public-api/internal/dataproviders/postgres/repositories/projects"
+	"aletheia-public-api/internal/dataproviders/postgres/repositories/rule_changes"
 	"context"
+
+	"github.com/rs/zerolog/log"
 )
 
 // ProjectsUsecase описывает методы для получения проектов.
@@ -17,12 +20,14 @@ type ProjectsUsecase interface {
 
 type projectsUsecase struct {
 	projectsRepo projectsRepo.Provider
+	ruleChanges  rule_changes.Provider
 }
 
 // NewProjectsUsecase создаёт usecase с инъекцией репозитория.
-func NewProjectsUsecase(provider projectsRepo.Provider) ProjectsUsecase {
+func NewProjectsUsecase(provider projectsRepo.Provider, ruleChanges rule_changes.Provider) ProjectsUsecase {
 	return &projectsUsecase{
 		projectsRepo: provider,
+		ruleChanges:  ruleChanges,
 	}
 }
 
@@ -54,13 +59,20 @@ func (uc *projectsUsecase) CreateProject(ctx context.Context, userId int64, proj
 		Services:    servs,
 	}
 	err := uc.projectsRepo.CreateProject(ctx, req, userId)
+	if err == nil {
+		uc.publishRuleChange(ctx, userId)
+	}
 
 	return err
 }
 
 func (uc *projectsUsecase) DeleteProjectById(ctx context.Context, userId int64, projectID string) error {
 	req := projectsRepo.Request{UserId: userId, ProjectId: projectID}
-	return uc.projectsRepo.DeleteProjectById(ctx, req)
+	if err := uc.projectsRepo.DeleteProjectById(ctx, req); err != nil {
+		return err
+	}
+	uc.publishRuleChange(ctx, userId)
+	return nil
 }
 
 func (uc *projectsUsecase) UpdateProject(ctx context.Context, project *types.UpdateProjectRequest, projectID string, userId int64) error {
@@ -79,5 +91,18 @@ func (uc *projectsUsecase) UpdateProject(ctx context.Context, project *types.Upd
 		ProjectId:   projectID,
 	}
 
-	return uc.projectsRepo.UpdateProject(ctx, req, userId)
+	if err := uc.projectsRepo.UpdateProject(ctx, req, userId); err != nil {
+		return err
+	}
+	uc.publishRuleChange(ctx, userId)
+	return nil
+}
+
+// publishRuleChange просит оба движка сбросить кеш правил пользователя:
+// сервисы проекта и привязанные к ним правила могли измениться.
+// Ошибка только логируется: в худшем случае кеш истечёт по TTL.
+func (uc *projectsUsecase) publishRuleChange(ctx context.Context, userId int64) {
+	if err := uc.ruleChanges.Publish(ctx, userId, ""); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("userId", userId).Msg("failed to publish rule change")
+	}
 }
@@ -0,0 +1,56 @@
+package rules
+
+import (
+	v1 "aletheia-public-api/interfaces/types/v1"
+	"fmt"
+)
+
+const (
+	correlationMaxWindowSec = 86400
+	correlationMaxSteps     = 10
+)
+
+// validateCorrelation проверяет корреляционное правило до записи в базу:
+// движки читают его как есть, без повторной проверки.
+func validateCorrelation(spec *v1.CorrelationSpec) error {
+	if spec == nil {
+		return fmt.Errorf("correlation is required for correlation rule")
+	}
+	if spec.ProjectId == "" {
+		return fmt.Errorf("correlation.projectId is required")
+	}
+	switch spec.Kind {
+	case "sequence", "co_occurrence":
+	default:
+		return fmt.Errorf("invalid correlation kind %q: expected sequence or co_occurrence", spec.Kind)
+	}
+	if spec.WindowSec < 1 || spec.WindowSec > correlationMaxWindowSec {
+		return fmt.Errorf("correlation.window_sec must be between 1 and %d", correlationMaxWindowSec)
+	}
+	if len(spec.Steps) == 0 || len(spec.Steps) > correlationMaxSteps {
+		return fmt.Errorf("correlation must have from 1 to %d steps", correlationMaxSteps)
+	}
+	if spec.Kind == "sequence" && len(spec.Steps) < 2 {
+		return fmt.Errorf("sequence correlation must have at least 2 steps")
+	}
+	for i, step := range spec.Steps {
+		if step.Engine != "errors" && step.Engine != "resources" {
+			return fmt.Errorf("correlation step %d: invalid engine %q: expected errors or resources", i, step.Engine)
+		}
+		if len(step.RootNode.Conditions) == 0 && len(step.RootNode.Children) == 0 {
+			return fmt.Errorf("correlation step %d: root_node is empty", i)
+		}
+	}
+	if spec.MinDistinct < 0 {
+		return fmt.Errorf("correlation.min_distinct must not be negative")
+	}
+	if spec.MinDistinct > 0 {
+		if spec.Kind != "co_occurrence" {
+			return fmt.Errorf("correlation.min_distinct is supported only for co_occurrence")
+		}
+		if spec.DistinctBy == "" {
+			return fmt.Errorf("correlation.distinct_by is required with min_distinct")
+		}
+	}
+	return nil
+}
@@ -2,9 +2,13 @@ package rules
 
 import (
 	v1 "aletheia-public-api/interfaces/types/v1"
+	"aletheia-public-api/internal/config"
 	"aletheia-public-api/internal/dataproviders/postgres"
+	"aletheia-public-api/internal/dataproviders/postgres/repositories/rule_changes"
+	rulesCorrelation "aletheia-public-api/internal/dataproviders/postgres/repositories/rules_correlation"
 	rulesErrors "aletheia-public-api/internal/dataproviders/postgres/repositories/rules_errors"
 	rulesResources "aletheia-public-api/internal/dataproviders/postgres/repositories/rules_resources"
+	"aletheia-public-api/internal/dataproviders/rule_engine"
 	"context"
 	"fmt"
 	"strconv"
@@ -18,7 +22,11 @@ type Rules struct {
 func NewRules() *Rules {
 	errorRepo := rulesErrors.NewProvider(postgres.GlobalInstance)
 	resourceRepo := rulesResources.NewProvider(postgres.GlobalInstance)
-	usecase := NewRulesUsecase(errorRepo, resourceRepo)
+	correlationRepo := rulesCorrelation.NewProvider(postgres.GlobalInstance)
+	errorsEngine := rule_engine.NewProvider(config.RuleEngine().ErrorsURL, config.RuleEngine().Token)
+	resourcesEngine := rule_engine.NewProvider(config.RuleEngine().ResourcesURL, config.RuleEngine().Token)
+	ruleChanges := rule_changes.NewProvider(postgres.GlobalInstance)
+	usecase := NewRulesUsecase(errorRepo, resourceRepo, correlationRepo, errorsEngine, resourcesEngine, ruleChanges)
 	return &Rules{
 		usecase: usecase,
 	}
@@ -85,3 +93,12 @@ func (r *Rules) UpdateRuleById(ctx context.Context, userId int64, request v1.Upd
 	}
 	return true, nil
 }
+
+// TestRule проверяет правило на примере события и возвращает трассировку (dry-run).
+func (r *Rules) TestRule(ctx context.Context, userId int64, request v1.TestRuleRequest) (result *v1.TestRuleResponse, err error) {
+	res, err := r.usecase.TestRule(ctx, userId, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test rule: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,55 @@
+package rules
+
+import (
+	v1 "aletheia-public-api/interfaces/types/v1"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var scheduleDays = map[string]struct{}{
+	"mon": {}, "tue": {}, "wed": {}, "thu": {}, "fri": {}, "sat": {}, "sun": {},
+}
+
+// ValidateSchedule проверяет расписание правила или окна обслуживания до записи в базу.
+// Движки считают правило с битым расписанием активным всегда, поэтому ошибку нужно вернуть здесь.
+func ValidateSchedule(s *v1.RuleSchedule) error {
+	if s == nil {
+		return nil
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("invalid schedule timezone %q: %w", s.Timezone, err)
+		}
+	}
+	if err := validateClock(s.Start); err != nil {
+		return fmt.Errorf("invalid schedule start: %w", err)
+	}
+	if err := validateClock(s.End); err != nil {
+		return fmt.Errorf("invalid schedule end: %w", err)
+	}
+	for _, d := range s.Days {
+		if _, ok := scheduleDays[strings.ToLower(d)]; !ok {
+			return fmt.Errorf("invalid schedule day %q: expected mon, tue, wed, thu, fri, sat or sun", d)
+		}
+	}
+	return nil
+}
+
+// validateClock проверяет время в формате "HH:MM".
+func validateClock(s string) error {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok || len(h) != 2 || len(m) != 2 {
+		return fmt.Errorf("%q: expected HH:MM", s)
+	}
+	hours, err := strconv.Atoi(h)
+	if err != nil || hours < 0 || hours > 23 {
+		return fmt.Errorf("%q: expected HH:MM", s)
+	}
+	minutes, err := strconv.Atoi(m)
+	if err != nil || minutes < 0 || minutes > 59 {
+		return fmt.Errorf("%q: expected HH:MM", s)
+	}
+	return nil
+}
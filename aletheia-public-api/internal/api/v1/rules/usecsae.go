@@ -2,11 +2,16 @@ package rules
 
 import (
 	v1 "aletheia-public-api/interfaces/types/v1"
+	"aletheia-public-api/internal/dataproviders/postgres/repositories/rule_changes"
+	"aletheia-public-api/internal/dataproviders/postgres/repositories/rules_correlation"
 	"aletheia-public-api/internal/dataproviders/postgres/repositories/rules_errors"
 	"aletheia-public-api/internal/dataproviders/postgres/repositories/rules_resources"
+	"aletheia-public-api/internal/dataproviders/rule_engine"
 	"context"
 	"fmt"
 	"strconv"
+
+	"github.com/rs/zerolog/log"
 )
 
 // RulesUsecase описывает методы для получения проектов.
@@ -17,21 +22,34 @@ type RulesUsecase interface {
 	UpdateRuleById(ctx context.Context, userId int64, request v1.UpdateRuleRequest) error
 	GetRules(ctx context.Context, userId int64) (v1.RulesResponse, error)
 	GetRuleById(ctx context.Context, userId int64, request v1.RuleByIdRequest) (*v1.RuleDetailResponse, error)
+	TestRule(ctx context.Context, userId int64, request v1.TestRuleRequest) (*v1.TestRuleResponse, error)
 }
 
 type rulesUsecase struct {
 	rulesErrorsRepo    rules_errors.Provider
 	rulesResourcesRepo rules_resources.Provider
+	correlationRepo    rules_correlation.Provider
+	errorsEngine       rule_engine.Provider
+	resourcesEngine    rule_engine.Provider
+	ruleChanges        rule_changes.Provider
 }
 
 // NewRulesUsecase  создаёт usecase с инъекцией репозитория.
 func NewRulesUsecase(
 	rulesErrorsRepo rules_errors.Provider,
 	rulesResourcesRepo rules_resources.Provider,
+	correlationRepo rules_correlation.Provider,
+	errorsEngine rule_engine.Provider,
+	resourcesEngine rule_engine.Provider,
+	ruleChanges rule_changes.Provider,
 ) RulesUsecase {
 	return &rulesUsecase{
 		rulesErrorsRepo:    rulesErrorsRepo,
 		rulesResourcesRepo: rulesResourcesRepo,
+		correlationRepo:    correlationRepo,
+		errorsEngine:       errorsEngine,
+		resourcesEngine:    resourcesEngine,
+		ruleChanges:        ruleChanges,
 	}
 }
 
@@ -95,6 +113,7 @@ func (r *rulesUsecase) DeleteRuleById(ctx context.Context, userId int64, request
 		if err != nil {
 			return fmt.Errorf("error deleting error rule: %w", err)
 		}
+		r.publishRuleChange(ctx, userId, request.RuleType)
 		return nil
 	}
 
@@ -103,6 +122,15 @@ func (r *rulesUsecase) DeleteRuleById(ctx context.Context, userId int64, request
 		if err != nil {
 			return fmt.Errorf("error deleting error rule: %w", err)
 		}
+		r.publishRuleChange(ctx, userId, request.RuleType)
+		return nil
+	}
+
+	// Корреляционные правила движки перечитывают по TTL, уведомление не нужно
+	if request.RuleType == "correlation" {
+		if err := r.correlationRepo.DeleteRuleById(ctx, request.RuleId, userId); err != nil {
+			return fmt.Errorf("error deleting correlation rule: %w", err)
+		}
 		return nil
 	}
 	return nil
@@ -112,6 +140,9 @@ func (r *rulesUsecase) CreateRule(ctx context.Context, userId int64, request v1.
 	if request.RuleType == "" {
 		return fmt.Errorf("rule type is required")
 	}
+	if err := ValidateSchedule(request.Schedule); err != nil {
+		return err
+	}
 	if request.RuleType == "errors" {
 		err := r.rulesErrorsRepo.CreateRule(ctx, userId, request)
 		if err != nil {
@@ -125,6 +156,15 @@ func (r *rulesUsecase) CreateRule(ctx context.Context, userId int64, request v1.
 			return fmt.Errorf("error creating resource rule: %w", err)
 		}
 		return nil
+	}
+	if request.RuleType == "correlation" {
+		if err := validateCorrelation(request.Correlation); err != nil {
+			return err
+		}
+		if err := r.correlationRepo.CreateRule(ctx, userId, request); err != nil {
+			return fmt.Errorf("error creating correlation rule: %w", err)
+		}
+		return nil
 	} else {
 		return fmt.Errorf("invalid rule type")
 	}
@@ -134,11 +174,15 @@ func (r *rulesUsecase) UpdateRuleById(ctx context.Context, userId int64, request
 	if request.RuleType == "" {
 		return fmt.Errorf("rule type is required")
 	}
+	if err := ValidateSchedule(request.Schedule); err != nil {
+		return err
+	}
 	if request.RuleType == "errors" {
 		err := r.rulesErrorsRepo.UpdateRuleById(ctx, userId, request)
 		if err != nil {
 			return fmt.Errorf("error updating error rule: %w", err)
 		}
+		r.publishRuleChange(ctx, userId, request.RuleType)
 		return nil
 	}
 	if request.RuleType == "resources" {
@@ -146,6 +190,16 @@ func (r *rulesUsecase) UpdateRuleById(ctx context.Context, userId int64, request
 		if err != nil {
 			return fmt.Errorf("error updating resource rule: %w", err)
 		}
+		r.publishRuleChange(ctx, userId, request.RuleType)
+		return nil
+	}
+	if request.RuleType == "correlation" {
+		if err := validateCorrelation(request.Correlation); err != nil {
+			return err
+		}
+		if err := r.correlationRepo.UpdateRuleById(ctx, userId, request); err != nil {
+			return fmt.Errorf("error updating correlation rule: %w", err)
+		}
 		return nil
 	}
 	return nil
@@ -165,6 +219,12 @@ func (r *rulesUsecase) GetRules(ctx context.Context, userId int64) (v1.RulesResp
 		return v1.RulesResponse{}, fmt.Errorf("failed to get resource rules: %w", err)
 	}
 
+	// Получаем все корреляционные правила для пользователя.
+	correlationRulesData, err := r.correlationRepo.GetCorrelationRulesData(ctx, rules_correlation.Request{UserId: userId})
+	if err != nil {
+		return v1.RulesResponse{}, fmt.Errorf("failed to get correlation rules: %w", err)
+	}
+
 	// Преобразуем данные из репозиториев в единый срез правил для ответа.
 	var combined []*v1.Rule
 	if len(errorRulesData) > 0 {
@@ -197,6 +257,14 @@ func (r *rulesUsecase) GetRules(ctx context.Context, userId int64) (v1.RulesResp
 			})
 		}
 	}
+	for _, rd := range correlationRulesData {
+		combined = append(combined, &v1.Rule{
+			ID:          rd.Id,
+			Name:        rd.RuleName,
+			RuleType:    &rd.RuleType,
+			Description: rd.Description,
+		})
+	}
 	return v1.RulesResponse{Rules: combined}, nil
 }
 
@@ -219,5 +287,62 @@ func (r *rulesUsecase) GetRuleById(ctx context.Context, userId int64, request v1
 		}
 		return res, nil
 	}
+
+	if request.RuleType == "correlation" {
+		res, err := r.correlationRepo.GetRuleById(ctx, request.RuleId, userId)
+		if err != nil {
+			return nil, fmt.Errorf("error getting rule by id: %w", err)
+		}
+		return res, nil
+	}
 	return nil, fmt.Errorf("invalid rule type")
 }
+
+// TestRule – dry-run правила на примере события. Проверяет тот движок, которому принадлежит правило,
+// поэтому результат совпадает с боевой проверкой (кроме repeat_over, который в dry-run не считается).
+func (r *rulesUsecase) TestRule(ctx context.Context, userId int64, request v1.TestRuleRequest) (*v1.TestRuleResponse, error) {
+	if request.RuleType == "" {
+		return nil, fmt.Errorf("rule type is required")
+	}
+
+	var engine rule_engine.Provider
+	switch request.RuleType {
+	case "errors":
+		engine = r.errorsEngine
+	case "resources":
+		engine = r.resourcesEngine
+	default:
+		return nil, fmt.Errorf("invalid rule type")
+	}
+
+	rule := rule_engine.Rule{ID: request.RuleId}
+	if request.RootNode != nil {
+		rule.RootNode = *request.RootNode
+	} else {
+		if request.RuleId == "" {
+			return nil, fmt.Errorf("ruleId or root_node is required")
+		}
+		detail, err := r.GetRuleById(ctx, userId, v1.RuleByIdRequest{RuleId: request.RuleId, RuleType: request.RuleType})
+		if err != nil {
+			return nil, err
+		}
+		if detail == nil {
+			return nil, fmt.Errorf("rule %s not found", request.RuleId)
+		}
+		rule.RootNode = detail.RootNode
+	}
+
+	res, err := engine.Explain(ctx, rule, request.Event)
+	if err != nil {
+		return nil, fmt.Errorf("error testing rule: %w", err)
+	}
+	return res, nil
+}
+
+// publishRuleChange просит движки сбросить кеш правил пользователя.
+// Изменение уже сохранено, поэтому ошибка только логируется: в худшем случае кеш истечёт по TTL.
+func (r *rulesUsecase) publishRuleChange(ctx context.Context, userId int64, ruleType string) {
+	if err := r.ruleChanges.Publish(ctx, userId, ruleType); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("userId", userId).Msg("failed to publish rule change")
+	}
+}
@@ -131,3 +131,25 @@ func Postgres() PostgresConfig {
 	}
 	return *postgresConfig
 }
+
+// RuleEngineConfig – адреса служебных HTTP-серверов движков правил (dry-run правил)
+// и общий с движками токен (HTTP_TOKEN движков).
+type RuleEngineConfig struct {
+	ErrorsURL    string `envconfig:"RULE_ENGINE_ERRORS_URL" default:"http://rule-engine-errors:8090"`
+	ResourcesURL string `envconfig:"RULE_ENGINE_RESOURCES_URL" default:"http://rule-engine-resources:8090"`
+	Token        string `envconfig:"RULE_ENGINE_TOKEN" default:""`
+}
+
+var ruleEngineConfig *RuleEngineConfig
+
+// RuleEngine возвращает адреса движков правил.
+func RuleEngine() RuleEngineConfig {
+	if ruleEngineConfig != nil {
+		return *ruleEngineConfig
+	}
+	ruleEngineConfig = &RuleEngineConfig{}
+	if err := envconfig.Process("", ruleEngineConfig); err != nil {
+		log.Fatal().Err(err).Msg("error processing RuleEngine config")
+	}
+	return *ruleEngineConfig
+}
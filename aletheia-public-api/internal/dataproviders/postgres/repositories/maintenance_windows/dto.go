@@ -0,0 +1,17 @@
+package maintenance_windows
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+// MaintenanceWindow описывает окно обслуживания проекта в Postgres.
+type MaintenanceWindow struct {
+	ID        string          `json:"id"`
+	ProjectId string          `json:"project_id"`
+	UserId    int64           `json:"user_id"`
+	Name      string          `json:"name"`
+	StartsAt  sql.NullTime    `json:"starts_at"`
+	EndsAt    sql.NullTime    `json:"ends_at"`
+	Schedule  json.RawMessage `json:"schedule"` // nil – окно без расписания
+}
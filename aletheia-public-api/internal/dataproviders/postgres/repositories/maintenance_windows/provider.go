@@ -0,0 +1,105 @@
+package maintenance_windows
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type Provider interface {
+	GetMaintenanceWindows(ctx context.Context, userId int64, projectId string) ([]*MaintenanceWindow, error)
+	CreateMaintenanceWindow(ctx context.Context, w MaintenanceWindow) error
+	UpdateMaintenanceWindow(ctx context.Context, w MaintenanceWindow) error
+	DeleteMaintenanceWindow(ctx context.Context, userId int64, projectId, windowId string) error
+}
+
+type postgresProvider struct {
+	conn *sql.DB
+}
+
+func NewProvider(conn *sql.DB) Provider {
+	return &postgresProvider{conn: conn}
+}
+
+func (p *postgresProvider) GetMaintenanceWindows(ctx context.Context, userId int64, projectId string) ([]*MaintenanceWindow, error) {
+	query := `
+SELECT w.id::text, w.project_id::text, w.user_id, w.name, w.starts_at, w.ends_at, w.schedule
+FROM rule_engine.maintenance_windows w
+WHERE w.user_id = $1 AND w.project_id::text = $2
+ORDER BY w.id;
+`
+	rows, err := p.conn.QueryContext(ctx, query, userId, projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var windows []*MaintenanceWindow
+	for rows.Next() {
+		var w MaintenanceWindow
+		if err = rows.Scan(&w.ID, &w.ProjectId, &w.UserId, &w.Name, &w.StartsAt, &w.EndsAt, &w.Schedule); err != nil {
+			return nil, fmt.Errorf("failed to scan maintenance window row: %w", err)
+		}
+		windows = append(windows, &w)
+	}
+	return windows, rows.Err()
+}
+
+// CreateMaintenanceWindow создаёт окно, только если проект принадлежит пользователю.
+func (p *postgresProvider) CreateMaintenanceWindow(ctx context.Context, w MaintenanceWindow) error {
+	query := `
+INSERT INTO rule_engine.maintenance_windows (project_id, user_id, name, starts_at, ends_at, schedule)
+SELECT p.id, $2, $3, $4, $5, $6
+FROM rule_engine.projects p
+WHERE p.id::text = $1 AND p.user_id = $2;
+`
+	result, err := p.conn.ExecContext(ctx, query, w.ProjectId, w.UserId, w.Name, w.StartsAt, w.EndsAt, scheduleArg(w))
+	if err != nil {
+		return fmt.Errorf("failed to create maintenance window '%s': %w", w.Name, err)
+	}
+	return expectAffected(result, fmt.Sprintf("no project found with id %s for user %d", w.ProjectId, w.UserId))
+}
+
+func (p *postgresProvider) UpdateMaintenanceWindow(ctx context.Context, w MaintenanceWindow) error {
+	query := `
+UPDATE rule_engine.maintenance_windows
+SET name = $4, starts_at = $5, ends_at = $6, schedule = $7
+WHERE id::text = $1 AND project_id::text = $2 AND user_id = $3;
+`
+	result, err := p.conn.ExecContext(ctx, query, w.ID, w.ProjectId, w.UserId, w.Name, w.StartsAt, w.EndsAt, scheduleArg(w))
+	if err != nil {
+		return fmt.Errorf("failed to update maintenance window %s: %w", w.ID, err)
+	}
+	return expectAffected(result, fmt.Sprintf("no maintenance window found with id %s for user %d", w.ID, w.UserId))
+}
+
+func (p *postgresProvider) DeleteMaintenanceWindow(ctx context.Context, userId int64, projectId, windowId string) error {
+	query := `
+DELETE FROM rule_engine.maintenance_windows
+WHERE id::text = $1 AND project_id::text = $2 AND user_id = $3;
+`
+	result, err := p.conn.ExecContext(ctx, query, windowId, projectId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to delete maintenance window %s: %w", windowId, err)
+	}
+	return expectAffected(result, fmt.Sprintf("no maintenance window found with id %s for user %d", windowId, userId))
+}
+
+// scheduleArg – окно без расписания пишет в колонку NULL, а не пустую строку.
+func scheduleArg(w MaintenanceWindow) sql.NullString {
+	if len(w.Schedule) == 0 {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(w.Schedule), Valid: true}
+}
+
+func expectAffected(result sql.Result, notFound string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s", notFound)
+	}
+	return nil
+}
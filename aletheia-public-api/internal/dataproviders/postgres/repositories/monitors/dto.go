@@ -0,0 +1,19 @@
+package monitors
+
+import "encoding/json"
+
+// Monitor описывает монитор cron-задачи в Postgres.
+type Monitor struct {
+	ID           string          `json:"id"`
+	ProjectId    string          `json:"project_id"`
+	UserId       int64           `json:"user_id"`
+	Slug         string          `json:"slug"`
+	Name         string          `json:"name"`
+	ScheduleType string          `json:"schedule_type"`
+	CronExpr     string          `json:"cron_expr"`
+	IntervalSec  int             `json:"interval_sec"`
+	GraceSec     int             `json:"grace_sec"`
+	Timezone     string          `json:"timezone"`
+	Actions      json.RawMessage `json:"actions"`
+	Enabled      bool            `json:"enabled"`
+}
@@ -0,0 +1,103 @@
+package monitors
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type Provider interface {
+	GetMonitors(ctx context.Context, userId int64, projectId string) ([]*Monitor, error)
+	CreateMonitor(ctx context.Context, m Monitor) error
+	UpdateMonitor(ctx context.Context, m Monitor) error
+	DeleteMonitor(ctx context.Context, userId int64, projectId, monitorId string) error
+}
+
+type postgresProvider struct {
+	conn *sql.DB
+}
+
+func NewProvider(conn *sql.DB) Provider {
+	return &postgresProvider{conn: conn}
+}
+
+func (p *postgresProvider) GetMonitors(ctx context.Context, userId int64, projectId string) ([]*Monitor, error) {
+	query := `
+SELECT m.id::text, m.project_id::text, m.user_id, m.slug, m.name, m.schedule_type,
+       m.cron_expr, m.interval_sec, m.grace_sec, m.timezone, m.actions, m.enabled
+FROM rule_engine.monitors m
+WHERE m.user_id = $1 AND m.project_id::text = $2
+ORDER BY m.id;
+`
+	rows, err := p.conn.QueryContext(ctx, query, userId, projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var monitors []*Monitor
+	for rows.Next() {
+		var m Monitor
+		if err = rows.Scan(&m.ID, &m.ProjectId, &m.UserId, &m.Slug, &m.Name, &m.ScheduleType,
+			&m.CronExpr, &m.IntervalSec, &m.GraceSec, &m.Timezone, &m.Actions, &m.Enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan monitor row: %w", err)
+		}
+		monitors = append(monitors, &m)
+	}
+	return monitors, rows.Err()
+}
+
+// CreateMonitor создаёт монитор, только если проект принадлежит пользователю.
+func (p *postgresProvider) CreateMonitor(ctx context.Context, m Monitor) error {
+	query := `
+INSERT INTO rule_engine.monitors
+    (project_id, user_id, slug, name, schedule_type, cron_expr, interval_sec, grace_sec, timezone, actions, enabled)
+SELECT p.id, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+FROM rule_engine.projects p
+WHERE p.id::text = $1 AND p.user_id = $2;
+`
+	result, err := p.conn.ExecContext(ctx, query, m.ProjectId, m.UserId, m.Slug, m.Name, m.ScheduleType,
+		m.CronExpr, m.IntervalSec, m.GraceSec, m.Timezone, []byte(m.Actions), m.Enabled)
+	if err != nil {
+		return fmt.Errorf("failed to create monitor '%s': %w", m.Slug, err)
+	}
+	return expectAffected(result, fmt.Sprintf("no project found with id %s for user %d", m.ProjectId, m.UserId))
+}
+
+func (p *postgresProvider) UpdateMonitor(ctx context.Context, m Monitor) error {
+	query := `
+UPDATE rule_engine.monitors
+SET slug = $4, name = $5, schedule_type = $6, cron_expr = $7, interval_sec = $8,
+    grace_sec = $9, timezone = $10, actions = $11, enabled = $12
+WHERE id::text = $1 AND project_id::text = $2 AND user_id = $3;
+`
+	result, err := p.conn.ExecContext(ctx, query, m.ID, m.ProjectId, m.UserId, m.Slug, m.Name, m.ScheduleType,
+		m.CronExpr, m.IntervalSec, m.GraceSec, m.Timezone, []byte(m.Actions), m.Enabled)
+	if err != nil {
+		return fmt.Errorf("failed to update monitor %s: %w", m.ID, err)
+	}
+	return expectAffected(result, fmt.Sprintf("no monitor found with id %s for user %d", m.ID, m.UserId))
+}
+
+func (p *postgresProvider) DeleteMonitor(ctx context.Context, userId int64, projectId, monitorId string) error {
+	query := `
+DELETE FROM rule_engine.monitors
+WHERE id::text = $1 AND project_id::text = $2 AND user_id = $3;
+`
+	result, err := p.conn.ExecContext(ctx, query, monitorId, projectId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to delete monitor %s: %w", monitorId, err)
+	}
+	return expectAffected(result, fmt.Sprintf("no monitor found with id %s for user %d", monitorId, userId))
+}
+
+func expectAffected(result sql.Result, notFound string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s", notFound)
+	}
+	return nil
+}
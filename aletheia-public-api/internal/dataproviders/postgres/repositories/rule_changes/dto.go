@@ -0,0 +1,13 @@
+package rule_changes
+
+// KindSilences – изменились silences проекта ProjectId; пустой Kind – изменились правила пользователя.
+const KindSilences = "silences"
+
+// RuleChange – payload уведомления об изменении правил пользователя.
+// RuleType – "errors" или "resources"; пустой – изменились правила обоих движков (например, проект).
+type RuleChange struct {
+	UserId    int64  `json:"user_id"`
+	RuleType  string `json:"rule_type,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	ProjectId string `json:"project_id,omitempty"`
+}
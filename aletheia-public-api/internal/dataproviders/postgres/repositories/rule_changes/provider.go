@@ -0,0 +1,46 @@
+package rule_changes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// Channel – канал Postgres LISTEN/NOTIFY, который слушают движки правил, чтобы сбросить кеш правил.
+const Channel = "rule_changes"
+
+type Provider interface {
+	// Publish сообщает движкам, что правила пользователя изменились.
+	Publish(ctx context.Context, userId int64, ruleType string) error
+	// PublishSilences сообщает движкам, что silences проекта изменились.
+	PublishSilences(ctx context.Context, projectId string) error
+}
+
+type postgresProvider struct {
+	conn *sql.DB
+}
+
+func NewProvider(conn *sql.DB) Provider {
+	return &postgresProvider{conn: conn}
+}
+
+func (p *postgresProvider) Publish(ctx context.Context, userId int64, ruleType string) error {
+	return p.publish(ctx, RuleChange{UserId: userId, RuleType: ruleType})
+}
+
+func (p *postgresProvider) PublishSilences(ctx context.Context, projectId string) error {
+	return p.publish(ctx, RuleChange{Kind: KindSilences, ProjectId: projectId})
+}
+
+func (p *postgresProvider) publish(ctx context.Context, change RuleChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule change: %w", err)
+	}
+
+	if _, err = p.conn.ExecContext(ctx, `SELECT pg_notify($1, $2);`, Channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to publish rule change: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package rules_correlation
+
+type RuleData struct {
+	Id          string  `json:"id"`
+	RuleName    string  `json:"rule_name"`
+	Description *string `json:"description"`
+	RuleType    string  `json:"rule_type"`
+}
@@ -0,0 +1,159 @@
+package rules_correlation
+
+import (
+	v1 "aletheia-public-api/interfaces/types/v1"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+type Request struct {
+	UserId int64 `json:"user_id"`
+}
+
+type Provider interface {
+	GetCorrelationRulesData(ctx context.Context, req Request) ([]*RuleData, error)
+	DeleteRuleById(ctx context.Context, ruleId string, userId int64) error
+	CreateRule(ctx context.Context, userId int64, request v1.CreateRuleRequest) error
+	GetRuleById(ctx context.Context, ruleId string, userId int64) (*v1.RuleDetailResponse, error)
+	UpdateRuleById(ctx context.Context, userId int64, request v1.UpdateRuleRequest) error
+}
+
+type postgresProvider struct {
+	conn *sql.DB
+}
+
+func NewProvider(conn *sql.DB) Provider {
+	return &postgresProvider{conn: conn}
+}
+
+func (p *postgresProvider) GetCorrelationRulesData(ctx context.Context, req Request) ([]*RuleData, error) {
+	query := `
+		SELECT id::text, COALESCE(name, ''), description
+		FROM rule_engine.correlation_rules
+		WHERE user_id = $1;
+`
+	rows, err := p.conn.QueryContext(ctx, query, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*RuleData
+	for rows.Next() {
+		var rd RuleData
+		if err := rows.Scan(&rd.Id, &rd.RuleName, &rd.Description); err != nil {
+			return nil, err
+		}
+		rd.RuleType = "correlation"
+		results = append(results, &rd)
+	}
+	return results, rows.Err()
+}
+
+func (p *postgresProvider) DeleteRuleById(ctx context.Context, ruleId string, userId int64) error {
+	query := `DELETE FROM rule_engine.correlation_rules WHERE id::text = $1 AND user_id = $2;`
+	_, err := p.conn.ExecContext(ctx, query, ruleId, userId)
+	return err
+}
+
+// CreateRule создаёт корреляционное правило, только если проект принадлежит пользователю.
+func (p *postgresProvider) CreateRule(ctx context.Context, userId int64, request v1.CreateRuleRequest) error {
+	spec := request.Correlation
+	actionsJSON, stepsJSON, err := marshalRule(request.Actions, spec)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO rule_engine.correlation_rules
+		    (user_id, project_id, name, description, kind, steps, window_sec, distinct_by, min_distinct, actions)
+		SELECT $1, p.id, $3, $4, $5, $6, $7, $8, $9, $10
+		FROM rule_engine.projects p
+		WHERE p.id::text = $2 AND p.user_id = $1;
+	`
+	result, err := p.conn.ExecContext(ctx, query, userId, spec.ProjectId, request.RuleName, request.RuleDescription,
+		spec.Kind, stepsJSON, spec.WindowSec, spec.DistinctBy, spec.MinDistinct, actionsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to create correlation rule: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("no project found with id %s for user %d", spec.ProjectId, userId)
+	}
+	return nil
+}
+
+func (p *postgresProvider) GetRuleById(ctx context.Context, ruleId string, userId int64) (*v1.RuleDetailResponse, error) {
+	query := `
+		SELECT COALESCE(name, ''), description, actions, project_id::text, kind, steps, window_sec, distinct_by, min_distinct
+		FROM rule_engine.correlation_rules
+		WHERE id::text = $1 AND user_id = $2;
+	`
+	var (
+		res                    v1.RuleDetailResponse
+		spec                   v1.CorrelationSpec
+		actionsJSON, stepsJSON []byte
+	)
+	err := p.conn.QueryRowContext(ctx, query, ruleId, userId).Scan(&res.Name, &res.Description, &actionsJSON,
+		&spec.ProjectId, &spec.Kind, &stepsJSON, &spec.WindowSec, &spec.DistinctBy, &spec.MinDistinct)
+	if err == sql.ErrNoRows {
+		// Как и у остальных правил: не найдено – nil без ошибки.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule: %w", err)
+	}
+
+	res.Actions = []v1.Action{}
+	if len(actionsJSON) > 0 {
+		if err := json.Unmarshal(actionsJSON, &res.Actions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal actions: %w", err)
+		}
+	}
+	if err := json.Unmarshal(stepsJSON, &spec.Steps); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal steps: %w", err)
+	}
+	ruleType := "correlation"
+	res.RuleType = &ruleType
+	res.Correlation = &spec
+	return &res, nil
+}
+
+// UpdateRuleById обновляет правило; проект правила не меняется.
+func (p *postgresProvider) UpdateRuleById(ctx context.Context, userId int64, request v1.UpdateRuleRequest) error {
+	spec := request.Correlation
+	actionsJSON, stepsJSON, err := marshalRule(request.Actions, spec)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE rule_engine.correlation_rules
+		SET name = $1, description = $2, kind = $3, steps = $4, window_sec = $5,
+		    distinct_by = $6, min_distinct = $7, actions = $8
+		WHERE id::text = $9 AND user_id = $10;
+	`
+	_, err = p.conn.ExecContext(ctx, query, request.RuleName, request.RuleDescription, spec.Kind, stepsJSON,
+		spec.WindowSec, spec.DistinctBy, spec.MinDistinct, actionsJSON, request.RuleId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update correlation rule: %w", err)
+	}
+	return nil
+}
+
+func marshalRule(actions []v1.Action, spec *v1.CorrelationSpec) ([]byte, []byte, error) {
+	actionsJSON, err := json.Marshal(actions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal actions: %w", err)
+	}
+	stepsJSON, err := json.Marshal(spec.Steps)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal steps: %w", err)
+	}
+	return actionsJSON, stepsJSON, nil
+}
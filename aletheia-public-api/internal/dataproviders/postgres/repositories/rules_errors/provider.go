@@ -110,13 +110,18 @@ func (p *postgresProvider) CreateRule(ctx context.Context, userId int64, request
 		return fmt.Errorf("failed to marshal root_node: %w", err)
 	}
 
+	scheduleJSON, err := marshalSchedule(request.Schedule)
+	if err != nil {
+		return err
+	}
+
 	// Выполняем INSERT. Поскольку free‑правило не привязано ни к какому сервису, передаем NULL для service_id.
 	query := `
-		INSERT INTO rule_engine.error_rules (name, actions, root_node, user_id, service_id, description)
-		VALUES ($1, $2, $3, $4, NULL, $5);
+		INSERT INTO rule_engine.error_rules (name, actions, root_node, user_id, service_id, description, cooldown_sec, dedup_key, schedule)
+		VALUES ($1, $2, $3, $4, NULL, $5, $6, $7, $8);
 	`
 
-	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, userId, request.RuleDescription)
+	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, userId, request.RuleDescription, request.CooldownSec, request.DedupKey, scheduleJSON)
 	if err != nil {
 		return fmt.Errorf("failed to create error rule: %w", err)
 	}
@@ -137,6 +142,11 @@ func (p *postgresProvider) UpdateRuleById(ctx context.Context, userId int64, req
 		return fmt.Errorf("failed to marshal root_node: %w", err)
 	}
 
+	scheduleJSON, err := marshalSchedule(request.Schedule)
+	if err != nil {
+		return err
+	}
+
 	// Выполняем INSERT. Поскольку free‑правило не привязано ни к какому сервису, передаем NULL для service_id.
 	//query := `
 	//	INSERT INTO rule_engine.error_rules (name, actions, root_node, user_id, service_id, description)
@@ -144,11 +154,11 @@ func (p *postgresProvider) UpdateRuleById(ctx context.Context, userId int64, req
 	//`
 	query := `
 	update rule_engine.error_rules
-	set name = $1, actions = $2, root_node = $3, description = $4
-	where id = $5 and user_id = $6;
+	set name = $1, actions = $2, root_node = $3, description = $4, cooldown_sec = $5, dedup_key = $6, schedule = $7
+	where id = $8 and user_id = $9;
 	`
 
-	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, request.RuleDescription, request.RuleId, userId)
+	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, request.RuleDescription, request.CooldownSec, request.DedupKey, scheduleJSON, request.RuleId, userId)
 	if err != nil {
 		return fmt.Errorf("failed to create error rule: %w", err)
 	}
@@ -158,7 +168,7 @@ func (p *postgresProvider) UpdateRuleById(ctx context.Context, userId int64, req
 
 func (p *postgresProvider) GetRuleById(ctx context.Context, ruleId string, userId int64) (*v1.RuleDetailResponse, error) {
 	query := `
-		SELECT name, description, actions, root_node 
+		SELECT name, description, actions, root_node, cooldown_sec, dedup_key, schedule
 		FROM rule_engine.error_rules 
 		WHERE id = $1 AND user_id = $2;
 	`
@@ -174,8 +184,8 @@ func (p *postgresProvider) GetRuleById(ctx context.Context, ruleId string, userI
 	}
 
 	var res v1.RuleDetailResponse
-	var actionsJSON, rootNodeJSON []byte
-	if err := rows.Scan(&res.Name, &res.Description, &actionsJSON, &rootNodeJSON); err != nil {
+	var actionsJSON, rootNodeJSON, scheduleJSON []byte
+	if err := rows.Scan(&res.Name, &res.Description, &actionsJSON, &rootNodeJSON, &res.CooldownSec, &res.DedupKey, &scheduleJSON); err != nil {
 		return nil, fmt.Errorf("failed to scan row: %w", err)
 	}
 
@@ -199,8 +209,28 @@ func (p *postgresProvider) GetRuleById(ctx context.Context, ruleId string, userI
 		}
 	}
 
+	if len(scheduleJSON) > 0 {
+		var schedule v1.RuleSchedule
+		if err := json.Unmarshal(scheduleJSON, &schedule); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal schedule: %w", err)
+		}
+		res.Schedule = &schedule
+	}
+
 	res.Actions = actions
 	res.RootNode = rootNode
 
 	return &res, nil
 }
+
+// marshalSchedule сериализует расписание правила; без расписания в колонку пишется NULL.
+func marshalSchedule(s *v1.RuleSchedule) (sql.NullString, error) {
+	if s == nil {
+		return sql.NullString{}, nil
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	return sql.NullString{String: string(raw), Valid: true}, nil
+}
@@ -110,13 +110,18 @@ func (p *postgresProvider) CreateRule(ctx context.Context, userId int64, request
 		return fmt.Errorf("failed to marshal root_node: %w", err)
 	}
 
+	scheduleJSON, err := marshalSchedule(request.Schedule)
+	if err != nil {
+		return err
+	}
+
 	// Выполняем INSERT. Поскольку free‑правило не привязано ни к какому сервису, передаем NULL для service_id.
 	query := `
-		INSERT INTO rule_engine.resource_rules (name, actions, root_node, user_id, service_id,description)
-		VALUES ($1, $2, $3, $4, NULL, $5);
+		INSERT INTO rule_engine.resource_rules (name, actions, root_node, user_id, service_id, description, cooldown_sec, dedup_key, for_samples, for_minutes, schedule)
+		VALUES ($1, $2, $3, $4, NULL, $5, $6, $7, $8, $9, $10);
 	`
 
-	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, userId, request.RuleDescription)
+	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, userId, request.RuleDescription, request.CooldownSec, request.DedupKey, request.ForSamples, request.ForMinutes, scheduleJSON)
 	if err != nil {
 		return fmt.Errorf("failed to create error rule: %w", err)
 	}
@@ -126,7 +131,7 @@ func (p *postgresProvider) CreateRule(ctx context.Context, userId int64, request
 
 func (p *postgresProvider) GetRuleById(ctx context.Context, ruleId string, userId int64) (*v1.RuleDetailResponse, error) {
 	query := `
-		SELECT name, description, actions, root_node 
+		SELECT name, description, actions, root_node, cooldown_sec, dedup_key, for_samples, for_minutes, schedule
 		FROM rule_engine.resource_rules 
 		WHERE id = $1 AND user_id = $2;
 	`
@@ -142,8 +147,8 @@ func (p *postgresProvider) GetRuleById(ctx context.Context, ruleId string, userI
 	}
 
 	var res v1.RuleDetailResponse
-	var actionsJSON, rootNodeJSON []byte
-	if err := rows.Scan(&res.Name, &res.Description, &actionsJSON, &rootNodeJSON); err != nil {
+	var actionsJSON, rootNodeJSON, scheduleJSON []byte
+	if err := rows.Scan(&res.Name, &res.Description, &actionsJSON, &rootNodeJSON, &res.CooldownSec, &res.DedupKey, &res.ForSamples, &res.ForMinutes, &scheduleJSON); err != nil {
 		return nil, fmt.Errorf("failed to scan row: %w", err)
 	}
 
@@ -167,6 +172,14 @@ func (p *postgresProvider) GetRuleById(ctx context.Context, ruleId string, userI
 		}
 	}
 
+	if len(scheduleJSON) > 0 {
+		var schedule v1.RuleSchedule
+		if err := json.Unmarshal(scheduleJSON, &schedule); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal schedule: %w", err)
+		}
+		res.Schedule = &schedule
+	}
+
 	res.Actions = actions
 	res.RootNode = rootNode
 
@@ -186,6 +199,11 @@ func (p *postgresProvider) UpdateRuleById(ctx context.Context, userId int64, req
 		return fmt.Errorf("failed to marshal root_node: %w", err)
 	}
 
+	scheduleJSON, err := marshalSchedule(request.Schedule)
+	if err != nil {
+		return err
+	}
+
 	// Выполняем INSERT. Поскольку free‑правило не привязано ни к какому сервису, передаем NULL для service_id.
 	//query := `
 	//	INSERT INTO rule_engine.error_rules (name, actions, root_node, user_id, service_id, description)
@@ -193,14 +211,26 @@ func (p *postgresProvider) UpdateRuleById(ctx context.Context, userId int64, req
 	//`
 	query := `
 	update rule_engine.resource_rules
-	set name = $1, actions = $2, root_node = $3, description = $4
-	where id = $5 and user_id = $6;
+	set name = $1, actions = $2, root_node = $3, description = $4, cooldown_sec = $5, dedup_key = $6, for_samples = $7, for_minutes = $8, schedule = $9
+	where id = $9 and user_id = $10;
 	`
 
-	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, request.RuleDescription, request.RuleId, userId)
+	_, err = p.conn.ExecContext(ctx, query, request.RuleName, actionsJSON, rootNodeJSON, request.RuleDescription, request.CooldownSec, request.DedupKey, request.ForSamples, request.ForMinutes, scheduleJSON, request.RuleId, userId)
 	if err != nil {
 		return fmt.Errorf("failed to create error rule: %w", err)
 	}
 
 	return nil
 }
+
+// marshalSchedule сериализует расписание правила; без расписания в колонку пишется NULL.
+func marshalSchedule(s *v1.RuleSchedule) (sql.NullString, error) {
+	if s == nil {
+		return sql.NullString{}, nil
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	return sql.NullString{String: string(raw), Valid: true}, nil
+}
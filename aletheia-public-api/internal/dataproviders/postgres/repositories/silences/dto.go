@@ -0,0 +1,18 @@
+package silences
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Silence описывает silence проекта в Postgres.
+type Silence struct {
+	ID        string          `json:"id"`
+	ProjectId string          `json:"project_id"`
+	UserId    int64           `json:"user_id"`
+	Matchers  json.RawMessage `json:"matchers"`
+	StartsAt  time.Time       `json:"starts_at"`
+	EndsAt    time.Time       `json:"ends_at"`
+	CreatedBy string          `json:"created_by"`
+	Comment   string          `json:"comment"`
+}
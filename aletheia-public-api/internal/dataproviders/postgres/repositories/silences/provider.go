@@ -0,0 +1,86 @@
+package silences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type Provider interface {
+	GetSilences(ctx context.Context, userId int64, projectId string) ([]*Silence, error)
+	CreateSilence(ctx context.Context, s Silence) error
+	ExpireSilence(ctx context.Context, userId int64, projectId, silenceId string) error
+}
+
+type postgresProvider struct {
+	conn *sql.DB
+}
+
+func NewProvider(conn *sql.DB) Provider {
+	return &postgresProvider{conn: conn}
+}
+
+// GetSilences возвращает активные silences проекта и истёкшие за последнюю неделю, новые первыми.
+func (p *postgresProvider) GetSilences(ctx context.Context, userId int64, projectId string) ([]*Silence, error) {
+	query := `
+SELECT s.id::text, s.project_id::text, s.user_id, s.matchers, s.starts_at, s.ends_at, s.created_by, s.comment
+FROM rule_engine.silences s
+WHERE s.user_id = $1 AND s.project_id::text = $2 AND s.ends_at > now() - interval '7 days'
+ORDER BY s.id DESC;
+`
+	rows, err := p.conn.QueryContext(ctx, query, userId, projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var silences []*Silence
+	for rows.Next() {
+		var s Silence
+		if err = rows.Scan(&s.ID, &s.ProjectId, &s.UserId, &s.Matchers, &s.StartsAt, &s.EndsAt, &s.CreatedBy, &s.Comment); err != nil {
+			return nil, fmt.Errorf("failed to scan silence row: %w", err)
+		}
+		silences = append(silences, &s)
+	}
+	return silences, rows.Err()
+}
+
+// CreateSilence создаёт silence, только если проект принадлежит пользователю. Silence действует сразу.
+func (p *postgresProvider) CreateSilence(ctx context.Context, s Silence) error {
+	query := `
+INSERT INTO rule_engine.silences (project_id, user_id, matchers, ends_at, created_by, comment)
+SELECT p.id, $2, $3, $4, $5, $6
+FROM rule_engine.projects p
+WHERE p.id::text = $1 AND p.user_id = $2;
+`
+	result, err := p.conn.ExecContext(ctx, query, s.ProjectId, s.UserId, []byte(s.Matchers), s.EndsAt, s.CreatedBy, s.Comment)
+	if err != nil {
+		return fmt.Errorf("failed to create silence: %w", err)
+	}
+	return expectAffected(result, fmt.Sprintf("no project found with id %s for user %d", s.ProjectId, s.UserId))
+}
+
+// ExpireSilence завершает действующий silence сейчас. Запись остаётся: по ней видно, кто и зачем глушил алерты.
+func (p *postgresProvider) ExpireSilence(ctx context.Context, userId int64, projectId, silenceId string) error {
+	query := `
+UPDATE rule_engine.silences
+SET ends_at = now()
+WHERE id::text = $1 AND project_id::text = $2 AND user_id = $3 AND ends_at > now();
+`
+	result, err := p.conn.ExecContext(ctx, query, silenceId, projectId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to expire silence %s: %w", silenceId, err)
+	}
+	return expectAffected(result, fmt.Sprintf("no active silence found with id %s for user %d", silenceId, userId))
+}
+
+func expectAffected(result sql.Result, notFound string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s", notFound)
+	}
+	return nil
+}
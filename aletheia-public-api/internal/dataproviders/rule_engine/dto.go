@@ -0,0 +1,18 @@
+package rule_engine
+
+import v1 "aletheia-public-api/interfaces/types/v1"
+
+// Rule – правило в формате движка (только то, что нужно для dry-run).
+type Rule struct {
+	ID       string  `json:"id"`
+	RootNode v1.Node `json:"root_node"`
+}
+
+type explainRequest struct {
+	Rule  Rule                   `json:"rule"`
+	Event map[string]interface{} `json:"event"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,72 @@
+package rule_engine
+
+import (
+	v1 "aletheia-public-api/interfaces/types/v1"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider обращается к служебному HTTP-серверу движка правил.
+type Provider interface {
+	// Explain проверяет правило на примере события без побочных эффектов (алертов, счётчиков)
+	// и возвращает трассировку по каждому узлу и условию.
+	Explain(ctx context.Context, rule Rule, event map[string]interface{}) (*v1.TestRuleResponse, error)
+}
+
+// tokenHeader – заголовок с общим токеном API и движка (transport.TokenHeader движков).
+const tokenHeader = "X-Rule-Engine-Token"
+
+type httpProvider struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewProvider(baseURL, token string) Provider {
+	return &httpProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (p *httpProvider) Explain(ctx context.Context, rule Rule, event map[string]interface{}) (*v1.TestRuleResponse, error) {
+	body, err := json.Marshal(explainRequest{Rule: rule, Event: event})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal explain request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/rules/explain", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build explain request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set(tokenHeader, p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call rule engine: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+			return nil, fmt.Errorf("rule engine returned status %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("rule engine: %s", errResp.Error)
+	}
+
+	var res v1.TestRuleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode explain response: %w", err)
+	}
+	return &res, nil
+}
@@ -0,0 +1,15 @@
+package alert_transitions
+
+import "time"
+
+// Transition – переход состояния алерта (rule, service, environment, instance),
+// который пишет движок ресурсов в alert_state_transitions.
+type Transition struct {
+	Timestamp   time.Time // время перехода
+	RuleId      string    // id правила
+	RuleName    string    // имя правила
+	ServiceName string    // имя сервиса
+	Environment string    // окружение
+	Instance    string    // экземпляр (хост, под), по которому считается алерт
+	State       string    // pending / firing / resolved / inactive
+}
@@ -0,0 +1,49 @@
+package alert_transitions
+
+import (
+	"context"
+	"database/sql"
+)
+
+// transitionsLimit – сколько последних переходов отдаётся за запрос.
+const transitionsLimit = 500
+
+type Provider interface {
+	GetTransitions(ctx context.Context, userId int64, projectId string, pastHours int, ruleId string) ([]*Transition, error)
+}
+
+type provider struct {
+	conn *sql.DB
+}
+
+func NewProvider(conn *sql.DB) Provider {
+	return &provider{conn}
+}
+
+// GetTransitions возвращает переходы состояний алертов проекта за последние pastHours часов, новые первыми.
+// Непустой ruleId – только переходы этого правила.
+func (p *provider) GetTransitions(ctx context.Context, userId int64, projectId string, pastHours int, ruleId string) ([]*Transition, error) {
+	query := `
+		SELECT timestamp, rule_id, rule_name, service_name, environment, instance, state
+		FROM alert_state_transitions
+		WHERE user_id = $1 AND project_id = $2 AND timestamp >= NOW() - ($3 * INTERVAL '1 hour')
+		  AND ($4 = '' OR rule_id = $4)
+		ORDER BY timestamp DESC
+		LIMIT $5;
+	`
+	rows, err := p.conn.QueryContext(ctx, query, userId, projectId, pastHours, ruleId, transitionsLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []*Transition
+	for rows.Next() {
+		var t Transition
+		if err = rows.Scan(&t.Timestamp, &t.RuleId, &t.RuleName, &t.ServiceName, &t.Environment, &t.Instance, &t.State); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, &t)
+	}
+	return transitions, rows.Err()
+}
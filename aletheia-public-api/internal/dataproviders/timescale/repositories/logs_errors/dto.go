@@ -20,8 +20,10 @@ type Event struct {
 }
 
 type UsedRule struct {
-	RuleId   string `json:"rule_id"`
-	RuleName string `json:"rule_name"`
+	RuleId     string `json:"rule_id"`
+	RuleName   string `json:"rule_name"`
+	Suppressed bool   `json:"suppressed,omitempty"` // алерт подавлен cooldown
+	SilenceId  string `json:"silence_id,omitempty"` // алерт заглушён silence
 }
 
 type UsedAction struct {
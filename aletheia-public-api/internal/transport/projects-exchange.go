@@ -47,3 +47,123 @@ type requestProjectsUpdateProject struct {
 type responseProjectsUpdateProject struct {
 	Status bool `json:"status,omitempty"`
 }
+
+type requestProjectsGetMonitors struct {
+	ProjectID string `json:"projectID,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type responseProjectsGetMonitors struct {
+	Items v1.MonitorsResponse `json:"items,omitempty"`
+}
+
+type requestProjectsCreateMonitor struct {
+	Monitor   *v1.MonitorRequest `json:"monitor,omitempty"`
+	ProjectID string             `json:"projectID,omitempty"`
+	UserId    int64              `json:"userId,omitempty"`
+}
+
+type responseProjectsCreateMonitor struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsUpdateMonitor struct {
+	Monitor   *v1.MonitorRequest `json:"monitor,omitempty"`
+	ProjectID string             `json:"projectID,omitempty"`
+	MonitorID string             `json:"monitorID,omitempty"`
+	UserId    int64              `json:"userId,omitempty"`
+}
+
+type responseProjectsUpdateMonitor struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsDeleteMonitor struct {
+	ProjectID string `json:"projectID,omitempty"`
+	MonitorID string `json:"monitorID,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type responseProjectsDeleteMonitor struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsGetMaintenanceWindows struct {
+	ProjectID string `json:"projectID,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type responseProjectsGetMaintenanceWindows struct {
+	Items v1.MaintenanceWindowsResponse `json:"items,omitempty"`
+}
+
+type requestProjectsCreateMaintenanceWindow struct {
+	Window    *v1.MaintenanceWindowRequest `json:"window,omitempty"`
+	ProjectID string                       `json:"projectID,omitempty"`
+	UserId    int64                        `json:"userId,omitempty"`
+}
+
+type responseProjectsCreateMaintenanceWindow struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsUpdateMaintenanceWindow struct {
+	Window    *v1.MaintenanceWindowRequest `json:"window,omitempty"`
+	ProjectID string                       `json:"projectID,omitempty"`
+	WindowID  string                       `json:"windowID,omitempty"`
+	UserId    int64                        `json:"userId,omitempty"`
+}
+
+type responseProjectsUpdateMaintenanceWindow struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsDeleteMaintenanceWindow struct {
+	ProjectID string `json:"projectID,omitempty"`
+	WindowID  string `json:"windowID,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type responseProjectsDeleteMaintenanceWindow struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsGetSilences struct {
+	ProjectID string `json:"projectID,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type responseProjectsGetSilences struct {
+	Items v1.SilencesResponse `json:"items,omitempty"`
+}
+
+type requestProjectsCreateSilence struct {
+	Silence   *v1.SilenceRequest `json:"silence,omitempty"`
+	ProjectID string             `json:"projectID,omitempty"`
+	UserId    int64              `json:"userId,omitempty"`
+}
+
+type responseProjectsCreateSilence struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsExpireSilence struct {
+	ProjectID string `json:"projectID,omitempty"`
+	SilenceID string `json:"silenceID,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type responseProjectsExpireSilence struct {
+	Status bool `json:"status,omitempty"`
+}
+
+type requestProjectsGetAlertTransitions struct {
+	ProjectID string `json:"projectID,omitempty"`
+	PastHours int    `json:"pastHours,omitempty"`
+	RuleId    string `json:"ruleId,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type responseProjectsGetAlertTransitions struct {
+	Items v1.AlertTransitionsResponse `json:"items,omitempty"`
+}
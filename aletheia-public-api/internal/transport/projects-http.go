@@ -49,4 +49,16 @@ func (http *httpProjects) SetRoutes(route *fiber.App) {
 	route.Delete("/v1/project/:projectID", http.serveDeleteProjectByID)
 	route.Post("/v1/project/create", http.serveCreateProject)
 	route.Put("/v1/project/:projectID", http.serveUpdateProject)
+	route.Get("/v1/project/:projectID/monitors", http.serveGetMonitors)
+	route.Post("/v1/project/:projectID/monitor", http.serveCreateMonitor)
+	route.Put("/v1/project/:projectID/monitor/:monitorID", http.serveUpdateMonitor)
+	route.Delete("/v1/project/:projectID/monitor/:monitorID", http.serveDeleteMonitor)
+	route.Get("/v1/project/:projectID/maintenance-windows", http.serveGetMaintenanceWindows)
+	route.Post("/v1/project/:projectID/maintenance-window", http.serveCreateMaintenanceWindow)
+	route.Put("/v1/project/:projectID/maintenance-window/:windowID", http.serveUpdateMaintenanceWindow)
+	route.Delete("/v1/project/:projectID/maintenance-window/:windowID", http.serveDeleteMaintenanceWindow)
+	route.Get("/v1/project/:projectID/silences", http.serveGetSilences)
+	route.Post("/v1/project/:projectID/silence", http.serveCreateSilence)
+	route.Delete("/v1/project/:projectID/silence/:silenceID", http.serveExpireSilence)
+	route.Get("/v1/project/:projectID/alert-transitions", http.serveGetAlertTransitions)
 }
@@ -134,3 +134,291 @@ func (m loggerProjects) UpdateProject(ctx context.Context, project *v1.UpdatePro
 	}(time.Now())
 	return m.next.UpdateProject(ctx, project, projectID, userId)
 }
+
+func (m loggerProjects) GetMonitors(ctx context.Context, projectID string, userId int64) (items v1.MonitorsResponse, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "getMonitors").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.getMonitors",
+				"request": viewer.Sprintf("%+v", requestProjectsGetMonitors{
+					ProjectID: projectID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsGetMonitors{Items: items}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call getMonitors")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call getMonitors")
+	}(time.Now())
+	return m.next.GetMonitors(ctx, projectID, userId)
+}
+
+func (m loggerProjects) CreateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "createMonitor").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.createMonitor",
+				"request": viewer.Sprintf("%+v", requestProjectsCreateMonitor{
+					Monitor:   monitor,
+					ProjectID: projectID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsCreateMonitor{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call createMonitor")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call createMonitor")
+	}(time.Now())
+	return m.next.CreateMonitor(ctx, monitor, projectID, userId)
+}
+
+func (m loggerProjects) UpdateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, monitorID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "updateMonitor").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.updateMonitor",
+				"request": viewer.Sprintf("%+v", requestProjectsUpdateMonitor{
+					Monitor:   monitor,
+					ProjectID: projectID,
+					MonitorID: monitorID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsUpdateMonitor{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call updateMonitor")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call updateMonitor")
+	}(time.Now())
+	return m.next.UpdateMonitor(ctx, monitor, projectID, monitorID, userId)
+}
+
+func (m loggerProjects) DeleteMonitor(ctx context.Context, projectID string, monitorID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "deleteMonitor").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.deleteMonitor",
+				"request": viewer.Sprintf("%+v", requestProjectsDeleteMonitor{
+					ProjectID: projectID,
+					MonitorID: monitorID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsDeleteMonitor{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call deleteMonitor")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call deleteMonitor")
+	}(time.Now())
+	return m.next.DeleteMonitor(ctx, projectID, monitorID, userId)
+}
+
+func (m loggerProjects) GetMaintenanceWindows(ctx context.Context, projectID string, userId int64) (items v1.MaintenanceWindowsResponse, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "getMaintenanceWindows").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.getMaintenanceWindows",
+				"request": viewer.Sprintf("%+v", requestProjectsGetMaintenanceWindows{
+					ProjectID: projectID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsGetMaintenanceWindows{Items: items}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call getMaintenanceWindows")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call getMaintenanceWindows")
+	}(time.Now())
+	return m.next.GetMaintenanceWindows(ctx, projectID, userId)
+}
+
+func (m loggerProjects) CreateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "createMaintenanceWindow").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.createMaintenanceWindow",
+				"request": viewer.Sprintf("%+v", requestProjectsCreateMaintenanceWindow{
+					Window:    window,
+					ProjectID: projectID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsCreateMaintenanceWindow{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call createMaintenanceWindow")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call createMaintenanceWindow")
+	}(time.Now())
+	return m.next.CreateMaintenanceWindow(ctx, window, projectID, userId)
+}
+
+func (m loggerProjects) UpdateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, windowID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "updateMaintenanceWindow").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.updateMaintenanceWindow",
+				"request": viewer.Sprintf("%+v", requestProjectsUpdateMaintenanceWindow{
+					Window:    window,
+					ProjectID: projectID,
+					WindowID:  windowID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsUpdateMaintenanceWindow{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call updateMaintenanceWindow")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call updateMaintenanceWindow")
+	}(time.Now())
+	return m.next.UpdateMaintenanceWindow(ctx, window, projectID, windowID, userId)
+}
+
+func (m loggerProjects) DeleteMaintenanceWindow(ctx context.Context, projectID string, windowID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "deleteMaintenanceWindow").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.deleteMaintenanceWindow",
+				"request": viewer.Sprintf("%+v", requestProjectsDeleteMaintenanceWindow{
+					ProjectID: projectID,
+					WindowID:  windowID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsDeleteMaintenanceWindow{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call deleteMaintenanceWindow")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call deleteMaintenanceWindow")
+	}(time.Now())
+	return m.next.DeleteMaintenanceWindow(ctx, projectID, windowID, userId)
+}
+
+func (m loggerProjects) GetSilences(ctx context.Context, projectID string, userId int64) (items v1.SilencesResponse, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "getSilences").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.getSilences",
+				"request": viewer.Sprintf("%+v", requestProjectsGetSilences{
+					ProjectID: projectID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsGetSilences{Items: items}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call getSilences")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call getSilences")
+	}(time.Now())
+	return m.next.GetSilences(ctx, projectID, userId)
+}
+
+func (m loggerProjects) CreateSilence(ctx context.Context, silence *v1.SilenceRequest, projectID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "createSilence").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.createSilence",
+				"request": viewer.Sprintf("%+v", requestProjectsCreateSilence{
+					Silence:   silence,
+					ProjectID: projectID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsCreateSilence{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call createSilence")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call createSilence")
+	}(time.Now())
+	return m.next.CreateSilence(ctx, silence, projectID, userId)
+}
+
+func (m loggerProjects) ExpireSilence(ctx context.Context, projectID string, silenceID string, userId int64) (status bool, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "expireSilence").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.expireSilence",
+				"request": viewer.Sprintf("%+v", requestProjectsExpireSilence{
+					ProjectID: projectID,
+					SilenceID: silenceID,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsExpireSilence{Status: status}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call expireSilence")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call expireSilence")
+	}(time.Now())
+	return m.next.ExpireSilence(ctx, projectID, silenceID, userId)
+}
+
+func (m loggerProjects) GetAlertTransitions(ctx context.Context, projectID string, pastHours int, ruleId string, userId int64) (items v1.AlertTransitionsResponse, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Projects").Str("method", "getAlertTransitions").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "projects.getAlertTransitions",
+				"request": viewer.Sprintf("%+v", requestProjectsGetAlertTransitions{
+					PastHours: pastHours,
+					ProjectID: projectID,
+					RuleId:    ruleId,
+					UserId:    userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseProjectsGetAlertTransitions{Items: items}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call getAlertTransitions")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call getAlertTransitions")
+	}(time.Now())
+	return m.next.GetAlertTransitions(ctx, projectID, pastHours, ruleId, userId)
+}
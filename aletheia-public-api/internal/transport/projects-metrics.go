@@ -86,3 +86,147 @@ func (m metricsProjects) UpdateProject(ctx context.Context, project *v1.UpdatePr
 
 	return m.next.UpdateProject(ctx, project, projectID, userId)
 }
+
+func (m metricsProjects) GetMonitors(ctx context.Context, projectID string, userId int64) (items v1.MonitorsResponse, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "getMonitors", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "getMonitors", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "getMonitors").Add(1)
+
+	return m.next.GetMonitors(ctx, projectID, userId)
+}
+
+func (m metricsProjects) CreateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "createMonitor", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "createMonitor", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "createMonitor").Add(1)
+
+	return m.next.CreateMonitor(ctx, monitor, projectID, userId)
+}
+
+func (m metricsProjects) UpdateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, monitorID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "updateMonitor", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "updateMonitor", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "updateMonitor").Add(1)
+
+	return m.next.UpdateMonitor(ctx, monitor, projectID, monitorID, userId)
+}
+
+func (m metricsProjects) DeleteMonitor(ctx context.Context, projectID string, monitorID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "deleteMonitor", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "deleteMonitor", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "deleteMonitor").Add(1)
+
+	return m.next.DeleteMonitor(ctx, projectID, monitorID, userId)
+}
+
+func (m metricsProjects) GetMaintenanceWindows(ctx context.Context, projectID string, userId int64) (items v1.MaintenanceWindowsResponse, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "getMaintenanceWindows", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "getMaintenanceWindows", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "getMaintenanceWindows").Add(1)
+
+	return m.next.GetMaintenanceWindows(ctx, projectID, userId)
+}
+
+func (m metricsProjects) CreateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "createMaintenanceWindow", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "createMaintenanceWindow", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "createMaintenanceWindow").Add(1)
+
+	return m.next.CreateMaintenanceWindow(ctx, window, projectID, userId)
+}
+
+func (m metricsProjects) UpdateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, windowID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "updateMaintenanceWindow", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "updateMaintenanceWindow", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "updateMaintenanceWindow").Add(1)
+
+	return m.next.UpdateMaintenanceWindow(ctx, window, projectID, windowID, userId)
+}
+
+func (m metricsProjects) DeleteMaintenanceWindow(ctx context.Context, projectID string, windowID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "deleteMaintenanceWindow", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "deleteMaintenanceWindow", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "deleteMaintenanceWindow").Add(1)
+
+	return m.next.DeleteMaintenanceWindow(ctx, projectID, windowID, userId)
+}
+
+func (m metricsProjects) GetSilences(ctx context.Context, projectID string, userId int64) (items v1.SilencesResponse, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "getSilences", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "getSilences", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "getSilences").Add(1)
+
+	return m.next.GetSilences(ctx, projectID, userId)
+}
+
+func (m metricsProjects) CreateSilence(ctx context.Context, silence *v1.SilenceRequest, projectID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "createSilence", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "createSilence", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "createSilence").Add(1)
+
+	return m.next.CreateSilence(ctx, silence, projectID, userId)
+}
+
+func (m metricsProjects) ExpireSilence(ctx context.Context, projectID string, silenceID string, userId int64) (status bool, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "expireSilence", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "expireSilence", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "expireSilence").Add(1)
+
+	return m.next.ExpireSilence(ctx, projectID, silenceID, userId)
+}
+
+func (m metricsProjects) GetAlertTransitions(ctx context.Context, projectID string, pastHours int, ruleId string, userId int64) (items v1.AlertTransitionsResponse, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "getAlertTransitions", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "getAlertTransitions", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "getAlertTransitions").Add(1)
+
+	return m.next.GetAlertTransitions(ctx, projectID, pastHours, ruleId, userId)
+}
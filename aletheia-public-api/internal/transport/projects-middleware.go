@@ -12,6 +12,18 @@ type ProjectsGetProjectByID func(ctx context.Context, projectID string, userId i
 type ProjectsDeleteProjectByID func(ctx context.Context, projectID string, userId int64) (status bool, err error)
 type ProjectsCreateProject func(ctx context.Context, project *v1.CreateProjectRequest, userId int64) (status bool, err error)
 type ProjectsUpdateProject func(ctx context.Context, project *v1.UpdateProjectRequest, projectID string, userId int64) (status bool, err error)
+type ProjectsGetMonitors func(ctx context.Context, projectID string, userId int64) (items v1.MonitorsResponse, err error)
+type ProjectsCreateMonitor func(ctx context.Context, monitor *v1.MonitorRequest, projectID string, userId int64) (status bool, err error)
+type ProjectsUpdateMonitor func(ctx context.Context, monitor *v1.MonitorRequest, projectID string, monitorID string, userId int64) (status bool, err error)
+type ProjectsDeleteMonitor func(ctx context.Context, projectID string, monitorID string, userId int64) (status bool, err error)
+type ProjectsGetMaintenanceWindows func(ctx context.Context, projectID string, userId int64) (items v1.MaintenanceWindowsResponse, err error)
+type ProjectsCreateMaintenanceWindow func(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, userId int64) (status bool, err error)
+type ProjectsUpdateMaintenanceWindow func(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, windowID string, userId int64) (status bool, err error)
+type ProjectsDeleteMaintenanceWindow func(ctx context.Context, projectID string, windowID string, userId int64) (status bool, err error)
+type ProjectsGetSilences func(ctx context.Context, projectID string, userId int64) (items v1.SilencesResponse, err error)
+type ProjectsCreateSilence func(ctx context.Context, silence *v1.SilenceRequest, projectID string, userId int64) (status bool, err error)
+type ProjectsExpireSilence func(ctx context.Context, projectID string, silenceID string, userId int64) (status bool, err error)
+type ProjectsGetAlertTransitions func(ctx context.Context, projectID string, pastHours int, ruleId string, userId int64) (items v1.AlertTransitionsResponse, err error)
 
 type MiddlewareProjects func(next interfaces.Projects) interfaces.Projects
 
@@ -20,3 +32,15 @@ type MiddlewareProjectsGetProjectByID func(next ProjectsGetProjectByID) Projects
 type MiddlewareProjectsDeleteProjectByID func(next ProjectsDeleteProjectByID) ProjectsDeleteProjectByID
 type MiddlewareProjectsCreateProject func(next ProjectsCreateProject) ProjectsCreateProject
 type MiddlewareProjectsUpdateProject func(next ProjectsUpdateProject) ProjectsUpdateProject
+type MiddlewareProjectsGetMonitors func(next ProjectsGetMonitors) ProjectsGetMonitors
+type MiddlewareProjectsCreateMonitor func(next ProjectsCreateMonitor) ProjectsCreateMonitor
+type MiddlewareProjectsUpdateMonitor func(next ProjectsUpdateMonitor) ProjectsUpdateMonitor
+type MiddlewareProjectsDeleteMonitor func(next ProjectsDeleteMonitor) ProjectsDeleteMonitor
+type MiddlewareProjectsGetMaintenanceWindows func(next ProjectsGetMaintenanceWindows) ProjectsGetMaintenanceWindows
+type MiddlewareProjectsCreateMaintenanceWindow func(next ProjectsCreateMaintenanceWindow) ProjectsCreateMaintenanceWindow
+type MiddlewareProjectsUpdateMaintenanceWindow func(next ProjectsUpdateMaintenanceWindow) ProjectsUpdateMaintenanceWindow
+type MiddlewareProjectsDeleteMaintenanceWindow func(next ProjectsDeleteMaintenanceWindow) ProjectsDeleteMaintenanceWindow
+type MiddlewareProjectsGetSilences func(next ProjectsGetSilences) ProjectsGetSilences
+type MiddlewareProjectsCreateSilence func(next ProjectsCreateSilence) ProjectsCreateSilence
+type MiddlewareProjectsExpireSilence func(next ProjectsExpireSilence) ProjectsExpireSilence
+type MiddlewareProjectsGetAlertTransitions func(next ProjectsGetAlertTransitions) ProjectsGetAlertTransitions
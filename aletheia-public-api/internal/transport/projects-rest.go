@@ -236,3 +236,625 @@ func (http *httpProjects) serveUpdateProject(ctx *fiber.Ctx) (err error) {
 	}
 	return sendResponse(ctx, err)
 }
+func (http *httpProjects) getMonitors(ctx context.Context, request requestProjectsGetMonitors) (response responseProjectsGetMonitors, err error) {
+
+	response.Items, err = http.svc.GetMonitors(ctx, request.ProjectID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveGetMonitors(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsGetMonitors
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsGetMonitors
+	if response, err = http.getMonitors(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) createMonitor(ctx context.Context, request requestProjectsCreateMonitor) (response responseProjectsCreateMonitor, err error) {
+
+	response.Status, err = http.svc.CreateMonitor(ctx, request.Monitor, request.ProjectID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveCreateMonitor(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsCreateMonitor
+	if err = ctx.BodyParser(&request); err != nil {
+		ctx.Response().SetStatusCode(fiber.StatusBadRequest)
+		_, err = ctx.WriteString("request body could not be decoded: " + err.Error())
+		return
+	}
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsCreateMonitor
+	if response, err = http.createMonitor(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) updateMonitor(ctx context.Context, request requestProjectsUpdateMonitor) (response responseProjectsUpdateMonitor, err error) {
+
+	response.Status, err = http.svc.UpdateMonitor(ctx, request.Monitor, request.ProjectID, request.MonitorID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveUpdateMonitor(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsUpdateMonitor
+	if err = ctx.BodyParser(&request); err != nil {
+		ctx.Response().SetStatusCode(fiber.StatusBadRequest)
+		_, err = ctx.WriteString("request body could not be decoded: " + err.Error())
+		return
+	}
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _monitorID := ctx.Params("monitorID"); _monitorID != "" {
+		var monitorID string
+		monitorID = _monitorID
+		request.MonitorID = monitorID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsUpdateMonitor
+	if response, err = http.updateMonitor(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) deleteMonitor(ctx context.Context, request requestProjectsDeleteMonitor) (response responseProjectsDeleteMonitor, err error) {
+
+	response.Status, err = http.svc.DeleteMonitor(ctx, request.ProjectID, request.MonitorID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveDeleteMonitor(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsDeleteMonitor
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _monitorID := ctx.Params("monitorID"); _monitorID != "" {
+		var monitorID string
+		monitorID = _monitorID
+		request.MonitorID = monitorID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsDeleteMonitor
+	if response, err = http.deleteMonitor(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) getMaintenanceWindows(ctx context.Context, request requestProjectsGetMaintenanceWindows) (response responseProjectsGetMaintenanceWindows, err error) {
+
+	response.Items, err = http.svc.GetMaintenanceWindows(ctx, request.ProjectID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveGetMaintenanceWindows(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsGetMaintenanceWindows
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsGetMaintenanceWindows
+	if response, err = http.getMaintenanceWindows(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) createMaintenanceWindow(ctx context.Context, request requestProjectsCreateMaintenanceWindow) (response responseProjectsCreateMaintenanceWindow, err error) {
+
+	response.Status, err = http.svc.CreateMaintenanceWindow(ctx, request.Window, request.ProjectID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveCreateMaintenanceWindow(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsCreateMaintenanceWindow
+	if err = ctx.BodyParser(&request); err != nil {
+		ctx.Response().SetStatusCode(fiber.StatusBadRequest)
+		_, err = ctx.WriteString("request body could not be decoded: " + err.Error())
+		return
+	}
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsCreateMaintenanceWindow
+	if response, err = http.createMaintenanceWindow(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) updateMaintenanceWindow(ctx context.Context, request requestProjectsUpdateMaintenanceWindow) (response responseProjectsUpdateMaintenanceWindow, err error) {
+
+	response.Status, err = http.svc.UpdateMaintenanceWindow(ctx, request.Window, request.ProjectID, request.WindowID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveUpdateMaintenanceWindow(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsUpdateMaintenanceWindow
+	if err = ctx.BodyParser(&request); err != nil {
+		ctx.Response().SetStatusCode(fiber.StatusBadRequest)
+		_, err = ctx.WriteString("request body could not be decoded: " + err.Error())
+		return
+	}
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _windowID := ctx.Params("windowID"); _windowID != "" {
+		var windowID string
+		windowID = _windowID
+		request.WindowID = windowID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsUpdateMaintenanceWindow
+	if response, err = http.updateMaintenanceWindow(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) deleteMaintenanceWindow(ctx context.Context, request requestProjectsDeleteMaintenanceWindow) (response responseProjectsDeleteMaintenanceWindow, err error) {
+
+	response.Status, err = http.svc.DeleteMaintenanceWindow(ctx, request.ProjectID, request.WindowID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveDeleteMaintenanceWindow(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsDeleteMaintenanceWindow
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _windowID := ctx.Params("windowID"); _windowID != "" {
+		var windowID string
+		windowID = _windowID
+		request.WindowID = windowID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsDeleteMaintenanceWindow
+	if response, err = http.deleteMaintenanceWindow(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) getSilences(ctx context.Context, request requestProjectsGetSilences) (response responseProjectsGetSilences, err error) {
+
+	response.Items, err = http.svc.GetSilences(ctx, request.ProjectID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveGetSilences(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsGetSilences
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsGetSilences
+	if response, err = http.getSilences(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) createSilence(ctx context.Context, request requestProjectsCreateSilence) (response responseProjectsCreateSilence, err error) {
+
+	response.Status, err = http.svc.CreateSilence(ctx, request.Silence, request.ProjectID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveCreateSilence(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsCreateSilence
+	if err = ctx.BodyParser(&request); err != nil {
+		ctx.Response().SetStatusCode(fiber.StatusBadRequest)
+		_, err = ctx.WriteString("request body could not be decoded: " + err.Error())
+		return
+	}
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsCreateSilence
+	if response, err = http.createSilence(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) expireSilence(ctx context.Context, request requestProjectsExpireSilence) (response responseProjectsExpireSilence, err error) {
+
+	response.Status, err = http.svc.ExpireSilence(ctx, request.ProjectID, request.SilenceID, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveExpireSilence(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsExpireSilence
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _silenceID := ctx.Params("silenceID"); _silenceID != "" {
+		var silenceID string
+		silenceID = _silenceID
+		request.SilenceID = silenceID
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsExpireSilence
+	if response, err = http.expireSilence(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
+func (http *httpProjects) getAlertTransitions(ctx context.Context, request requestProjectsGetAlertTransitions) (response responseProjectsGetAlertTransitions, err error) {
+
+	response.Items, err = http.svc.GetAlertTransitions(ctx, request.ProjectID, request.PastHours, request.RuleId, request.UserId)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpProjects) serveGetAlertTransitions(ctx *fiber.Ctx) (err error) {
+
+	var request requestProjectsGetAlertTransitions
+
+	if _projectID := ctx.Params("projectID"); _projectID != "" {
+		var projectID string
+		projectID = _projectID
+		request.ProjectID = projectID
+	}
+
+	if _pastHours := ctx.Query("pastHours"); _pastHours != "" {
+		var pastHours int
+		pastHours, err = strconv.Atoi(_pastHours)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "url arguments could not be decoded: "+err.Error())
+		}
+		request.PastHours = pastHours
+	}
+	if _ruleId := ctx.Query("ruleId"); _ruleId != "" {
+		var ruleId string
+		ruleId = _ruleId
+		request.RuleId = ruleId
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseProjectsGetAlertTransitions
+	if response, err = http.getAlertTransitions(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
@@ -8,12 +8,24 @@ import (
 )
 
 type serverProjects struct {
-	svc               interfaces.Projects
-	getProjects       ProjectsGetProjects
-	getProjectByID    ProjectsGetProjectByID
-	deleteProjectByID ProjectsDeleteProjectByID
-	createProject     ProjectsCreateProject
-	updateProject     ProjectsUpdateProject
+	svc                     interfaces.Projects
+	getProjects             ProjectsGetProjects
+	getProjectByID          ProjectsGetProjectByID
+	deleteProjectByID       ProjectsDeleteProjectByID
+	createProject           ProjectsCreateProject
+	updateProject           ProjectsUpdateProject
+	getMonitors             ProjectsGetMonitors
+	createMonitor           ProjectsCreateMonitor
+	updateMonitor           ProjectsUpdateMonitor
+	deleteMonitor           ProjectsDeleteMonitor
+	getMaintenanceWindows   ProjectsGetMaintenanceWindows
+	createMaintenanceWindow ProjectsCreateMaintenanceWindow
+	updateMaintenanceWindow ProjectsUpdateMaintenanceWindow
+	deleteMaintenanceWindow ProjectsDeleteMaintenanceWindow
+	getSilences             ProjectsGetSilences
+	createSilence           ProjectsCreateSilence
+	expireSilence           ProjectsExpireSilence
+	getAlertTransitions     ProjectsGetAlertTransitions
 }
 
 type MiddlewareSetProjects interface {
@@ -23,6 +35,18 @@ type MiddlewareSetProjects interface {
 	WrapDeleteProjectByID(m MiddlewareProjectsDeleteProjectByID)
 	WrapCreateProject(m MiddlewareProjectsCreateProject)
 	WrapUpdateProject(m MiddlewareProjectsUpdateProject)
+	WrapGetMonitors(m MiddlewareProjectsGetMonitors)
+	WrapCreateMonitor(m MiddlewareProjectsCreateMonitor)
+	WrapUpdateMonitor(m MiddlewareProjectsUpdateMonitor)
+	WrapDeleteMonitor(m MiddlewareProjectsDeleteMonitor)
+	WrapGetMaintenanceWindows(m MiddlewareProjectsGetMaintenanceWindows)
+	WrapCreateMaintenanceWindow(m MiddlewareProjectsCreateMaintenanceWindow)
+	WrapUpdateMaintenanceWindow(m MiddlewareProjectsUpdateMaintenanceWindow)
+	WrapDeleteMaintenanceWindow(m MiddlewareProjectsDeleteMaintenanceWindow)
+	WrapGetSilences(m MiddlewareProjectsGetSilences)
+	WrapCreateSilence(m MiddlewareProjectsCreateSilence)
+	WrapExpireSilence(m MiddlewareProjectsExpireSilence)
+	WrapGetAlertTransitions(m MiddlewareProjectsGetAlertTransitions)
 
 	WithMetrics()
 	WithLog()
@@ -30,12 +54,24 @@ type MiddlewareSetProjects interface {
 
 func newServerProjects(svc interfaces.Projects) *serverProjects {
 	return &serverProjects{
-		createProject:     svc.CreateProject,
-		deleteProjectByID: svc.DeleteProjectByID,
-		getProjectByID:    svc.GetProjectByID,
-		getProjects:       svc.GetProjects,
-		svc:               svc,
-		updateProject:     svc.UpdateProject,
+		createMaintenanceWindow: svc.CreateMaintenanceWindow,
+		createMonitor:           svc.CreateMonitor,
+		createProject:           svc.CreateProject,
+		createSilence:           svc.CreateSilence,
+		deleteMaintenanceWindow: svc.DeleteMaintenanceWindow,
+		deleteMonitor:           svc.DeleteMonitor,
+		deleteProjectByID:       svc.DeleteProjectByID,
+		expireSilence:           svc.ExpireSilence,
+		getAlertTransitions:     svc.GetAlertTransitions,
+		getMaintenanceWindows:   svc.GetMaintenanceWindows,
+		getMonitors:             svc.GetMonitors,
+		getProjectByID:          svc.GetProjectByID,
+		getProjects:             svc.GetProjects,
+		getSilences:             svc.GetSilences,
+		svc:                     svc,
+		updateMaintenanceWindow: svc.UpdateMaintenanceWindow,
+		updateMonitor:           svc.UpdateMonitor,
+		updateProject:           svc.UpdateProject,
 	}
 }
 
@@ -46,6 +82,18 @@ func (srv *serverProjects) Wrap(m MiddlewareProjects) {
 	srv.deleteProjectByID = srv.svc.DeleteProjectByID
 	srv.createProject = srv.svc.CreateProject
 	srv.updateProject = srv.svc.UpdateProject
+	srv.getMonitors = srv.svc.GetMonitors
+	srv.createMonitor = srv.svc.CreateMonitor
+	srv.updateMonitor = srv.svc.UpdateMonitor
+	srv.deleteMonitor = srv.svc.DeleteMonitor
+	srv.getMaintenanceWindows = srv.svc.GetMaintenanceWindows
+	srv.createMaintenanceWindow = srv.svc.CreateMaintenanceWindow
+	srv.updateMaintenanceWindow = srv.svc.UpdateMaintenanceWindow
+	srv.deleteMaintenanceWindow = srv.svc.DeleteMaintenanceWindow
+	srv.getSilences = srv.svc.GetSilences
+	srv.createSilence = srv.svc.CreateSilence
+	srv.expireSilence = srv.svc.ExpireSilence
+	srv.getAlertTransitions = srv.svc.GetAlertTransitions
 }
 
 func (srv *serverProjects) GetProjects(ctx context.Context, userId int64) (items v1.ProjectsResponse, err error) {
@@ -68,6 +116,54 @@ func (srv *serverProjects) UpdateProject(ctx context.Context, project *v1.Update
 	return srv.updateProject(ctx, project, projectID, userId)
 }
 
+func (srv *serverProjects) GetMonitors(ctx context.Context, projectID string, userId int64) (items v1.MonitorsResponse, err error) {
+	return srv.getMonitors(ctx, projectID, userId)
+}
+
+func (srv *serverProjects) CreateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, userId int64) (status bool, err error) {
+	return srv.createMonitor(ctx, monitor, projectID, userId)
+}
+
+func (srv *serverProjects) UpdateMonitor(ctx context.Context, monitor *v1.MonitorRequest, projectID string, monitorID string, userId int64) (status bool, err error) {
+	return srv.updateMonitor(ctx, monitor, projectID, monitorID, userId)
+}
+
+func (srv *serverProjects) DeleteMonitor(ctx context.Context, projectID string, monitorID string, userId int64) (status bool, err error) {
+	return srv.deleteMonitor(ctx, projectID, monitorID, userId)
+}
+
+func (srv *serverProjects) GetMaintenanceWindows(ctx context.Context, projectID string, userId int64) (items v1.MaintenanceWindowsResponse, err error) {
+	return srv.getMaintenanceWindows(ctx, projectID, userId)
+}
+
+func (srv *serverProjects) CreateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, userId int64) (status bool, err error) {
+	return srv.createMaintenanceWindow(ctx, window, projectID, userId)
+}
+
+func (srv *serverProjects) UpdateMaintenanceWindow(ctx context.Context, window *v1.MaintenanceWindowRequest, projectID string, windowID string, userId int64) (status bool, err error) {
+	return srv.updateMaintenanceWindow(ctx, window, projectID, windowID, userId)
+}
+
+func (srv *serverProjects) DeleteMaintenanceWindow(ctx context.Context, projectID string, windowID string, userId int64) (status bool, err error) {
+	return srv.deleteMaintenanceWindow(ctx, projectID, windowID, userId)
+}
+
+func (srv *serverProjects) GetSilences(ctx context.Context, projectID string, userId int64) (items v1.SilencesResponse, err error) {
+	return srv.getSilences(ctx, projectID, userId)
+}
+
+func (srv *serverProjects) CreateSilence(ctx context.Context, silence *v1.SilenceRequest, projectID string, userId int64) (status bool, err error) {
+	return srv.createSilence(ctx, silence, projectID, userId)
+}
+
+func (srv *serverProjects) ExpireSilence(ctx context.Context, projectID string, silenceID string, userId int64) (status bool, err error) {
+	return srv.expireSilence(ctx, projectID, silenceID, userId)
+}
+
+func (srv *serverProjects) GetAlertTransitions(ctx context.Context, projectID string, pastHours int, ruleId string, userId int64) (items v1.AlertTransitionsResponse, err error) {
+	return srv.getAlertTransitions(ctx, projectID, pastHours, ruleId, userId)
+}
+
 func (srv *serverProjects) WrapGetProjects(m MiddlewareProjectsGetProjects) {
 	srv.getProjects = m(srv.getProjects)
 }
@@ -88,6 +184,54 @@ func (srv *serverProjects) WrapUpdateProject(m MiddlewareProjectsUpdateProject)
 	srv.updateProject = m(srv.updateProject)
 }
 
+func (srv *serverProjects) WrapGetMonitors(m MiddlewareProjectsGetMonitors) {
+	srv.getMonitors = m(srv.getMonitors)
+}
+
+func (srv *serverProjects) WrapCreateMonitor(m MiddlewareProjectsCreateMonitor) {
+	srv.createMonitor = m(srv.createMonitor)
+}
+
+func (srv *serverProjects) WrapUpdateMonitor(m MiddlewareProjectsUpdateMonitor) {
+	srv.updateMonitor = m(srv.updateMonitor)
+}
+
+func (srv *serverProjects) WrapDeleteMonitor(m MiddlewareProjectsDeleteMonitor) {
+	srv.deleteMonitor = m(srv.deleteMonitor)
+}
+
+func (srv *serverProjects) WrapGetMaintenanceWindows(m MiddlewareProjectsGetMaintenanceWindows) {
+	srv.getMaintenanceWindows = m(srv.getMaintenanceWindows)
+}
+
+func (srv *serverProjects) WrapCreateMaintenanceWindow(m MiddlewareProjectsCreateMaintenanceWindow) {
+	srv.createMaintenanceWindow = m(srv.createMaintenanceWindow)
+}
+
+func (srv *serverProjects) WrapUpdateMaintenanceWindow(m MiddlewareProjectsUpdateMaintenanceWindow) {
+	srv.updateMaintenanceWindow = m(srv.updateMaintenanceWindow)
+}
+
+func (srv *serverProjects) WrapDeleteMaintenanceWindow(m MiddlewareProjectsDeleteMaintenanceWindow) {
+	srv.deleteMaintenanceWindow = m(srv.deleteMaintenanceWindow)
+}
+
+func (srv *serverProjects) WrapGetSilences(m MiddlewareProjectsGetSilences) {
+	srv.getSilences = m(srv.getSilences)
+}
+
+func (srv *serverProjects) WrapCreateSilence(m MiddlewareProjectsCreateSilence) {
+	srv.createSilence = m(srv.createSilence)
+}
+
+func (srv *serverProjects) WrapExpireSilence(m MiddlewareProjectsExpireSilence) {
+	srv.expireSilence = m(srv.expireSilence)
+}
+
+func (srv *serverProjects) WrapGetAlertTransitions(m MiddlewareProjectsGetAlertTransitions) {
+	srv.getAlertTransitions = m(srv.getAlertTransitions)
+}
+
 func (srv *serverProjects) WithMetrics() {
 	srv.Wrap(metricsMiddlewareProjects)
 }
@@ -55,3 +55,12 @@ type requestRulesUpdateRuleById struct {
 type responseRulesUpdateRuleById struct {
 	Status bool `json:"status,omitempty"`
 }
+
+type requestRulesTestRule struct {
+	UserId  int64              `json:"userId,omitempty"`
+	Request v1.TestRuleRequest `json:"request,omitempty"`
+}
+
+type responseRulesTestRule struct {
+	Result *v1.TestRuleResponse `json:"result,omitempty"`
+}
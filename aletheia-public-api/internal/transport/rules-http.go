@@ -50,4 +50,5 @@ func (http *httpRules) SetRoutes(route *fiber.App) {
 	route.Delete("/v1/rules", http.serveDeleteRuleByID)
 	route.Post("/v1/rules/create", http.serveCreateRule)
 	route.Put("/v1/rules/update", http.serveUpdateRuleById)
+	route.Post("/v1/rules/test", http.serveTestRule)
 }
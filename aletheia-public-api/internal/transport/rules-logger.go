@@ -154,3 +154,26 @@ func (m loggerRules) UpdateRuleById(ctx context.Context, userId int64, request v
 	}(time.Now())
 	return m.next.UpdateRuleById(ctx, userId, request)
 }
+
+func (m loggerRules) TestRule(ctx context.Context, userId int64, request v1.TestRuleRequest) (result *v1.TestRuleResponse, err error) {
+	logger := log.Ctx(ctx).With().Str("service", "Rules").Str("method", "testRule").Logger()
+	defer func(_begin time.Time) {
+		logHandle := func(ev *zerolog.Event) {
+			fields := map[string]interface{}{
+				"method": "rules.testRule",
+				"request": viewer.Sprintf("%+v", requestRulesTestRule{
+					Request: request,
+					UserId:  userId,
+				}),
+				"response": viewer.Sprintf("%+v", responseRulesTestRule{Result: result}),
+			}
+			ev.Fields(fields).Str("took", time.Since(_begin).String())
+		}
+		if err != nil {
+			logger.Error().Err(err).Func(logHandle).Msg("call testRule")
+			return
+		}
+		logger.Info().Func(logHandle).Msg("call testRule")
+	}(time.Now())
+	return m.next.TestRule(ctx, userId, request)
+}
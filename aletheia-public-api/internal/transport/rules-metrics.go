@@ -98,3 +98,15 @@ func (m metricsRules) UpdateRuleById(ctx context.Context, userId int64, request
 
 	return m.next.UpdateRuleById(ctx, userId, request)
 }
+
+func (m metricsRules) TestRule(ctx context.Context, userId int64, request v1.TestRuleRequest) (result *v1.TestRuleResponse, err error) {
+
+	defer func(_begin time.Time) {
+		m.requestCount.With("method", "testRule", "success", fmt.Sprint(err == nil)).Add(1)
+		m.requestLatency.With("method", "testRule", "success", fmt.Sprint(err == nil)).Observe(time.Since(_begin).Seconds())
+	}(time.Now())
+
+	m.requestCountAll.With("method", "testRule").Add(1)
+
+	return m.next.TestRule(ctx, userId, request)
+}
@@ -13,6 +13,7 @@ type RulesGetAvailableRules func(ctx context.Context, userId int64) (items v1.Ru
 type RulesDeleteRuleByID func(ctx context.Context, userId int64, req v1.DeleteRuleRequest) (status bool, err error)
 type RulesCreateRule func(ctx context.Context, userId int64, request v1.CreateRuleRequest) (status bool, err error)
 type RulesUpdateRuleById func(ctx context.Context, userId int64, request v1.UpdateRuleRequest) (status bool, err error)
+type RulesTestRule func(ctx context.Context, userId int64, request v1.TestRuleRequest) (result *v1.TestRuleResponse, err error)
 
 type MiddlewareRules func(next interfaces.Rules) interfaces.Rules
 
@@ -22,3 +23,4 @@ type MiddlewareRulesGetAvailableRules func(next RulesGetAvailableRules) RulesGet
 type MiddlewareRulesDeleteRuleByID func(next RulesDeleteRuleByID) RulesDeleteRuleByID
 type MiddlewareRulesCreateRule func(next RulesCreateRule) RulesCreateRule
 type MiddlewareRulesUpdateRuleById func(next RulesUpdateRuleById) RulesUpdateRuleById
+type MiddlewareRulesTestRule func(next RulesTestRule) RulesTestRule
@@ -274,3 +274,48 @@ func (http *httpRules) serveUpdateRuleById(ctx *fiber.Ctx) (err error) {
 	}
 	return sendResponse(ctx, err)
 }
+func (http *httpRules) testRule(ctx context.Context, request requestRulesTestRule) (response responseRulesTestRule, err error) {
+
+	response.Result, err = http.svc.TestRule(ctx, request.UserId, request.Request)
+	if err != nil {
+		if http.errorHandler != nil {
+			err = http.errorHandler(err)
+		}
+	}
+	return
+}
+func (http *httpRules) serveTestRule(ctx *fiber.Ctx) (err error) {
+
+	var request requestRulesTestRule
+	if err = ctx.BodyParser(&request); err != nil {
+		ctx.Response().SetStatusCode(fiber.StatusBadRequest)
+		_, err = ctx.WriteString("request body could not be decoded: " + err.Error())
+		return
+	}
+
+	if _userId := string(ctx.Request().Header.Peek("X-User-Id")); _userId != "" {
+		var userId int64
+		userId, err = strconv.ParseInt(_userId, 10, 64)
+		if err != nil {
+			ctx.Status(fiber.StatusBadRequest)
+			return sendResponse(ctx, "http header could not be decoded: "+err.Error())
+		}
+		request.UserId = userId
+	}
+
+	var response responseRulesTestRule
+	if response, err = http.testRule(ctx.UserContext(), request); err == nil {
+		var iResponse interface{} = response
+		if redirect, ok := iResponse.(withRedirect); ok {
+			return ctx.Redirect(redirect.RedirectTo())
+		}
+
+		return sendResponse(ctx, response)
+	}
+	if errCoder, ok := err.(withErrorCode); ok {
+		ctx.Status(errCoder.Code())
+	} else {
+		ctx.Status(fiber.StatusInternalServerError)
+	}
+	return sendResponse(ctx, err)
+}
@@ -15,6 +15,7 @@ type serverRules struct {
 	deleteRuleByID    RulesDeleteRuleByID
 	createRule        RulesCreateRule
 	updateRuleById    RulesUpdateRuleById
+	testRule          RulesTestRule
 }
 
 type MiddlewareSetRules interface {
@@ -25,6 +26,7 @@ type MiddlewareSetRules interface {
 	WrapDeleteRuleByID(m MiddlewareRulesDeleteRuleByID)
 	WrapCreateRule(m MiddlewareRulesCreateRule)
 	WrapUpdateRuleById(m MiddlewareRulesUpdateRuleById)
+	WrapTestRule(m MiddlewareRulesTestRule)
 
 	WithMetrics()
 	WithLog()
@@ -38,6 +40,7 @@ func newServerRules(svc interfaces.Rules) *serverRules {
 		getRuleByID:       svc.GetRuleByID,
 		getRules:          svc.GetRules,
 		svc:               svc,
+		testRule:          svc.TestRule,
 		updateRuleById:    svc.UpdateRuleById,
 	}
 }
@@ -50,6 +53,7 @@ func (srv *serverRules) Wrap(m MiddlewareRules) {
 	srv.deleteRuleByID = srv.svc.DeleteRuleByID
 	srv.createRule = srv.svc.CreateRule
 	srv.updateRuleById = srv.svc.UpdateRuleById
+	srv.testRule = srv.svc.TestRule
 }
 
 func (srv *serverRules) GetRules(ctx context.Context, userId int64) (items v1.RulesResponse, err error) {
@@ -76,6 +80,10 @@ func (srv *serverRules) UpdateRuleById(ctx context.Context, userId int64, reques
 	return srv.updateRuleById(ctx, userId, request)
 }
 
+func (srv *serverRules) TestRule(ctx context.Context, userId int64, request v1.TestRuleRequest) (result *v1.TestRuleResponse, err error) {
+	return srv.testRule(ctx, userId, request)
+}
+
 func (srv *serverRules) WrapGetRules(m MiddlewareRulesGetRules) {
 	srv.getRules = m(srv.getRules)
 }
@@ -100,6 +108,10 @@ func (srv *serverRules) WrapUpdateRuleById(m MiddlewareRulesUpdateRuleById) {
 	srv.updateRuleById = m(srv.updateRuleById)
 }
 
+func (srv *serverRules) WrapTestRule(m MiddlewareRulesTestRule) {
+	srv.testRule = m(srv.testRule)
+}
+
 func (srv *serverRules) WithMetrics() {
 	srv.Wrap(metricsMiddlewareRules)
 }
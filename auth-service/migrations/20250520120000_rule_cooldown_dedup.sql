@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE rule_engine.error_rules
+    ADD COLUMN IF NOT EXISTS cooldown_sec INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS dedup_key    VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE rule_engine.resource_rules
+    ADD COLUMN IF NOT EXISTS cooldown_sec INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS dedup_key    VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE rule_engine.resource_rules
+    DROP COLUMN IF EXISTS dedup_key,
+    DROP COLUMN IF EXISTS cooldown_sec;
+
+ALTER TABLE rule_engine.error_rules
+    DROP COLUMN IF EXISTS dedup_key,
+    DROP COLUMN IF EXISTS cooldown_sec;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE rule_engine.resource_rules
+    ADD COLUMN IF NOT EXISTS for_samples INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS for_minutes INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE rule_engine.resource_rules
+    DROP COLUMN IF EXISTS for_minutes,
+    DROP COLUMN IF EXISTS for_samples;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS rule_engine.monitors (
+    id            SERIAL PRIMARY KEY,
+    project_id    INTEGER      NOT NULL,        -- Ссылка на projects.id
+    user_id       INTEGER      NOT NULL,
+    slug          VARCHAR(255) NOT NULL,        -- monitor_slug из check-in SDK
+    name          VARCHAR(255) NOT NULL DEFAULT '',
+    schedule_type VARCHAR(16)  NOT NULL,        -- cron | interval
+    cron_expr     VARCHAR(255) NOT NULL DEFAULT '',
+    interval_sec  INTEGER      NOT NULL DEFAULT 0,
+    grace_sec     INTEGER      NOT NULL DEFAULT 0,
+    timezone      VARCHAR(64)  NOT NULL DEFAULT 'UTC',
+    actions       JSONB        NOT NULL DEFAULT '[]',
+    enabled       BOOLEAN      NOT NULL DEFAULT TRUE,
+    UNIQUE (project_id, slug),
+    FOREIGN KEY (project_id) REFERENCES rule_engine.projects(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rule_engine.monitors;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS rule_engine.correlation_rules (
+    id           SERIAL PRIMARY KEY,
+    user_id      INTEGER      NOT NULL,
+    project_id   INTEGER      NOT NULL,        -- Ссылка на projects.id: шаги могут относиться к разным сервисам проекта
+    name         VARCHAR(255),
+    description  VARCHAR(255),
+    kind         VARCHAR(16)  NOT NULL,        -- sequence | co_occurrence
+    steps        JSONB        NOT NULL,        -- [{name, engine, service_name, root_node}]
+    window_sec   INTEGER      NOT NULL,
+    distinct_by  VARCHAR(255) NOT NULL DEFAULT '',
+    min_distinct INTEGER      NOT NULL DEFAULT 0,
+    actions      JSONB,
+    FOREIGN KEY (project_id) REFERENCES rule_engine.projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS correlation_rules_user_project_idx
+    ON rule_engine.correlation_rules (user_id, project_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rule_engine.correlation_rules;
+-- +goose StatementEnd
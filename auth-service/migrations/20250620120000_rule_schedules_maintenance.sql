@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- schedule: {"timezone": "Europe/Moscow", "days": ["mon", "fri"], "start": "09:00", "end": "18:00"}; NULL – правило активно всегда
+ALTER TABLE rule_engine.error_rules
+    ADD COLUMN IF NOT EXISTS schedule JSONB;
+ALTER TABLE rule_engine.resource_rules
+    ADD COLUMN IF NOT EXISTS schedule JSONB;
+
+CREATE TABLE IF NOT EXISTS rule_engine.maintenance_windows (
+    id         SERIAL PRIMARY KEY,
+    project_id INTEGER      NOT NULL,        -- Ссылка на projects.id
+    user_id    INTEGER      NOT NULL,
+    name       VARCHAR(255) NOT NULL DEFAULT '',
+    starts_at  TIMESTAMPTZ,                  -- разовое окно (деплой)
+    ends_at    TIMESTAMPTZ,
+    schedule   JSONB,                        -- повторяющееся окно (ночные батчи), формат как у правил
+    CHECK ((starts_at IS NOT NULL AND ends_at > starts_at) OR schedule IS NOT NULL),
+    FOREIGN KEY (project_id) REFERENCES rule_engine.projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS maintenance_windows_project_idx
+    ON rule_engine.maintenance_windows (project_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rule_engine.maintenance_windows;
+ALTER TABLE rule_engine.resource_rules
+    DROP COLUMN IF EXISTS schedule;
+ALTER TABLE rule_engine.error_rules
+    DROP COLUMN IF EXISTS schedule;
+-- +goose StatementEnd
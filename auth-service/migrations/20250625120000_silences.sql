@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- matchers: {"rule_id": "12", "service_name": "billing", "environment": "prod",
+--            "error_message": "^timeout", "tags": {"region": "eu-west-1"}}; все заданные условия должны совпасть
+CREATE TABLE IF NOT EXISTS rule_engine.silences (
+    id         SERIAL PRIMARY KEY,
+    project_id INTEGER      NOT NULL,        -- Ссылка на projects.id
+    user_id    INTEGER      NOT NULL,
+    matchers   JSONB        NOT NULL,
+    starts_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    ends_at    TIMESTAMPTZ  NOT NULL,        -- expire через API переносит ends_at на now()
+    created_by VARCHAR(255) NOT NULL,
+    comment    TEXT         NOT NULL DEFAULT '',
+    CHECK (ends_at >= starts_at),
+    FOREIGN KEY (project_id) REFERENCES rule_engine.projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS silences_project_ends_at_idx
+    ON rule_engine.silences (project_id, ends_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rule_engine.silences;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Известные отпечатки ошибок для first_seen / first_seen_in_version.
+-- version = '' – отпечатки по (project, service, environment) без учёта версии.
+CREATE TABLE IF NOT EXISTS rule_engine.error_fingerprints (
+    project_id   INTEGER      NOT NULL,        -- Ссылка на projects.id
+    service_name VARCHAR(255) NOT NULL,
+    environment  VARCHAR(255) NOT NULL DEFAULT '',
+    version      VARCHAR(255) NOT NULL DEFAULT '',
+    fingerprint  CHAR(40)     NOT NULL,        -- sha1 нормализованного сообщения и верхних кадров стека
+    first_seen   TIMESTAMPTZ  NOT NULL,
+    last_seen    TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (project_id, service_name, environment, version, fingerprint),
+    FOREIGN KEY (project_id) REFERENCES rule_engine.projects(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rule_engine.error_fingerprints;
+-- +goose StatementEnd
@@ -0,0 +1,253 @@
+// dlq – просмотр и повторная отправка сообщений из dead-letter топика движка.
+//
+//	go run ./cmd/dlq list -limit 20
+//	go run ./cmd/dlq reinject -partition 0 -from 120 -to 130
+//
+// list печатает сообщения с причиной ошибки из заголовков x-dlq-*.
+// reinject отправляет сообщения обратно в исходный топик (заголовок x-dlq-original-topic
+// или -target) без заголовков x-dlq-*. Офсеты DLQ не коммитятся: какие сообщения уже
+// возвращены, оператор выбирает через -partition/-from/-to.
+// Брокеры и топик по умолчанию берутся из KAFKA_BROKERS, KAFKA_DLQ_TOPIC и KAFKA_RESOURCE_TOPIC.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	kafkaRepository "rule-engine-errors/internal/dataproviders/kafka_repository"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type options struct {
+	brokers   []string
+	topic     string
+	target    string
+	partition int
+	from      int64
+	to        int64
+	limit     int
+}
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "list" && os.Args[1] != "reinject") {
+		fmt.Fprintln(os.Stderr, "usage: dlq list|reinject [flags]")
+		os.Exit(2)
+	}
+	cmd := os.Args[1]
+
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	brokers := fs.String("brokers", envOr("KAFKA_BROKERS", "localhost:9092"), "брокеры Kafka через запятую")
+	topic := fs.String("topic", defaultTopic(), "dead-letter топик")
+	target := fs.String("target", "", "куда отправлять при reinject (по умолчанию – исходный топик из заголовка)")
+	partition := fs.Int("partition", -1, "партиция DLQ (-1 – все)")
+	from := fs.Int64("from", -1, "первый офсет (включительно, -1 – с начала)")
+	to := fs.Int64("to", -1, "последний офсет (включительно, -1 – до конца)")
+	limit := fs.Int("limit", 0, "максимум сообщений (0 – без ограничения)")
+	_ = fs.Parse(os.Args[2:])
+
+	opts := options{
+		brokers:   strings.Split(*brokers, ","),
+		topic:     *topic,
+		target:    *target,
+		partition: *partition,
+		from:      *from,
+		to:        *to,
+		limit:     *limit,
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch cmd {
+	case "list":
+		err = list(ctx, opts)
+	case "reinject":
+		err = reinject(ctx, opts)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dlq %s: %v\n", cmd, err)
+		os.Exit(1)
+	}
+}
+
+func list(ctx context.Context, opts options) error {
+	return scan(ctx, opts, func(m kafka.Message) error {
+		fmt.Printf("%s/%d/%d key=%s\n", m.Topic, m.Partition, m.Offset, m.Key)
+		for _, h := range m.Headers {
+			if strings.HasPrefix(h.Key, "x-dlq-") {
+				fmt.Printf("  %s: %s\n", h.Key, h.Value)
+			}
+		}
+		fmt.Printf("  value: %s\n", truncate(string(m.Value), 512))
+		return nil
+	})
+}
+
+func reinject(ctx context.Context, opts options) error {
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(opts.brokers...),
+		RequiredAcks: kafka.RequireAll,
+	}
+	defer writer.Close()
+
+	count := 0
+	err := scan(ctx, opts, func(m kafka.Message) error {
+		target := opts.target
+		if target == "" {
+			target = header(m, kafkaRepository.HeaderDLQOriginalTopic)
+		}
+		if target == "" {
+			return fmt.Errorf("message %d/%d has no %s header, use -target", m.Partition, m.Offset, kafkaRepository.HeaderDLQOriginalTopic)
+		}
+
+		headers := make([]kafka.Header, 0, len(m.Headers))
+		for _, h := range m.Headers {
+			if !strings.HasPrefix(h.Key, "x-dlq-") {
+				headers = append(headers, h)
+			}
+		}
+		if err := writer.WriteMessages(ctx, kafka.Message{
+			Topic:   target,
+			Key:     m.Key,
+			Value:   m.Value,
+			Headers: headers,
+		}); err != nil {
+			return fmt.Errorf("reinject %d/%d to %s: %w", m.Partition, m.Offset, target, err)
+		}
+		count++
+		fmt.Printf("reinjected %s/%d/%d -> %s\n", m.Topic, m.Partition, m.Offset, target)
+		return nil
+	})
+	fmt.Printf("reinjected %d message(s)\n", count)
+	return err
+}
+
+// scan читает сообщения DLQ в диапазоне офсетов по каждой партиции и передаёт их в fn.
+// Читает без consumer group, чтобы просмотр не сдвигал никаких офсетов.
+func scan(ctx context.Context, opts options, fn func(kafka.Message) error) error {
+	conn, err := kafka.DialContext(ctx, "tcp", opts.brokers[0])
+	if err != nil {
+		return err
+	}
+	partitions, err := conn.ReadPartitions(opts.topic)
+	conn.Close()
+	if err != nil {
+		return err
+	}
+
+	seen := 0
+	for _, p := range partitions {
+		if opts.partition >= 0 && p.ID != opts.partition {
+			continue
+		}
+		first, last, err := partitionOffsets(ctx, opts, p.ID)
+		if err != nil {
+			return err
+		}
+		start := first
+		if opts.from > start {
+			start = opts.from
+		}
+		end := last // офсет следующего сообщения
+		if opts.to >= 0 && opts.to+1 < end {
+			end = opts.to + 1
+		}
+		if start >= end {
+			continue
+		}
+
+		n, err := scanPartition(ctx, opts, p.ID, start, end, seen, fn)
+		seen += n
+		if err != nil {
+			return err
+		}
+		if opts.limit > 0 && seen >= opts.limit {
+			return nil
+		}
+	}
+	return nil
+}
+
+func scanPartition(ctx context.Context, opts options, partition int, start, end int64, seen int, fn func(kafka.Message) error) (int, error) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   opts.brokers,
+		Topic:     opts.topic,
+		Partition: partition,
+		MaxWait:   time.Second,
+	})
+	defer reader.Close()
+	if err := reader.SetOffset(start); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for offset := start; offset < end; {
+		if opts.limit > 0 && seen+n >= opts.limit {
+			break
+		}
+		m, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return n, nil
+			}
+			return n, err
+		}
+		if m.Offset >= end {
+			break
+		}
+		if err := fn(m); err != nil {
+			return n, err
+		}
+		n++
+		offset = m.Offset + 1
+	}
+	return n, nil
+}
+
+func partitionOffsets(ctx context.Context, opts options, partition int) (int64, int64, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", opts.brokers[0], opts.topic, partition)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+	return conn.ReadOffsets()
+}
+
+func header(m kafka.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+func defaultTopic() string {
+	if t := os.Getenv("KAFKA_DLQ_TOPIC"); t != "" {
+		return t
+	}
+	return envOr("KAFKA_RESOURCE_TOPIC", "kafka-resource-usage-topic") + "-dlq"
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
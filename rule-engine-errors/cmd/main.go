@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	postgres "rule-engine-errors/internal/dataproviders/postgres_repository"
@@ -15,6 +17,7 @@ import (
 	kafkaRepository "rule-engine-errors/internal/dataproviders/kafka_repository"
 	redisRepository "rule-engine-errors/internal/dataproviders/redis_repository"
 	timescaleRepository "rule-engine-errors/internal/dataproviders/timescale_repository"
+	"rule-engine-errors/internal/transport"
 	"rule-engine-errors/internal/usecases"
 
 	"github.com/redis/go-redis/v9"
@@ -73,10 +76,16 @@ func main() {
 
 	// Инициализация репозитория правил из Mongo
 	//ruleRepo := mongoRepository.NewMongoRuleRepository(db, "rules_errors", logger)
-	ruleRepo, err := postgres.NewPostgresRuleRepository(&logger, cfg)
+	db, err := postgres.NewPostgresDB(&logger, cfg)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to init Postgres repo")
+		logger.Fatal().Err(err).Msg("Failed to init Postgres")
 	}
+	defer db.Close()
+	ruleRepo := postgres.NewPostgresRuleRepository(&logger, db)
+	correlationRepo := postgres.NewPostgresCorrelationRuleRepository(&logger, db)
+	maintenanceRepo := postgres.NewPostgresMaintenanceRepository(&logger, db)
+	silenceRepo := postgres.NewPostgresSilenceRepository(&logger, db)
+	fingerprints := postgres.NewPostgresFingerprintStore(&logger, db)
 
 	// Подключение к Redis
 	rdb := redis.NewClient(&redis.Options{
@@ -86,8 +95,13 @@ func main() {
 	})
 	defer rdb.Close()
 
-	repeatCounter := redisRepository.NewRedisRepeatCounter(rdb, 300, &logger)
+	repeatCounter := redisRepository.NewRedisRepeatCounter(rdb, internal.RepeatMaxWindowSec, &logger)
+	distinctCounter := redisRepository.NewRedisDistinctCounter(rdb, internal.RepeatMaxWindowSec, &logger)
 	redisCache := redisRepository.NewRedisRuleCache(rdb, internal.RulesCacheTTL, &logger)
+	alertCooldown := redisRepository.NewRedisAlertCooldown(rdb, usecases.ENGINE, &logger)
+	correlationState := redisRepository.NewRedisCorrelationState(rdb, &logger)
+	silenceCache := redisRepository.NewRedisSilenceCache(rdb, internal.SilencesCacheTTL, &logger)
+	monitorState := redisRepository.NewRedisMonitorState(rdb, &logger)
 
 	// Разбиваем список брокеров (ожидается, что в конфигурации они разделены запятыми)
 	kafkaBrokers := strings.Split(cfg.Kafka.Brokers, ",")
@@ -109,16 +123,39 @@ func main() {
 		timeScaleRepo,
 		dispatcher,
 		repeatCounter,
+		distinctCounter,
 		redisCache,
+		alertCooldown,
+		fingerprints,
+		correlationRepo,
+		correlationState,
+		maintenanceRepo,
+		silenceRepo,
+		silenceCache,
 		&logger,
 	)
 
+	// Dead-letter очередь для сообщений, которые не удалось обработать
+	dlq := kafkaRepository.NewDeadLetterProducer(kafkaBrokers, cfg.DLQTopic(), &logger)
+	defer dlq.Close()
+
 	// Инициализация Kafka Consumer (ручной коммит: CommitInterval = 0)
 	consumer := kafkaRepository.NewRuleEngineConsumer(
 		kafkaBrokers,
 		cfg.Kafka.ConsumerGroup,
 		cfg.Kafka.ResourceTopic,
 		evalUC,
+		dlq,
+		kafkaRepository.RetryPolicy{
+			MaxAttempts:    cfg.Kafka.RetryMaxAttempts,
+			InitialBackoff: cfg.Kafka.RetryInitialBackoff,
+			MaxBackoff:     cfg.Kafka.RetryMaxBackoff,
+		},
+		kafkaRepository.WorkerPoolConfig{
+			WorkersPerPartition: cfg.Kafka.PartitionWorkers,
+			MaxInFlight:         cfg.Kafka.MaxInFlight,
+			DrainTimeout:        cfg.Kafka.DrainTimeout,
+		},
 		&logger,
 	)
 	defer consumer.Close()
@@ -128,18 +165,100 @@ func main() {
 	defer cancel()
 
 	// Запуск consumer в отдельной горутине
+	consumerDone := make(chan struct{})
 	go func() {
+		defer close(consumerDone)
 		if err := consumer.Run(ctx); err != nil {
 			logger.Error().Err(err).Msg("Resource consumer stopped with error")
 		}
 	}()
 
+	// Сброс кеша правил по уведомлениям public API об изменениях
+	ruleChanges := postgres.NewRuleChangeListener(cfg, usecases.ENGINE, evalUC, silenceCache, &logger)
+	defer ruleChanges.Close()
+	go func() {
+		if err := ruleChanges.Run(ctx); err != nil {
+			logger.Error().Err(err).Msg("Rule change listener stopped with error")
+		}
+	}()
+
+	// Мониторы cron-задач: check-in'ы обрабатывает любая реплика, пропуски ищет только лидер
+	monitorRepo := postgres.NewPostgresMonitorRepository(&logger, db)
+	monitorRegistry := usecases.NewMonitorRegistry(monitorRepo, &logger)
+	if err := monitorRegistry.Refresh(ctx); err != nil {
+		logger.Error().Err(err).Msg("Failed to load monitors")
+	}
+
+	leaderLock := redisRepository.NewRedisLeaderLock(rdb, internal.MonitorLeaderKey, replicaID(), cfg.Monitors.LeaderTTL, &logger)
+	scheduler := usecases.NewMonitorScheduler(monitorRegistry, monitorState, leaderLock, dispatcher, cfg.Monitors.CheckInterval, &logger)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		if err := scheduler.Run(ctx); err != nil {
+			logger.Error().Err(err).Msg("Monitor scheduler stopped with error")
+		}
+	}()
+
+	checkInConsumer := kafkaRepository.NewCheckInConsumer(
+		kafkaBrokers,
+		cfg.Kafka.CheckInConsumerGroup,
+		cfg.Kafka.CheckInTopic,
+		usecases.NewCheckInUseCase(monitorRegistry, monitorState, dispatcher, &logger),
+		kafkaRepository.RetryPolicy{
+			MaxAttempts:    cfg.Kafka.RetryMaxAttempts,
+			InitialBackoff: cfg.Kafka.RetryInitialBackoff,
+			MaxBackoff:     cfg.Kafka.RetryMaxBackoff,
+		},
+		&logger,
+	)
+	defer checkInConsumer.Close()
+	checkInDone := make(chan struct{})
+	go func() {
+		defer close(checkInDone)
+		if err := checkInConsumer.Run(ctx); err != nil {
+			logger.Error().Err(err).Msg("Check-in consumer stopped with error")
+		}
+	}()
+
+	// Служебный HTTP-сервер для dry-run правил
+	httpServer, err := transport.NewHTTPServer(cfg.HTTPAddr, cfg.HTTPToken, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid HTTP server config")
+	}
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error().Err(err).Msg("HTTP server stopped with error")
+		}
+	}()
+
 	logger.Info().Msg("Resource Rule Engine running. Waiting for signal...")
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 
 	logger.Info().Msg("Shutting down Resource Rule Engine gracefully...")
+
+	// Перестаём читать Kafka и ждём, пока consumer дообработает и закоммитит прочитанные сообщения
+	// (не дольше KAFKA_DRAIN_TIMEOUT), и только потом закрываем Redis, Timescale и dispatcher
+	cancel()
+	<-consumerDone
+	<-checkInDone
+	<-schedulerDone
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("Failed to shut down HTTP server")
+	}
+}
+
+// replicaID – идентификатор реплики для блокировки лидера: hostname (имя пода) и pid.
+func replicaID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
 // initMongoWithAuth устанавливает соединение с MongoDB с аутентификацией.
@@ -2,4 +2,11 @@ package internal
 
 const (
 	RulesCacheTTL = 300
+	// SilencesCacheTTL – сколько silences проекта живут в Redis: новый или истёкший через API silence
+	// начинает (перестаёт) действовать не позже чем через это время
+	SilencesCacheTTL = 15
+	// RepeatMaxWindowSec – максимальное окно для repeat_over и distinct_over (сутки)
+	RepeatMaxWindowSec = 86400
+	// MonitorLeaderKey – ключ Redis, через который реплики выбирают лидера планировщика мониторов
+	MonitorLeaderKey = "monitor-scheduler:leader"
 )
@@ -0,0 +1,89 @@
+package kafka_repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"rule-engine-errors/internal/domain"
+	"rule-engine-errors/internal/usecases"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+)
+
+// CheckInConsumer читает check-in'ы cron-задач и передаёт их в CheckInUseCase.
+// Поток check-in'ов небольшой, поэтому сообщения обрабатываются последовательно.
+// Временные ошибки (Redis) повторяются по retry; после последней попытки check-in
+// пропускается – в худшем случае придёт лишний алерт о пропущенном запуске.
+type CheckInConsumer struct {
+	reader  *kafka.Reader
+	useCase *usecases.CheckInUseCase
+	retry   RetryPolicy
+	logger  *zerolog.Logger
+}
+
+// NewCheckInConsumer создаёт потребителя с ручным коммитом.
+func NewCheckInConsumer(brokers []string, groupID, topic string, uc *usecases.CheckInUseCase, retry RetryPolicy, logger *zerolog.Logger) *CheckInConsumer {
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+	return &CheckInConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:        brokers,
+			GroupID:        groupID,
+			Topic:          topic,
+			CommitInterval: 0, // ручной коммит
+		}),
+		useCase: uc,
+		retry:   retry,
+		logger:  logger,
+	}
+}
+
+// Run читает check-in'ы, пока не отменён ctx.
+func (c *CheckInConsumer) Run(ctx context.Context) error {
+	c.logger.Info().Msg("Started check-in worker")
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.logger.Error().Err(err).Msg("Error reading check-in message")
+			return err
+		}
+
+		c.handle(ctx, m)
+
+		if err := c.reader.CommitMessages(context.WithoutCancel(ctx), m); err != nil {
+			c.logger.Error().Err(err).Msgf("Failed to commit check-in offset %d", m.Offset)
+		}
+	}
+}
+
+func (c *CheckInConsumer) handle(ctx context.Context, m kafka.Message) {
+	var ci domain.CheckIn
+	if err := json.Unmarshal(m.Value, &ci); err != nil {
+		c.logger.Error().Err(err).Msgf("Failed to decode check-in at offset %d", m.Offset)
+		return
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.useCase.HandleCheckIn(ctx, &ci)
+		if err == nil {
+			return
+		}
+		if attempt >= c.retry.MaxAttempts {
+			c.logger.Error().Err(err).Msgf("Dropping check-in project=%s slug=%s after %d attempt(s)",
+				ci.ProjectID, ci.MonitorSlug, attempt)
+			return
+		}
+		if !sleep(ctx, c.retry.backoff(attempt)) {
+			return
+		}
+	}
+}
+
+func (c *CheckInConsumer) Close() error {
+	return c.reader.Close()
+}
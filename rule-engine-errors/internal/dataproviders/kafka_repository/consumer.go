@@ -3,6 +3,12 @@ package kafka_repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
 	"rule-engine-errors/internal/domain"
 	"rule-engine-errors/internal/usecases"
 
@@ -11,14 +17,30 @@ import (
 )
 
 // RuleEngineConsumer читает сообщения из Kafka и передаёт события в EvaluateRulesUseCase.
+// Сообщение, которое не удалось обработать за retry.MaxAttempts попыток, уходит в DLQ,
+// и только после этого коммитится – так ни одно событие не теряется молча.
+//
+// Сообщения обрабатываются параллельно пулом воркеров на каждую партицию: сообщения с одним
+// ключом всегда попадают к одному воркеру и обрабатываются по порядку. Офсет коммитится,
+// только когда обработаны все более ранние сообщения партиции (см. offsetTracker).
 type RuleEngineConsumer struct {
 	reader  *kafka.Reader
 	useCase *usecases.EvaluateRulesUseCase
+	dlq     *DeadLetterProducer
+	retry   RetryPolicy
+	pool    WorkerPoolConfig
 	logger  *zerolog.Logger
 }
 
+// WorkerPoolConfig – параллельная обработка сообщений.
+type WorkerPoolConfig struct {
+	WorkersPerPartition int           // воркеров на партицию
+	MaxInFlight         int           // сколько сообщений может обрабатываться одновременно (по всем партициям)
+	DrainTimeout        time.Duration // сколько ждать обработки уже прочитанных сообщений при остановке
+}
+
 // NewRuleEngineConsumer создаёт нового потребителя с ручным коммитом (CommitInterval: 0).
-func NewRuleEngineConsumer(brokers []string, groupID, topic string, uc *usecases.EvaluateRulesUseCase, logger *zerolog.Logger) *RuleEngineConsumer {
+func NewRuleEngineConsumer(brokers []string, groupID, topic string, uc *usecases.EvaluateRulesUseCase, dlq *DeadLetterProducer, retry RetryPolicy, pool WorkerPoolConfig, logger *zerolog.Logger) *RuleEngineConsumer {
 	readerConfig := kafka.ReaderConfig{
 		Brokers:        brokers,
 		GroupID:        groupID,
@@ -28,40 +50,235 @@ func NewRuleEngineConsumer(brokers []string, groupID, topic string, uc *usecases
 		CommitInterval: 0,    // ручной коммит
 	}
 	reader := kafka.NewReader(readerConfig)
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+	if pool.WorkersPerPartition < 1 {
+		pool.WorkersPerPartition = 1
+	}
+	if pool.MaxInFlight < 1 {
+		pool.MaxInFlight = 1
+	}
 	return &RuleEngineConsumer{
 		reader:  reader,
 		useCase: uc,
+		dlq:     dlq,
+		retry:   retry,
+		pool:    pool,
 		logger:  logger,
 	}
 }
 
-// Run запускает бесконечный цикл чтения сообщений.
+// Run читает сообщения, пока не отменён ctx. После отмены новые сообщения не читаются,
+// а уже прочитанные дообрабатываются и коммитятся в пределах pool.DrainTimeout.
+// Run возвращается только после остановки всех воркеров.
 func (rec *RuleEngineConsumer) Run(ctx context.Context) error {
-	rec.logger.Info().Msg("Started errors worker")
-	for {
-		m, err := rec.reader.ReadMessage(ctx)
-		if err != nil {
-			rec.logger.Error().Err(err).Msg("Error reading Kafka message")
-			return err
+	rec.logger.Info().Msgf("Started errors worker: %d worker(s) per partition, max in flight %d",
+		rec.pool.WorkersPerPartition, rec.pool.MaxInFlight)
+
+	// Обработка не прерывается отменой ctx: её отменяет только истёкший DrainTimeout
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
+
+	tracker := newOffsetTracker()
+	inFlight := make(chan struct{}, rec.pool.MaxInFlight)
+	completed := make(chan kafka.Message, rec.pool.MaxInFlight)
+	partitions := make(map[int][]chan kafka.Message)
+	var workers sync.WaitGroup
+
+	committerDone := make(chan struct{})
+	go func() {
+		defer close(committerDone)
+		rec.commitLoop(workCtx, tracker, completed)
+	}()
+
+	var runErr error
+	for runErr == nil {
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+			runErr = ctx.Err()
+			continue
 		}
-		var evt domain.Event
-		if err := json.Unmarshal(m.Value, &evt); err != nil {
-			rec.logger.Warn().Err(err).Msg("Failed to unmarshal event from Kafka")
+
+		m, err := rec.reader.FetchMessage(ctx)
+		if err != nil {
+			<-inFlight
+			if ctx.Err() == nil {
+				rec.logger.Error().Err(err).Msg("Error reading Kafka message")
+			}
+			runErr = err
 			continue
 		}
-		rec.logger.Debug().Msgf("Received Event: service=%s environment=%s level=%s", evt.ServiceName, evt.Environment, evt.EventType)
+		tracker.add(m)
 
-		// Обработка события и отправка сообщений в соответствующие топики
-		if err := rec.useCase.Evaluate(ctx, &evt); err != nil {
-			rec.logger.Error().Err(err).Msg("EvaluateRulesUseCase returned error")
+		queues, ok := partitions[m.Partition]
+		if !ok {
+			queues = make([]chan kafka.Message, rec.pool.WorkersPerPartition)
+			for i := range queues {
+				queues[i] = make(chan kafka.Message, rec.pool.MaxInFlight)
+				workers.Add(1)
+				go func(queue <-chan kafka.Message) {
+					defer workers.Done()
+					rec.work(workCtx, queue, inFlight, completed)
+				}(queues[i])
+			}
+			partitions[m.Partition] = queues
+		}
+		queues[workerIndex(m.Key, len(queues))] <- m
+	}
+
+	// Остановка: дожидаемся воркеров и коммитим всё, что успело обработаться
+	rec.logger.Info().Msgf("Draining in-flight messages (timeout %s)", rec.pool.DrainTimeout)
+	for _, queues := range partitions {
+		for _, q := range queues {
+			close(q)
+		}
+	}
+	go func() {
+		workers.Wait()
+		close(completed)
+	}()
+
+	drainTimer := time.NewTimer(rec.pool.DrainTimeout)
+	defer drainTimer.Stop()
+	select {
+	case <-committerDone:
+		rec.logger.Info().Msg("Errors worker drained")
+	case <-drainTimer.C:
+		rec.logger.Warn().Msg("Drain timeout exceeded, uncommitted messages will be redelivered")
+		cancelWork()
+		<-committerDone
+	}
+
+	if errors.Is(runErr, context.Canceled) {
+		return nil
+	}
+	return runErr
+}
+
+// work обрабатывает сообщения одного воркера по порядку.
+func (rec *RuleEngineConsumer) work(ctx context.Context, queue <-chan kafka.Message, inFlight <-chan struct{}, completed chan<- kafka.Message) {
+	for m := range queue {
+		if err := rec.handle(ctx, m); err != nil {
+			// Обработка прервана по DrainTimeout: сообщение не коммитится и будет прочитано снова
+			rec.logger.Warn().Err(err).Msgf("Message %d/%d left unprocessed", m.Partition, m.Offset)
+		} else {
+			completed <- m
+		}
+		<-inFlight
+	}
+}
+
+// commitLoop коммитит офсеты по мере обработки. Завершения, накопившиеся к моменту коммита,
+// объединяются: коммитится только последний готовый офсет каждой партиции.
+func (rec *RuleEngineConsumer) commitLoop(ctx context.Context, tracker *offsetTracker, completed <-chan kafka.Message) {
+	for m := range completed {
+		ready := make(map[int]kafka.Message)
+		if next, ok := tracker.complete(m); ok {
+			ready[next.Partition] = next
+		}
+	batch:
+		for {
+			select {
+			case m, ok := <-completed:
+				if !ok {
+					break batch
+				}
+				if next, ok := tracker.complete(m); ok {
+					ready[next.Partition] = next
+				}
+			default:
+				break batch
+			}
+		}
+		if len(ready) == 0 {
 			continue
 		}
 
-		// После успешной отправки сообщений выполняем ручной коммит
-		if err := rec.reader.CommitMessages(ctx, m); err != nil {
-			rec.logger.Error().Err(err).Msg("Failed to commit message")
+		msgs := make([]kafka.Message, 0, len(ready))
+		for _, next := range ready {
+			msgs = append(msgs, next)
+		}
+		// После успешной обработки (или переноса в DLQ) выполняем ручной коммит
+		if err := rec.reader.CommitMessages(ctx, msgs...); err != nil {
+			rec.logger.Error().Err(err).Msg("Failed to commit messages")
 		} else {
-			rec.logger.Info().Msg("Message committed successfully")
+			for _, next := range msgs {
+				rec.logger.Info().Msgf("Committed offset %d of partition %d", next.Offset, next.Partition)
+			}
+		}
+	}
+}
+
+// workerIndex выбирает воркера по ключу сообщения, чтобы сообщения одного ключа шли по порядку.
+func workerIndex(key []byte, n int) int {
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(n))
+}
+
+// handle обрабатывает сообщение с повторами. Ошибка разбора JSON не повторяется:
+// такое сообщение сразу уходит в DLQ. Возвращает ошибку только при отмене ctx.
+//
+// Правила проверяются один раз: Evaluate меняет состояние в Redis (cooldown, состояние алертов,
+// окна), и повторная проверка увидела бы алерт уже подавленным. Повторяется только доставка
+// (Deliver) с уже посчитанным результатом; Evaluate повторяется, только пока не загрузились правила.
+func (rec *RuleEngineConsumer) handle(ctx context.Context, m kafka.Message) error {
+	var evt domain.Event
+	if err := json.Unmarshal(m.Value, &evt); err != nil {
+		rec.logger.Warn().Err(err).Msg("Failed to unmarshal event from Kafka")
+		return rec.deadLetter(ctx, m, DLQStageDecode, 1, err)
+	}
+	rec.logger.Debug().Msgf("Received Event: service=%s environment=%s level=%s", evt.ServiceName, evt.Environment, evt.EventType)
+
+	var ev *usecases.Evaluation
+	var lastErr error
+	for attempt := 1; attempt <= rec.retry.MaxAttempts; attempt++ {
+		if ev == nil {
+			ev, lastErr = rec.useCase.Evaluate(ctx, &evt)
+		}
+		// Отправка сообщений в соответствующие топики и запись лога
+		if ev != nil {
+			lastErr = rec.useCase.Deliver(ctx, ev)
+		}
+		if lastErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rec.logger.Error().Err(lastErr).Msgf("EvaluateRulesUseCase returned error (attempt %d/%d)", attempt, rec.retry.MaxAttempts)
+		if attempt < rec.retry.MaxAttempts && !sleep(ctx, rec.retry.backoff(attempt)) {
+			return ctx.Err()
+		}
+	}
+	stage := DLQStageEvaluate
+	if ev != nil {
+		stage = DLQStageDeliver
+		// Алерт не отправлен: занятые им окна cooldown не должны подавлять следующие
+		rec.useCase.Release(ctx, ev)
+	}
+	return rec.deadLetter(ctx, m, stage, rec.retry.MaxAttempts, lastErr)
+}
+
+// deadLetter пишет сообщение в DLQ. Пока DLQ недоступен, запись повторяется с backoff:
+// коммитить сообщение, которое не попало ни в обработку, ни в DLQ, нельзя.
+func (rec *RuleEngineConsumer) deadLetter(ctx context.Context, m kafka.Message, stage string, attempts int, cause error) error {
+	if rec.dlq == nil {
+		rec.logger.Error().Err(cause).Msgf("DLQ is not configured, dropping message %s/%d/%d", m.Topic, m.Partition, m.Offset)
+		return nil
+	}
+	for i := 1; ; i++ {
+		err := rec.dlq.Publish(ctx, m, stage, attempts, cause)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("publish to DLQ: %w", ctx.Err())
+		}
+		if !sleep(ctx, rec.retry.backoff(i)) {
+			return fmt.Errorf("publish to DLQ: %w", ctx.Err())
 		}
 	}
 }
@@ -0,0 +1,80 @@
+package kafka_repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+)
+
+// Заголовки, которые DLQ-сообщение получает поверх заголовков исходного сообщения.
+const (
+	HeaderDLQError             = "x-dlq-error"
+	HeaderDLQStage             = "x-dlq-stage"
+	HeaderDLQAttempts          = "x-dlq-attempts"
+	HeaderDLQFailedAt          = "x-dlq-failed-at"
+	HeaderDLQOriginalTopic     = "x-dlq-original-topic"
+	HeaderDLQOriginalPartition = "x-dlq-original-partition"
+	HeaderDLQOriginalOffset    = "x-dlq-original-offset"
+)
+
+// Этап, на котором сообщение не удалось обработать.
+const (
+	DLQStageDecode   = "decode"
+	DLQStageEvaluate = "evaluate"
+	DLQStageDeliver  = "deliver" // правила проверены, но действия или лог не доставлены
+)
+
+// DeadLetterProducer пишет необработанные сообщения в dead-letter топик
+// вместе с причиной ошибки в заголовках Kafka.
+type DeadLetterProducer struct {
+	writer *kafka.Writer
+	logger *zerolog.Logger
+}
+
+func NewDeadLetterProducer(brokers []string, topic string, logger *zerolog.Logger) *DeadLetterProducer {
+	return &DeadLetterProducer{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  topic,
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+		logger: logger,
+	}
+}
+
+// Publish отправляет исходное сообщение m (ключ, тело и заголовки без изменений) в DLQ.
+func (p *DeadLetterProducer) Publish(ctx context.Context, m kafka.Message, stage string, attempts int, cause error) error {
+	headers := make([]kafka.Header, 0, len(m.Headers)+7)
+	headers = append(headers, m.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: HeaderDLQError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderDLQStage, Value: []byte(stage)},
+		kafka.Header{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDLQFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+		kafka.Header{Key: HeaderDLQOriginalTopic, Value: []byte(m.Topic)},
+		kafka.Header{Key: HeaderDLQOriginalPartition, Value: []byte(strconv.Itoa(m.Partition))},
+		kafka.Header{Key: HeaderDLQOriginalOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+	)
+
+	err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		p.logger.Error().Err(err).Msgf("Failed to publish message to DLQ topic %s", p.writer.Topic)
+		return err
+	}
+	p.logger.Warn().Msgf("Message %s/%d/%d moved to DLQ topic %s: stage=%s attempts=%d error=%v",
+		m.Topic, m.Partition, m.Offset, p.writer.Topic, stage, attempts, cause)
+	return nil
+}
+
+// Close закрывает writer DLQ.
+func (p *DeadLetterProducer) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,76 @@
+package kafka_repository
+
+import (
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// offsetTracker следит за сообщениями, которые обрабатываются параллельно,
+// и отдаёт офсет для коммита только когда все более ранние сообщения партиции обработаны.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionQueue
+}
+
+// partitionQueue – сообщения партиции в порядке чтения.
+type partitionQueue struct {
+	entries []*trackedMessage
+	byOffs  map[int64]*trackedMessage
+}
+
+type trackedMessage struct {
+	msg  kafka.Message
+	done bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[int]*partitionQueue)}
+}
+
+// add регистрирует прочитанное сообщение. Офсет не больше последнего означает,
+// что партиция пришла заново после ребалансировки: старая очередь сбрасывается,
+// а завершения сообщений из неё игнорируются (они будут прочитаны и обработаны повторно).
+func (t *offsetTracker) add(m kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	q, ok := t.partitions[m.Partition]
+	if !ok || (len(q.entries) > 0 && m.Offset <= q.entries[len(q.entries)-1].msg.Offset) {
+		q = &partitionQueue{byOffs: make(map[int64]*trackedMessage)}
+		t.partitions[m.Partition] = q
+	}
+	tm := &trackedMessage{msg: m}
+	q.entries = append(q.entries, tm)
+	q.byOffs[m.Offset] = tm
+}
+
+// complete отмечает сообщение обработанным и возвращает последнее сообщение
+// непрерывного обработанного префикса партиции – его офсет можно коммитить.
+func (t *offsetTracker) complete(m kafka.Message) (kafka.Message, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	q, ok := t.partitions[m.Partition]
+	if !ok {
+		return kafka.Message{}, false
+	}
+	tm, ok := q.byOffs[m.Offset]
+	if !ok {
+		return kafka.Message{}, false
+	}
+	tm.done = true
+
+	var last *trackedMessage
+	n := 0
+	for n < len(q.entries) && q.entries[n].done {
+		last = q.entries[n]
+		delete(q.byOffs, last.msg.Offset)
+		n++
+	}
+	if last == nil {
+		return kafka.Message{}, false
+	}
+	q.entries = q.entries[n:]
+	return last.msg, true
+}
@@ -0,0 +1,38 @@
+package kafka_repository
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy – сколько раз и с какой паузой повторять обработку сообщения
+// при временных ошибках (Postgres, Redis, TimescaleDB) перед отправкой в DLQ.
+type RetryPolicy struct {
+	MaxAttempts    int           // всего попыток, включая первую
+	InitialBackoff time.Duration // пауза после первой неудачи
+	MaxBackoff     time.Duration // верхняя граница паузы (пауза удваивается с каждой попыткой)
+}
+
+// backoff возвращает паузу после неудачной попытки attempt (с 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// sleep ждёт d или отмены ctx; false – контекст отменён.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// PostgresCorrelationRuleRepository читает корреляционные правила, которые создаются в public API.
+type PostgresCorrelationRuleRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// NewPostgresCorrelationRuleRepository возвращает репозиторий корреляционных правил на общем пуле соединений db.
+func NewPostgresCorrelationRuleRepository(logger *zerolog.Logger, db *sqlx.DB) *PostgresCorrelationRuleRepository {
+	return &PostgresCorrelationRuleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetCorrelationRules возвращает корреляционные правила проекта.
+func (cr *PostgresCorrelationRuleRepository) GetCorrelationRules(ctx context.Context, userID, projectID string) ([]domain.CorrelationRule, error) {
+	query := `
+		SELECT c.id, COALESCE(c.name, ''), c.kind, c.steps, c.window_sec,
+		       c.distinct_by, c.min_distinct, c.actions
+		FROM rule_engine.correlation_rules c
+		WHERE c.user_id = $1 AND c.project_id = $2;
+	`
+	rows, err := cr.db.QueryContext(ctx, query, userID, projectID)
+	if err != nil {
+		cr.logger.Error().Err(err).Msg("Failed to fetch correlation rules")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []domain.CorrelationRule
+	for rows.Next() {
+		var (
+			id                  int
+			r                   domain.CorrelationRule
+			stepsRaw, actionRaw []byte
+		)
+		if err := rows.Scan(&id, &r.Name, &r.Kind, &stepsRaw, &r.WindowSec,
+			&r.DistinctBy, &r.MinDistinct, &actionRaw); err != nil {
+			cr.logger.Warn().Err(err).Msg("Failed to scan correlation rule row")
+			continue
+		}
+		if err := json.Unmarshal(stepsRaw, &r.Steps); err != nil {
+			cr.logger.Warn().Err(err).Msgf("Failed to unmarshal steps of correlation rule %d", id)
+			continue
+		}
+		if len(actionRaw) > 0 {
+			if err := json.Unmarshal(actionRaw, &r.Actions); err != nil {
+				cr.logger.Warn().Err(err).Msgf("Failed to unmarshal actions of correlation rule %d", id)
+				continue
+			}
+		}
+		r.ID = strconv.Itoa(id)
+		r.UserID = userID
+		r.ProjectID = projectID
+		r.Version = domain.CorrelationVersion(r.Kind, r.WindowSec, stepsRaw, r.DistinctBy, r.MinDistinct)
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
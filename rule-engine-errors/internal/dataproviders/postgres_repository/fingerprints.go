@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// PostgresFingerprintStore хранит известные отпечатки ошибок по (project, service, environment)
+// и отдельно по версии сервиса – для операторов first_seen и first_seen_in_version.
+// Отпечатки живут в Postgres, а не в Redis: после сброса или вытеснения кеша
+// все ошибки сервиса не должны снова стать «новыми».
+type PostgresFingerprintStore struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// NewPostgresFingerprintStore возвращает хранилище отпечатков на общем пуле соединений db.
+func NewPostgresFingerprintStore(logger *zerolog.Logger, db *sqlx.DB) *PostgresFingerprintStore {
+	return &PostgresFingerprintStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// touchFingerprintQuery продлевает известный отпечаток и возвращает предыдущее появление.
+// Строка блокируется, поэтому параллельные события одной ошибки видят last_seen друг друга.
+const touchFingerprintQuery = `
+	UPDATE rule_engine.error_fingerprints f
+	SET last_seen = GREATEST(f.last_seen, $6)
+	FROM (
+		SELECT last_seen
+		FROM rule_engine.error_fingerprints
+		WHERE project_id = $1 AND service_name = $2 AND environment = $3 AND version = $4 AND fingerprint = $5
+		FOR UPDATE
+	) prev
+	WHERE f.project_id = $1 AND f.service_name = $2 AND f.environment = $3 AND f.version = $4 AND f.fingerprint = $5
+	RETURNING f.first_seen, prev.last_seen;
+`
+
+// insertFingerprintQuery добавляет новый отпечаток. Пустой результат – отпечаток уже добавило другое событие.
+const insertFingerprintQuery = `
+	INSERT INTO rule_engine.error_fingerprints (project_id, service_name, environment, version, fingerprint, first_seen, last_seen)
+	VALUES ($1, $2, $3, $4, $5, $6, $6)
+	ON CONFLICT DO NOTHING
+	RETURNING first_seen;
+`
+
+// Observe отмечает отпечаток fingerprint события e и возвращает, встречался ли он раньше.
+// Непустой version – отпечатки считаются отдельно для этой версии сервиса.
+func (s *PostgresFingerprintStore) Observe(ctx context.Context, e *domain.Event, fingerprint, version string, now time.Time) (domain.Sighting, error) {
+	args := []interface{}{e.ProjectId, e.ServiceName, e.Environment, version, fingerprint, now}
+	sighting := domain.Sighting{Fingerprint: fingerprint, Version: version}
+
+	// Вторая попытка нужна, только если отпечаток между UPDATE и INSERT добавило параллельное событие
+	for attempt := 0; attempt < 2; attempt++ {
+		var lastSeen time.Time
+		err := s.db.QueryRowContext(ctx, touchFingerprintQuery, args...).Scan(&sighting.FirstSeen, &lastSeen)
+		if err == nil {
+			sighting.LastSeen = &lastSeen
+			s.logger.Debug().Msgf("Fingerprint %s of service=%s: seen before", fingerprint, e.ServiceName)
+			return sighting, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Error().Err(err).Msg("Failed to update error fingerprint")
+			return domain.Sighting{}, err
+		}
+
+		err = s.db.QueryRowContext(ctx, insertFingerprintQuery, args...).Scan(&sighting.FirstSeen)
+		if err == nil {
+			sighting.New = true
+			s.logger.Debug().Msgf("Fingerprint %s of service=%s: new", fingerprint, e.ServiceName)
+			return sighting, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Error().Err(err).Msg("Failed to insert error fingerprint")
+			return domain.Sighting{}, err
+		}
+	}
+	return domain.Sighting{}, fmt.Errorf("fingerprint %s: concurrent insert was not visible", fingerprint)
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// PostgresMonitorRepository читает мониторы cron-задач, которые создаются в public API.
+type PostgresMonitorRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// NewPostgresMonitorRepository возвращает репозиторий мониторов на общем пуле соединений db.
+func NewPostgresMonitorRepository(logger *zerolog.Logger, db *sqlx.DB) *PostgresMonitorRepository {
+	return &PostgresMonitorRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetEnabledMonitors возвращает все включённые мониторы.
+func (mr *PostgresMonitorRepository) GetEnabledMonitors(ctx context.Context) ([]domain.Monitor, error) {
+	query := `
+		SELECT m.id, m.project_id, m.user_id, m.slug, m.name, m.schedule_type,
+		       m.cron_expr, m.interval_sec, m.grace_sec, m.timezone, m.actions
+		FROM rule_engine.monitors m
+		WHERE m.enabled;
+	`
+	rows, err := mr.db.QueryContext(ctx, query)
+	if err != nil {
+		mr.logger.Error().Err(err).Msg("Failed to fetch monitors")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var monitors []domain.Monitor
+	for rows.Next() {
+		var (
+			id, projectID, userID int
+			m                     domain.Monitor
+			actionsRaw            []byte
+		)
+		if err := rows.Scan(&id, &projectID, &userID, &m.Slug, &m.Name, &m.ScheduleType,
+			&m.CronExpr, &m.IntervalSec, &m.GraceSec, &m.Timezone, &actionsRaw); err != nil {
+			mr.logger.Warn().Err(err).Msg("Failed to scan monitor row")
+			continue
+		}
+		if err := json.Unmarshal(actionsRaw, &m.Actions); err != nil {
+			mr.logger.Warn().Err(err).Msgf("Failed to unmarshal actions of monitor %d", id)
+			continue
+		}
+		m.ID = strconv.Itoa(id)
+		m.ProjectID = strconv.Itoa(projectID)
+		m.UserID = strconv.Itoa(userID)
+		monitors = append(monitors, m)
+	}
+	return monitors, rows.Err()
+}
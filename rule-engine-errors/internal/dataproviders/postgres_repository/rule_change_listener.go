@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"rule-engine-errors/internal/config"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+)
+
+// RuleChangesChannel – канал LISTEN/NOTIFY, в который public API пишет об изменении правил и проектов.
+const RuleChangesChannel = "rule_changes"
+
+// listenerPingInterval – как часто проверять соединение, если уведомлений нет.
+const listenerPingInterval = 90 * time.Second
+
+// RuleChangeKindSilences – изменились silences проекта ProjectID, правила не затронуты.
+const RuleChangeKindSilences = "silences"
+
+// RuleChange – payload уведомления. RuleType пустой – изменились правила обоих движков.
+type RuleChange struct {
+	UserID    int64  `json:"user_id"`
+	RuleType  string `json:"rule_type,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// RuleCacheInvalidator – кеши правил (в памяти и в Redis), которые нужно сбрасывать при изменениях.
+type RuleCacheInvalidator interface {
+	InvalidateUser(ctx context.Context, userID string) (int, error)
+	InvalidateAll(ctx context.Context) (int, error)
+}
+
+// SilenceCacheInvalidator – кеш silences проекта.
+type SilenceCacheInvalidator interface {
+	InvalidateProject(ctx context.Context, projectID string) error
+}
+
+// RuleChangeListener слушает RuleChangesChannel и сбрасывает кеш правил пользователя
+// или silences проекта, чтобы изменения из public API применялись сразу, а не по истечении TTL.
+type RuleChangeListener struct {
+	listener *pq.Listener
+	cache    RuleCacheInvalidator
+	silences SilenceCacheInvalidator
+	ruleType string
+	logger   *zerolog.Logger
+}
+
+// NewRuleChangeListener создаёт слушателя для движка ruleType ("errors" / "resources").
+func NewRuleChangeListener(cfg *config.Config, ruleType string, cache RuleCacheInvalidator, silences SilenceCacheInvalidator, logger *zerolog.Logger) *RuleChangeListener {
+	reportProblem := func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Error().Err(err).Msgf("Rule change listener event %d", ev)
+		}
+	}
+	return &RuleChangeListener{
+		listener: pq.NewListener(makeDSN(cfg), 10*time.Second, time.Minute, reportProblem),
+		cache:    cache,
+		silences: silences,
+		ruleType: ruleType,
+		logger:   logger,
+	}
+}
+
+// Run подписывается на канал и обрабатывает уведомления до отмены ctx.
+func (l *RuleChangeListener) Run(ctx context.Context) error {
+	if err := l.listener.Listen(RuleChangesChannel); err != nil {
+		return err
+	}
+	l.logger.Info().Msgf("Listening for rule changes on channel %s", RuleChangesChannel)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-l.listener.Notify:
+			// nil приходит после переподключения: уведомления за время разрыва потеряны
+			if n == nil {
+				l.logger.Warn().Msg("Rule change listener reconnected, invalidating whole rules cache")
+				if _, err := l.cache.InvalidateAll(ctx); err != nil {
+					l.logger.Error().Err(err).Msg("Failed to invalidate rules cache")
+				}
+				continue
+			}
+			l.handle(ctx, n.Extra)
+		case <-time.After(listenerPingInterval):
+			if err := l.listener.Ping(); err != nil {
+				l.logger.Error().Err(err).Msg("Rule change listener ping failed")
+			}
+		}
+	}
+}
+
+func (l *RuleChangeListener) handle(ctx context.Context, payload string) {
+	var change RuleChange
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		l.logger.Error().Err(err).Msgf("Invalid rule change payload: %s", payload)
+		return
+	}
+	if change.Kind == RuleChangeKindSilences {
+		if err := l.silences.InvalidateProject(ctx, change.ProjectID); err != nil {
+			l.logger.Error().Err(err).Msgf("Failed to invalidate silences cache for project=%s", change.ProjectID)
+			return
+		}
+		l.logger.Info().Msgf("Silences changed for project=%s, cache invalidated", change.ProjectID)
+		return
+	}
+	if change.RuleType != "" && change.RuleType != l.ruleType {
+		return
+	}
+
+	userID := strconv.FormatInt(change.UserID, 10)
+	deleted, err := l.cache.InvalidateUser(ctx, userID)
+	if err != nil {
+		l.logger.Error().Err(err).Msgf("Failed to invalidate rules cache for user=%s", userID)
+		return
+	}
+	l.logger.Info().Msgf("Rules changed for user=%s, invalidated %d cache keys", userID, deleted)
+}
+
+func (l *RuleChangeListener) Close() error {
+	return l.listener.Close()
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// PostgresSilenceRepository читает silences проектов, которые создаются в public API.
+type PostgresSilenceRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// NewPostgresSilenceRepository возвращает репозиторий silences на общем пуле соединений db.
+func NewPostgresSilenceRepository(logger *zerolog.Logger, db *sqlx.DB) *PostgresSilenceRepository {
+	return &PostgresSilenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetActiveSilences возвращает silences проекта, которые действуют сейчас.
+func (sr *PostgresSilenceRepository) GetActiveSilences(ctx context.Context, projectID string) ([]domain.Silence, error) {
+	query := `
+		SELECT s.id, s.matchers, s.ends_at, s.created_by, s.comment
+		FROM rule_engine.silences s
+		WHERE s.project_id::text = $1 AND s.starts_at <= now() AND s.ends_at > now();
+	`
+	rows, err := sr.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		sr.logger.Error().Err(err).Msg("Failed to fetch silences")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var silences []domain.Silence
+	for rows.Next() {
+		var (
+			id          int
+			s           domain.Silence
+			matchersRaw []byte
+		)
+		if err := rows.Scan(&id, &matchersRaw, &s.EndsAt, &s.CreatedBy, &s.Comment); err != nil {
+			sr.logger.Warn().Err(err).Msg("Failed to scan silence row")
+			continue
+		}
+		if err := json.Unmarshal(matchersRaw, &s.Matchers); err != nil {
+			sr.logger.Warn().Err(err).Msgf("Failed to unmarshal matchers of silence %d", id)
+			continue
+		}
+		s.ID = strconv.Itoa(id)
+		silences = append(silences, s)
+	}
+	return silences, rows.Err()
+}
@@ -0,0 +1,121 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// suppressedCounterTTL – сколько живёт счётчик подавленных срабатываний.
+// Должен быть заметно больше cooldown, чтобы следующий алерт увидел накопленное число.
+const suppressedCounterTTL = 24 * time.Hour
+
+// cooldownScript атомарно занимает окно cooldown или считает подавленное срабатывание.
+// KEYS[1] – ключ окна, KEYS[2] – счётчик подавленных; ARGV[1] – cooldown (ms), ARGV[2] – TTL счётчика (ms),
+// ARGV[3] – токен владельца окна
+// Возвращает {1, подавлено_до_этого} если алерт можно слать, иначе {0, подавлено_всего}.
+var cooldownScript = redis.NewScript(`
+if redis.call('SET', KEYS[1], ARGV[3], 'NX', 'PX', ARGV[1]) then
+	local n = tonumber(redis.call('GET', KEYS[2]) or '0')
+	redis.call('DEL', KEYS[2])
+	return {1, n}
+end
+local n = redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return {0, n}
+`)
+
+// cooldownReleaseScript освобождает окно, занятое токеном ARGV[1], и возвращает в счётчик
+// подавленных ARGV[2] срабатываний, забранных при захвате. Окно, которое уже истекло
+// и занято заново, не трогается.
+// KEYS[1] – ключ окна, KEYS[2] – счётчик подавленных; ARGV[3] – TTL счётчика (ms)
+var cooldownReleaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+if tonumber(ARGV[2]) > 0 then
+	redis.call('INCRBY', KEYS[2], ARGV[2])
+	redis.call('PEXPIRE', KEYS[2], ARGV[3])
+end
+return 1
+`)
+
+// RedisAlertCooldown подавляет повторные алерты правила в пределах cooldown.
+// Redis общий у движков, а ID правил у них из разных таблиц, поэтому ключи содержат имя движка.
+type RedisAlertCooldown struct {
+	rdb    *redis.Client
+	engine string
+	logger *zerolog.Logger
+}
+
+// CooldownLease – занятое окно cooldown: по нему окно освобождается, если алерт так и не отправлен.
+type CooldownLease struct {
+	RuleID      string
+	Fingerprint string
+	Token       string
+	Suppressed  int
+}
+
+func NewRedisAlertCooldown(rdb *redis.Client, engine string, logger *zerolog.Logger) *RedisAlertCooldown {
+	return &RedisAlertCooldown{
+		rdb:    rdb,
+		engine: engine,
+		logger: logger,
+	}
+}
+
+// Acquire пытается занять окно cooldown для пары (ruleID, fingerprint).
+// allowed=true – алерт можно отправлять, suppressed – сколько срабатываний было подавлено с прошлого алерта.
+// allowed=false – алерт подавлен, suppressed – сколько срабатываний подавлено в текущем окне.
+// Занятое окно (allowed=true) возвращается в lease: если алерт не удалось отправить, его освобождает Release.
+func (c *RedisAlertCooldown) Acquire(ctx context.Context, ruleID, fingerprint string, cooldown time.Duration) (allowed bool, suppressed int, lease CooldownLease, err error) {
+	windowKey, counterKey := c.makeKeys(ruleID, fingerprint)
+	token := strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	res, err := cooldownScript.Run(ctx, c.rdb, []string{windowKey, counterKey},
+		cooldown.Milliseconds(),
+		suppressedCounterTTL.Milliseconds(),
+		token,
+	).Int64Slice()
+	if err != nil {
+		c.logger.Error().Err(err).Msgf("Failed to run cooldown script for key=%s", windowKey)
+		return false, 0, CooldownLease{}, err
+	}
+	if len(res) != 2 {
+		return false, 0, CooldownLease{}, fmt.Errorf("unexpected cooldown script result: %v", res)
+	}
+
+	c.logger.Debug().Msgf("Cooldown for key=%s: allowed=%v suppressed=%d", windowKey, res[0] == 1, res[1])
+	if res[0] != 1 {
+		return false, int(res[1]), CooldownLease{}, nil
+	}
+	return true, int(res[1]), CooldownLease{RuleID: ruleID, Fingerprint: fingerprint, Token: token, Suppressed: int(res[1])}, nil
+}
+
+// Release освобождает окно cooldown неотправленного алерта: следующее срабатывание отправится,
+// а подавленные до него снова войдут в его счётчик.
+func (c *RedisAlertCooldown) Release(ctx context.Context, lease CooldownLease) error {
+	windowKey, counterKey := c.makeKeys(lease.RuleID, lease.Fingerprint)
+	err := cooldownReleaseScript.Run(ctx, c.rdb, []string{windowKey, counterKey},
+		lease.Token,
+		lease.Suppressed,
+		suppressedCounterTTL.Milliseconds(),
+	).Err()
+	if err != nil {
+		c.logger.Error().Err(err).Msgf("Failed to release cooldown for key=%s", windowKey)
+		return err
+	}
+	return nil
+}
+
+// makeKeys
+// cooldown:engine:rule_id:fingerprint и cooldown-suppressed:engine:rule_id:fingerprint
+func (c *RedisAlertCooldown) makeKeys(ruleID, fingerprint string) (string, string) {
+	return fmt.Sprintf("cooldown:%s:%s:%s", c.engine, ruleID, fingerprint),
+		fmt.Sprintf("cooldown-suppressed:%s:%s:%s", c.engine, ruleID, fingerprint)
+}
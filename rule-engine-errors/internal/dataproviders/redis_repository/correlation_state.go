@@ -0,0 +1,178 @@
+package redis_repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// correlationMaxEvents – сколько событий co_occurrence хранится в окне одного правила.
+// Самые старые вытесняются: шаг, который они закрывали, придётся дождаться снова.
+const correlationMaxEvents = 500
+
+// sequenceScript продвигает частичное совпадение sequence-правила.
+// KEYS[1] – ключ состояния (hash: next, started, events)
+// ARGV[1] – индекс шага события, ARGV[2] – число шагов, ARGV[3] – текущее время (ms),
+// ARGV[4] – окно (ms), ARGV[5] – событие (JSON)
+// Возвращает события всех шагов, если последовательность собрана, иначе пустой список.
+var sequenceScript = redis.NewScript(`
+local key = KEYS[1]
+local step = tonumber(ARGV[1])
+local total = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local window = tonumber(ARGV[4])
+local ev = ARGV[5]
+
+local nxt = tonumber(redis.call('HGET', key, 'next') or '0')
+local started = tonumber(redis.call('HGET', key, 'started') or '0')
+if nxt > 0 and now - started > window then
+	redis.call('DEL', key)
+	nxt = 0
+end
+
+if nxt > 0 and step == nxt then
+	local events = cjson.decode(redis.call('HGET', key, 'events'))
+	table.insert(events, ev)
+	if step == total - 1 then
+		redis.call('DEL', key)
+		return events
+	end
+	redis.call('HSET', key, 'next', step + 1, 'events', cjson.encode(events))
+	return {}
+end
+
+-- первый шаг начинает последовательность заново, пока не пройден второй:
+-- окно отсчитывается от последнего A перед B
+if step == 0 and nxt <= 1 then
+	if total == 1 then
+		return {ev}
+	end
+	redis.call('HSET', key, 'next', 1, 'started', now, 'events', cjson.encode({ev}))
+	redis.call('PEXPIRE', key, window)
+end
+return {}
+`)
+
+// coOccurrenceScript добавляет событие в окно co_occurrence-правила.
+// KEYS[1] – ключ состояния (zset: событие JSON => время, ms)
+// ARGV[1] – число шагов, ARGV[2] – min_distinct, ARGV[3] – текущее время (ms),
+// ARGV[4] – окно (ms), ARGV[5] – событие (JSON), ARGV[6] – предел событий в окне
+// Возвращает события окна, если в нём есть все шаги и не меньше min_distinct разных значений distinct.
+var coOccurrenceScript = redis.NewScript(`
+local key = KEYS[1]
+local total = tonumber(ARGV[1])
+local minDistinct = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local window = tonumber(ARGV[4])
+local limit = tonumber(ARGV[6])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+redis.call('ZADD', key, now, ARGV[5])
+local n = redis.call('ZCARD', key)
+if n > limit then
+	redis.call('ZREMRANGEBYRANK', key, 0, n - limit - 1)
+end
+redis.call('PEXPIRE', key, window)
+
+local members = redis.call('ZRANGE', key, 0, -1)
+local steps, nsteps, values, ndistinct = {}, 0, {}, 0
+for _, m in ipairs(members) do
+	local e = cjson.decode(m)
+	if not steps[e.step] then
+		steps[e.step] = true
+		nsteps = nsteps + 1
+	end
+	local d = e.distinct
+	if type(d) == 'string' and d ~= '' and not values[d] then
+		values[d] = true
+		ndistinct = ndistinct + 1
+	end
+end
+
+if nsteps >= total and ndistinct >= minDistinct then
+	redis.call('DEL', key)
+	return members
+end
+return {}
+`)
+
+// RedisCorrelationState хранит частичные совпадения корреляционных правил.
+// Состояние общее для движков ошибок и ресурсов: шаги правила могут приходить из разных потоков.
+// Порядок шагов sequence – порядок, в котором движки обработали события (время движка, а не время события).
+type RedisCorrelationState struct {
+	rdb    *redis.Client
+	logger *zerolog.Logger
+}
+
+func NewRedisCorrelationState(rdb *redis.Client, logger *zerolog.Logger) *RedisCorrelationState {
+	return &RedisCorrelationState{
+		rdb:    rdb,
+		logger: logger,
+	}
+}
+
+// Observe отмечает, что событие ev закрыло шаг step правила r.
+// Возвращает события всех шагов, если правило сработало; nil – совпадение ещё частичное.
+// Сработавшее правило сбрасывает состояние, следующее совпадение собирается с нуля.
+func (s *RedisCorrelationState) Observe(ctx context.Context, r domain.CorrelationRule, step int, ev domain.CorrelatedEvent, now time.Time) ([]domain.CorrelatedEvent, error) {
+	key := s.makeKey(r)
+	ev.Step = step
+	raw, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	switch r.Kind {
+	case domain.CorrelationSequence:
+		res, err = sequenceScript.Run(ctx, s.rdb, []string{key},
+			step,
+			len(r.Steps),
+			now.UnixMilli(),
+			r.Window().Milliseconds(),
+			string(raw),
+		).StringSlice()
+	case domain.CorrelationCoOccurrence:
+		res, err = coOccurrenceScript.Run(ctx, s.rdb, []string{key},
+			len(r.Steps),
+			r.MinDistinct,
+			now.UnixMilli(),
+			r.Window().Milliseconds(),
+			string(raw),
+			correlationMaxEvents,
+		).StringSlice()
+	default:
+		return nil, fmt.Errorf("unknown correlation kind %q", r.Kind)
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Msgf("Failed to run correlation script for key=%s", key)
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	events := make([]domain.CorrelatedEvent, 0, len(res))
+	for _, item := range res {
+		var e domain.CorrelatedEvent
+		if err := json.Unmarshal([]byte(item), &e); err != nil {
+			s.logger.Warn().Err(err).Msgf("Failed to unmarshal correlated event for key=%s", key)
+			continue
+		}
+		events = append(events, e)
+	}
+	s.logger.Debug().Msgf("Correlation rule %s completed with %d events", r.ID, len(events))
+	return events, nil
+}
+
+// makeKey
+// correlation:rule_id:version
+func (s *RedisCorrelationState) makeKey(r domain.CorrelationRule) string {
+	return fmt.Sprintf("correlation:%s:%s", r.ID, r.Version)
+}
@@ -0,0 +1,89 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// distinctMaxBuckets – на сколько HyperLogLog-корзин делится окно distinct_over.
+// Окно до часа считается поминутно, длиннее – корзинами по window/60.
+const distinctMaxBuckets = 60
+
+// distinctScript добавляет значение в текущую корзину и оценивает число уникальных значений по всем корзинам окна.
+// KEYS[1] – текущая корзина, KEYS[2..] – предыдущие корзины окна
+// ARGV[1] – значение ("" – событие без значения: только подсчёт), ARGV[2] – TTL корзины (ms)
+var distinctScript = redis.NewScript(`
+if ARGV[1] ~= '' then
+	redis.call('PFADD', KEYS[1], ARGV[1])
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return redis.call('PFCOUNT', unpack(KEYS))
+`)
+
+// RedisDistinctCounter оценивает число уникальных значений поля за скользящее окно (оператор distinct_over).
+// Окно состоит из корзин HyperLogLog: PFCOUNT по нескольким ключам объединяет их без копирования.
+// Окно сдвигается с шагом в одну корзину, оценка – с погрешностью HyperLogLog (~0.8%).
+type RedisDistinctCounter struct {
+	rdb              *redis.Client
+	maxTimeWindowSec int
+	logger           *zerolog.Logger
+}
+
+func NewRedisDistinctCounter(rdb *redis.Client, maxSec int, logger *zerolog.Logger) *RedisDistinctCounter {
+	return &RedisDistinctCounter{
+		rdb:              rdb,
+		maxTimeWindowSec: maxSec,
+		logger:           logger,
+	}
+}
+
+// CountDistinct добавляет value события в окно поля field правила r и возвращает
+// оценку числа уникальных значений за последние minutes минут.
+// Пустой value (у события нет поля) в окно не добавляется, но оценка возвращается.
+func (dc *RedisDistinctCounter) CountDistinct(ctx context.Context, e *domain.Event, r domain.Rule, field, value string, minutes int) (int, error) {
+	window := time.Duration(minutes) * time.Minute
+	maxWindow := time.Duration(dc.maxTimeWindowSec) * time.Second
+	if window > maxWindow {
+		dc.logger.Warn().Msgf("distinct_over window %s exceeds max %s for rule=%s, clamping", window, maxWindow, r.ID)
+		window = maxWindow
+	}
+	if window <= 0 {
+		return 0, fmt.Errorf("distinct_over window must be positive, got %d minutes", minutes)
+	}
+
+	bucket := time.Minute
+	if window > distinctMaxBuckets*time.Minute {
+		bucket = (window + distinctMaxBuckets - 1) / distinctMaxBuckets
+	}
+	buckets := int((window + bucket - 1) / bucket)
+
+	current := time.Now().UnixMilli() / bucket.Milliseconds()
+	keys := make([]string, 0, buckets)
+	for i := 0; i < buckets; i++ {
+		keys = append(keys, dc.makeKey(e, r.ID, field, bucket, current-int64(i)))
+	}
+
+	cnt, err := distinctScript.Run(ctx, dc.rdb, keys,
+		value,
+		(window + bucket).Milliseconds(),
+	).Int()
+	if err != nil {
+		dc.logger.Error().Err(err).Msgf("Failed to run distinct script for key=%s", keys[0])
+		return 0, err
+	}
+
+	dc.logger.Debug().Msgf("CountDistinct for key=%s, minutes=%d => ~%d", keys[0], minutes, cnt)
+	return cnt, nil
+}
+
+// makeKey
+// distinct-rule_id-user_id-service_name-environment-field-bucket_sec-bucket_index
+func (dc *RedisDistinctCounter) makeKey(e *domain.Event, ruleId, field string, bucket time.Duration, index int64) string {
+	return fmt.Sprintf("distinct-%s-%s-%s-%s-%s-%d-%d", ruleId, e.UserID, e.ServiceName, e.Environment, field, int(bucket.Seconds()), index)
+}
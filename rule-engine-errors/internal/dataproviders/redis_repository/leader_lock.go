@@ -0,0 +1,71 @@
+package redis_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// leaderAcquireScript занимает или продлевает лидерство.
+// KEYS[1] – ключ блокировки; ARGV[1] – id реплики, ARGV[2] – TTL (ms)
+// Возвращает 1, если реплика – лидер.
+var leaderAcquireScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 1
+end
+return 0
+`)
+
+// leaderReleaseScript снимает блокировку, только если её держит эта реплика.
+var leaderReleaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RedisLeaderLock – выбор лидера среди реплик через ключ с TTL.
+// Лидер продлевает ключ на каждом Acquire; если он упал, лидерство переходит к другой реплике через TTL.
+type RedisLeaderLock struct {
+	rdb    *redis.Client
+	key    string
+	id     string
+	ttl    time.Duration
+	logger *zerolog.Logger
+}
+
+// NewRedisLeaderLock создаёт блокировку key для реплики id. TTL должен быть больше интервала Acquire.
+func NewRedisLeaderLock(rdb *redis.Client, key, id string, ttl time.Duration, logger *zerolog.Logger) *RedisLeaderLock {
+	return &RedisLeaderLock{
+		rdb:    rdb,
+		key:    key,
+		id:     id,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+// Acquire возвращает true, если реплика является лидером (заняла или продлила блокировку).
+func (l *RedisLeaderLock) Acquire(ctx context.Context) (bool, error) {
+	res, err := leaderAcquireScript.Run(ctx, l.rdb, []string{l.key}, l.id, l.ttl.Milliseconds()).Int()
+	if err != nil {
+		l.logger.Error().Err(err).Msgf("Failed to acquire leader lock key=%s", l.key)
+		return false, err
+	}
+	return res == 1, nil
+}
+
+// Release отдаёт лидерство при остановке, чтобы другая реплика не ждала TTL.
+func (l *RedisLeaderLock) Release(ctx context.Context) error {
+	if err := leaderReleaseScript.Run(ctx, l.rdb, []string{l.key}, l.id).Err(); err != nil {
+		l.logger.Error().Err(err).Msgf("Failed to release leader lock key=%s", l.key)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// monitorStateTTL – сколько хранится состояние монитора без записей. Больше месяца,
+// чтобы не терять ожидаемый запуск у ежемесячных задач.
+const monitorStateTTL = 90 * 24 * time.Hour
+
+// monitorCheckInScript записывает check-in, если он не старше уже записанного.
+// KEYS[1] – ключ состояния (hash: next_due, last_checkin, last_status, service, env)
+// ARGV[1] – время приёма (ms), ARGV[2] – статус, ARGV[3] – сервис, ARGV[4] – окружение,
+// ARGV[5] – новый next_due (ms), ARGV[6] – TTL (ms)
+// Возвращает 1, если check-in записан.
+var monitorCheckInScript = redis.NewScript(`
+local last = tonumber(redis.call('HGET', KEYS[1], 'last_checkin') or '0')
+if tonumber(ARGV[1]) < last then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'last_checkin', ARGV[1], 'last_status', ARGV[2],
+	'service', ARGV[3], 'env', ARGV[4], 'next_due', ARGV[5])
+redis.call('PEXPIRE', KEYS[1], ARGV[6])
+return 1
+`)
+
+// monitorAdvanceScript сдвигает next_due, только если он не изменился с момента чтения:
+// check-in, пришедший между чтением и сдвигом, отменяет алерт о пропуске.
+// KEYS[1] – ключ состояния; ARGV[1] – прочитанный next_due (ms), ARGV[2] – новый (ms), ARGV[3] – TTL (ms)
+var monitorAdvanceScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'next_due') ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'next_due', ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+// RedisMonitorState хранит состояние мониторов cron-задач: ожидаемый запуск и последний check-in.
+type RedisMonitorState struct {
+	rdb    *redis.Client
+	logger *zerolog.Logger
+}
+
+func NewRedisMonitorState(rdb *redis.Client, logger *zerolog.Logger) *RedisMonitorState {
+	return &RedisMonitorState{
+		rdb:    rdb,
+		logger: logger,
+	}
+}
+
+// Get читает состояние монитора m. Если состояния нет, NextDue нулевой.
+func (s *RedisMonitorState) Get(ctx context.Context, m domain.Monitor) (domain.MonitorState, error) {
+	key := s.makeKey(m)
+	vals, err := s.rdb.HGetAll(ctx, key).Result()
+	if err != nil {
+		s.logger.Error().Err(err).Msgf("Failed to read monitor state key=%s", key)
+		return domain.MonitorState{}, err
+	}
+	return domain.MonitorState{
+		NextDue:     parseMillis(vals["next_due"]),
+		LastCheckIn: parseMillis(vals["last_checkin"]),
+		LastStatus:  domain.CheckInStatus(vals["last_status"]),
+		ServiceName: vals["service"],
+		Environment: vals["env"],
+	}, nil
+}
+
+// Init задаёт первый ожидаемый запуск, если состояния ещё нет.
+func (s *RedisMonitorState) Init(ctx context.Context, m domain.Monitor, nextDue time.Time) error {
+	key := s.makeKey(m)
+	if err := s.rdb.HSetNX(ctx, key, "next_due", nextDue.UnixMilli()).Err(); err != nil {
+		s.logger.Error().Err(err).Msgf("Failed to init monitor state key=%s", key)
+		return err
+	}
+	return s.rdb.PExpire(ctx, key, monitorStateTTL).Err()
+}
+
+// RecordCheckIn записывает check-in ci, принятый в receivedAt, и следующий ожидаемый запуск nextDue.
+// Возвращает false, если уже записан более поздний check-in.
+func (s *RedisMonitorState) RecordCheckIn(ctx context.Context, m domain.Monitor, ci *domain.CheckIn, receivedAt, nextDue time.Time) (bool, error) {
+	key := s.makeKey(m)
+	res, err := monitorCheckInScript.Run(ctx, s.rdb, []string{key},
+		receivedAt.UnixMilli(),
+		string(ci.Status),
+		ci.ServiceName,
+		ci.Environment,
+		nextDue.UnixMilli(),
+		monitorStateTTL.Milliseconds(),
+	).Int()
+	if err != nil {
+		s.logger.Error().Err(err).Msgf("Failed to record check-in key=%s", key)
+		return false, err
+	}
+	return res == 1, nil
+}
+
+// AdvanceDue меняет ожидаемый запуск с prev на next. Возвращает false, если за это время пришёл check-in.
+func (s *RedisMonitorState) AdvanceDue(ctx context.Context, m domain.Monitor, prev, next time.Time) (bool, error) {
+	key := s.makeKey(m)
+	res, err := monitorAdvanceScript.Run(ctx, s.rdb, []string{key},
+		strconv.FormatInt(prev.UnixMilli(), 10),
+		next.UnixMilli(),
+		monitorStateTTL.Milliseconds(),
+	).Int()
+	if err != nil {
+		s.logger.Error().Err(err).Msgf("Failed to advance monitor state key=%s", key)
+		return false, err
+	}
+	return res == 1, nil
+}
+
+// makeKey
+// monitor-state:monitor_id:schedule_version
+func (s *RedisMonitorState) makeKey(m domain.Monitor) string {
+	return fmt.Sprintf("monitor-state:%s:%s", m.ID, m.ScheduleVersion())
+}
+
+func parseMillis(v string) time.Time {
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
@@ -27,10 +27,14 @@ type LogEntry struct {
 	Log         json.RawMessage `db:"log"`
 	EventType   string          `db:"event_type"`
 	UsedRules   []domain.Rule   `db:"used_rules"`
-	Language    string          `db:"language"`
-	ActionUsed  []domain.Action `db:"action"`
-	ProjectId   string          `db:"project_id"`
-	Engine      string          `db:"engine"`
+	// SuppressedRules – сработавшие правила, алерт по которым подавлен cooldown
+	SuppressedRules []domain.Rule   `db:"-"`
+	Language        string          `db:"language"`
+	ActionUsed      []domain.Action `db:"action"`
+	ProjectId       string          `db:"project_id"`
+	Engine          string          `db:"engine"`
+	// SilencedRules – сработавшие правила, алерт по которым заглушён silence
+	SilencedRules []SilencedRule `db:"-"`
 }
 
 // Реализация репозитория
@@ -78,7 +82,7 @@ func NewTimescaleRepository(logger *zerolog.Logger, config *config.Config) (Time
 // Вставляет лог в таблицу logs_events
 func (r *timescaleRepository) InsertLog(ctx context.Context, entry LogEntry) error {
 
-	usedRules := r.mapUsedRules(entry.UsedRules)
+	usedRules := r.mapUsedRules(entry.UsedRules, entry.SuppressedRules, entry.SilencedRules)
 	usedRulesJson, err := json.Marshal(usedRules)
 	if err != nil {
 		r.logger.Error().Err(err).Msg("Failed to marshal used rules")
@@ -121,18 +125,41 @@ func (r *timescaleRepository) Close() error {
 
 // UsedRule – структура для хранения id и name правила
 type UsedRule struct {
-	RuleID   string `json:"rule_id"`
-	RuleName string `json:"rule_name"`
+	RuleID     string `json:"rule_id"`
+	RuleName   string `json:"rule_name"`
+	Suppressed bool   `json:"suppressed,omitempty"`
+	SilenceID  string `json:"silence_id,omitempty"`
 }
 
-// mapUsedRules преобразует срез domain.Rule в массив объектов UsedRule
-func (r *timescaleRepository) mapUsedRules(rules []domain.Rule) []UsedRule {
-	usedRules := make([]UsedRule, 0, len(rules))
+// SilencedRule – правило, алерт по которому заглушён silence SilenceID.
+type SilencedRule struct {
+	Rule      domain.Rule
+	SilenceID string
+}
+
+// mapUsedRules преобразует срезы domain.Rule в массив объектов UsedRule.
+// Подавленные cooldown правила помечаются флагом suppressed, заглушённые – ID silence.
+func (r *timescaleRepository) mapUsedRules(rules, suppressed []domain.Rule, silenced []SilencedRule) []UsedRule {
+	usedRules := make([]UsedRule, 0, len(rules)+len(suppressed)+len(silenced))
 	for _, rule := range rules {
 		usedRules = append(usedRules, UsedRule{
 			RuleID:   rule.ID,
 			RuleName: rule.Name,
 		})
 	}
+	for _, rule := range suppressed {
+		usedRules = append(usedRules, UsedRule{
+			RuleID:     rule.ID,
+			RuleName:   rule.Name,
+			Suppressed: true,
+		})
+	}
+	for _, s := range silenced {
+		usedRules = append(usedRules, UsedRule{
+			RuleID:    s.Rule.ID,
+			RuleName:  s.Rule.Name,
+			SilenceID: s.SilenceID,
+		})
+	}
 	return usedRules
 }
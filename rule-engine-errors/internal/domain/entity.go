@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
 // Event – входящее сообщение, которое нужно проверить правилами.
 // Можно добавить много полей (memoryUsage, goroutineCount, timestamp, etc.)
 type Event struct {
@@ -25,22 +31,126 @@ type Event struct {
 	// Поле для повторов (сколько таких ошибок за период)
 	// Если мы хотим хранить здесь, а не рассчитывать "на лету".
 	RepeatCount int `json:"repeat_count"`
+
+	// Ключ группы repeat_over (значения полей из group_by), по которому сработал счётчик
+	GroupKey string `json:"group_key,omitempty"`
+
+	// Оценка числа уникальных значений поля DistinctField за окно distinct_over (например, пользователей)
+	DistinctCount int    `json:"distinct_count,omitempty"`
+	DistinctField string `json:"distinct_field,omitempty"`
+
+	// Сколько срабатываний было подавлено cooldown с прошлого алерта
+	SuppressedCount int `json:"suppressed_count,omitempty"`
+
+	// Сработавшие корреляционные правила и события, которые их собрали
+	Correlations []CorrelationMatch `json:"correlations,omitempty"`
+
+	// Окно обслуживания проекта, из-за которого действия не отправлены (срабатывание только в логе)
+	MaintenanceWindowID string `json:"maintenance_window_id,omitempty"`
+
+	// Отпечаток ошибки в известных, посчитанный first_seen / first_seen_in_version (ключ – оператор)
+	Sightings map[string]Sighting `json:"sightings,omitempty"`
+
+	// tagMap – разобранные Tags, заполняется лениво в TagMap()
+	tagMap map[string]string
+
+	// contextMap – разобранный ContextJson, заполняется лениво в ContextMap()
+	contextMap    map[string]interface{}
+	contextErr    error
+	contextParsed bool
+}
+
+// TagMap возвращает теги события в виде map. SDK (utils.FormatTags) шлёт теги
+// строками "key=value"; тег без "=" попадает в map с пустым значением.
+// Список разбирается один раз на событие.
+func (e *Event) TagMap() map[string]string {
+	if e.tagMap != nil {
+		return e.tagMap
+	}
+	e.tagMap = make(map[string]string, len(e.Tags))
+	for _, t := range e.Tags {
+		k, v, _ := strings.Cut(t, "=")
+		e.tagMap[k] = v
+	}
+	return e.tagMap
+}
+
+// ContextMap возвращает ContextJson (контекст из CaptureException) в виде map.
+// JSON разбирается один раз на событие; пустой ContextJson даёт пустую map.
+func (e *Event) ContextMap() (map[string]interface{}, error) {
+	if e.contextParsed {
+		return e.contextMap, e.contextErr
+	}
+	e.contextParsed = true
+	e.contextMap = map[string]interface{}{}
+	if e.ContextJson != "" {
+		e.contextErr = json.Unmarshal([]byte(e.ContextJson), &e.contextMap)
+	}
+	return e.contextMap, e.contextErr
+}
+
+// SetSighting сохраняет результат first_seen, чтобы его увидели остальные правила и алерт.
+func (e *Event) SetSighting(op ConditionOperator, s Sighting) {
+	if e.Sightings == nil {
+		e.Sightings = make(map[string]Sighting)
+	}
+	e.Sightings[string(op)] = s
+}
+
+// Sighting – отпечаток ошибки и когда он встречался до этого события.
+type Sighting struct {
+	Fingerprint string     `json:"fingerprint"`
+	Version     string     `json:"version,omitempty"` // только для first_seen_in_version
+	New         bool       `json:"new"`               // отпечаток раньше не встречался
+	FirstSeen   time.Time  `json:"first_seen"`
+	LastSeen    *time.Time `json:"last_seen,omitempty"` // предыдущее появление, nil для новой ошибки
+}
+
+// QuietFor – сколько отпечаток не встречался до этого события.
+func (s Sighting) QuietFor(now time.Time) time.Duration {
+	if s.LastSeen == nil {
+		return 0
+	}
+	return now.Sub(*s.LastSeen)
 }
 
 // ConditionOperator – тип оператора в правилах.
 type ConditionOperator string
 
 const (
-	OpEQ         ConditionOperator = "eq"
-	OpNEQ        ConditionOperator = "neq"
-	OpGT         ConditionOperator = "gt"
-	OpGTE        ConditionOperator = "gte"
-	OpLT         ConditionOperator = "lt"
-	OpLTE        ConditionOperator = "lte"
-	OpIN         ConditionOperator = "in"
-	OpNIN        ConditionOperator = "nin"
-	OpCont       ConditionOperator = "contains"
-	OpRepeatOver ConditionOperator = "repeat_over" // нужный нам оператор
+	OpEQ             ConditionOperator = "eq"
+	OpNEQ            ConditionOperator = "neq"
+	OpGT             ConditionOperator = "gt"
+	OpGTE            ConditionOperator = "gte"
+	OpLT             ConditionOperator = "lt"
+	OpLTE            ConditionOperator = "lte"
+	OpIN             ConditionOperator = "in"
+	OpNIN            ConditionOperator = "nin"
+	OpCont           ConditionOperator = "contains"
+	OpNotCont        ConditionOperator = "not_contains"
+	OpIContains      ConditionOperator = "icontains" // contains без учёта регистра
+	OpStartsWith     ConditionOperator = "starts_with"
+	OpEndsWith       ConditionOperator = "ends_with"
+	OpMatches        ConditionOperator = "matches"    // регулярное выражение RE2
+	OpExists         ConditionOperator = "exists"     // поле/тег присутствует
+	OpNotExists      ConditionOperator = "not_exists" // поля/тега нет
+	OpVersionGT      ConditionOperator = "version_gt" // сравнение версий по semver
+	OpVersionGTE     ConditionOperator = "version_gte"
+	OpVersionLT      ConditionOperator = "version_lt"
+	OpVersionLTE     ConditionOperator = "version_lte"
+	OpVersionInRange ConditionOperator = "version_in_range" // диапазон вида ">=1.4.0 <2.0.0"
+	OpRepeatOver     ConditionOperator = "repeat_over"      // нужный нам оператор
+
+	// distinct_over – оценка числа уникальных значений поля за окно (HyperLogLog):
+	// "value": {"field": "context.user_id", "threshold": 25, "minutes": 10}
+	OpDistinctOver ConditionOperator = "distinct_over"
+
+	// Новые ошибки и регрессии по отпечатку (error_message + верхние кадры стека):
+	// first_seen – отпечаток впервые встретился в (project, service, environment),
+	// first_seen_in_version – впервые в этой версии сервиса. С quiet_days условие срабатывает
+	// и на отпечаток, который вернулся после quiet_days дней тишины: "value": {"quiet_days": 14}
+	OpFirstSeen          ConditionOperator = "first_seen"
+	OpFirstSeenInVersion ConditionOperator = "first_seen_in_version"
 )
 
 // Condition – условие
@@ -50,12 +160,21 @@ type Condition struct {
 	Value    interface{}       `bson:"value"    json:"value"`
 }
 
+// Операторы узла логического дерева.
+const (
+	LogicAND    = "AND"
+	LogicOR     = "OR"
+	LogicNOT    = "NOT"     // отрицание единственного операнда
+	LogicXOR    = "XOR"     // ровно один операнд true
+	LogicNoneOf = "NONE_OF" // ни один операнд не true
+)
+
 // LogicNode – узел логического дерева.
 // Он может содержать несколько Conditions (одним списком),
 // и может содержать дочерние узлы (Children).
-// Поле Operator определяет, как связаны *все* эти элементы: AND или OR.
+// Поле Operator определяет, как связаны *все* эти элементы: AND, OR, NOT, XOR или NONE_OF.
 type LogicNode struct {
-	Operator   string      `json:"operator"   bson:"operator"`   // "AND" / "OR"
+	Operator   string      `json:"operator"   bson:"operator"`   // "AND" / "OR" / "NOT" / "XOR" / "NONE_OF"
 	Conditions []Condition `json:"conditions" bson:"conditions"` // условия на этом уровне
 	Children   []LogicNode `json:"children"   bson:"children"`   // подузлы
 }
@@ -88,4 +207,11 @@ type Rule struct {
 
 	// RootNode – корень "дерева" логики (AND/OR + conditions + children)
 	RootNode LogicNode `bson:"root_node"     json:"root_node"`
+
+	// CooldownSec – после отправленного алерта повторные срабатывания подавляются столько секунд (0 – без подавления)
+	CooldownSec int `bson:"cooldown_sec" json:"cooldown_sec"`
+	// DedupKey – поля события через "+", по которым cooldown считается отдельно (например, "error_message+service_name")
+	DedupKey string `bson:"dedup_key" json:"dedup_key"`
+	// Schedule – когда правило активно; nil – всегда
+	Schedule *Schedule `bson:"schedule" json:"schedule,omitempty"`
 }
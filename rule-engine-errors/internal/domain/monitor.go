@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Тип расписания монитора.
+const (
+	ScheduleCron     = "cron"
+	ScheduleInterval = "interval"
+)
+
+// Типы событий, с которыми уходят алерты мониторов.
+const (
+	EventTypeCheckInMissed = "CHECKIN_MISSED"
+	EventTypeCheckInFailed = "CHECKIN_FAILED"
+)
+
+// CheckInStatus – статус запуска cron-задачи из check-in SDK.
+type CheckInStatus string
+
+const (
+	CheckInInProgress CheckInStatus = "in_progress"
+	CheckInOk         CheckInStatus = "ok"
+	CheckInError      CheckInStatus = "error"
+)
+
+// Monitor – монитор cron-задачи: по расписанию ожидается check-in с monitor_slug = Slug.
+type Monitor struct {
+	ID           string
+	ProjectID    string
+	UserID       string
+	Slug         string
+	Name         string
+	ScheduleType string
+	CronExpr     string
+	IntervalSec  int
+	GraceSec     int
+	Timezone     string
+	Actions      []Action
+}
+
+// Grace – сколько ждать check-in после запланированного запуска.
+func (m Monitor) Grace() time.Duration {
+	return time.Duration(m.GraceSec) * time.Second
+}
+
+// ScheduleVersion – короткий хеш расписания. Входит в ключ состояния монитора,
+// чтобы после изменения расписания ожидаемый запуск считался заново.
+func (m Monitor) ScheduleVersion() string {
+	h := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%d|%s", m.ScheduleType, m.CronExpr, m.IntervalSec, m.Timezone)))
+	return hex.EncodeToString(h[:4])
+}
+
+// CheckIn – отметка cron-задачи из Kafka (её пишет коллектор).
+type CheckIn struct {
+	ProjectID   string        `json:"project_id"`
+	UserID      string        `json:"user_id"`
+	MonitorSlug string        `json:"monitor_slug"`
+	Status      CheckInStatus `json:"status"`
+	ServiceName string        `json:"service_name"`
+	Environment string        `json:"environment"`
+	Timestamp   string        `json:"timestamp"`
+	// ReceivedAt – время приёма коллектором, по нему считаются пропуски
+	ReceivedAt string `json:"received_at"`
+}
+
+// MonitorState – состояние монитора в Redis.
+// NextDue – ближайший запуск, к которому ещё не пришёл check-in; zero – состояние не создано.
+type MonitorState struct {
+	NextDue     time.Time
+	LastCheckIn time.Time
+	LastStatus  CheckInStatus
+	ServiceName string
+	Environment string
+}
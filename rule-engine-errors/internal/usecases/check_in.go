@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rule-engine-errors/internal/dataproviders/redis_repository"
+	"rule-engine-errors/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// CheckInUseCase обрабатывает check-in'ы cron-задач: сдвигает ожидаемый запуск монитора
+// и сразу шлёт алерт, если задача сообщила об ошибке.
+type CheckInUseCase struct {
+	registry        *MonitorRegistry
+	state           *redis_repository.RedisMonitorState
+	alertDispatcher AlertDispatcher
+	logger          *zerolog.Logger
+}
+
+func NewCheckInUseCase(
+	registry *MonitorRegistry,
+	state *redis_repository.RedisMonitorState,
+	ad AlertDispatcher,
+	logger *zerolog.Logger,
+) *CheckInUseCase {
+	return &CheckInUseCase{
+		registry:        registry,
+		state:           state,
+		alertDispatcher: ad,
+		logger:          logger,
+	}
+}
+
+// HandleCheckIn применяет check-in ci. Check-in для неизвестного монитора или чужого проекта
+// пропускается: монитор могли удалить, а повтор сообщения ничего не исправит.
+// Любой статус (in_progress, ok, error) означает, что запуск состоялся; хэнг задачи после
+// in_progress не отслеживается.
+func (uc *CheckInUseCase) HandleCheckIn(ctx context.Context, ci *domain.CheckIn) error {
+	sm, ok := uc.registry.lookup(ci.ProjectID, ci.MonitorSlug)
+	if !ok {
+		uc.logger.Warn().Msgf("Check-in for unknown monitor project=%s slug=%s", ci.ProjectID, ci.MonitorSlug)
+		return nil
+	}
+	if sm.UserID != ci.UserID {
+		uc.logger.Warn().Msgf("Check-in for monitor %s from foreign user=%s", sm.ID, ci.UserID)
+		return nil
+	}
+
+	receivedAt := time.Now()
+	if t, err := time.Parse(time.RFC3339Nano, ci.ReceivedAt); err == nil {
+		receivedAt = t
+	}
+
+	recorded, err := uc.state.RecordCheckIn(ctx, sm.Monitor, ci, receivedAt, sm.nextAfter(receivedAt))
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		uc.logger.Debug().Msgf("Stale check-in for monitor %s ignored", sm.ID)
+		return nil
+	}
+
+	if ci.Status != domain.CheckInError {
+		return nil
+	}
+
+	event := monitorEvent(sm.Monitor, domain.EventTypeCheckInFailed, ci.ServiceName, ci.Environment,
+		fmt.Sprintf("Monitor %s reported a failed run", monitorTitle(sm.Monitor)), receivedAt)
+	if err := uc.alertDispatcher.DispatchActions(ctx, event, sm.Actions); err != nil {
+		uc.logger.Error().Err(err).Msgf("Failed to dispatch check-in failure for monitor %s", sm.ID)
+		return err
+	}
+	return nil
+}
+
+// monitorEvent собирает событие для алерта монитора: алерт-агенты получают его так же, как событие правила.
+func monitorEvent(m domain.Monitor, eventType, service, env, message string, at time.Time) *domain.Event {
+	return &domain.Event{
+		ProjectId:    m.ProjectID,
+		UserID:       m.UserID,
+		ServiceName:  service,
+		Environment:  env,
+		EventType:    eventType,
+		Level:        "error",
+		ErrorMessage: message,
+		EventMessage: message,
+		Timestamp:    at.UTC().Format(time.RFC3339),
+		Fields: map[string]interface{}{
+			"monitor_id":   m.ID,
+			"monitor_slug": m.Slug,
+		},
+	}
+}
+
+func monitorTitle(m domain.Monitor) string {
+	if m.Name != "" {
+		return fmt.Sprintf("%q (%s)", m.Name, m.Slug)
+	}
+	return m.Slug
+}
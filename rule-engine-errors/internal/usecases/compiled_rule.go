@@ -0,0 +1,280 @@
+package usecases
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// fieldAccessor – заранее выбранный способ чтения поля события (без разбора пути на каждом событии).
+type fieldAccessor func(rce *RuleConditionEvaluator, e *domain.Event) interface{}
+
+// conditionFunc – условие, подготовленное под конкретное правило: порог, регулярка или множество
+// значений разобраны один раз при компиляции, а не на каждом событии.
+type conditionFunc func(rce *RuleConditionEvaluator, e *domain.Event) bool
+
+type compiledNode struct {
+	operator   string
+	conditions []conditionFunc
+	children   []compiledNode
+}
+
+// CompiledRule – правило с заранее подготовленным деревом условий.
+// Результат Evaluate всегда совпадает с domain.EvaluateRule для того же правила.
+type CompiledRule struct {
+	Rule      domain.Rule
+	root      compiledNode
+	err       error                      // дерево не прошло валидацию
+	sightings []domain.ConditionOperator // first_seen-операторы, отпечаток для них отмечается до проверки (см. recordSightings)
+}
+
+// CompileRules компилирует список правил. Невалидное правило не выбрасывается:
+// его Evaluate возвращает ошибку, как и domain.EvaluateRule.
+func CompileRules(rules []domain.Rule) []CompiledRule {
+	compiled := make([]CompiledRule, 0, len(rules))
+	for _, r := range rules {
+		compiled = append(compiled, compileRule(r))
+	}
+	return compiled
+}
+
+func compileRule(r domain.Rule) CompiledRule {
+	if err := domain.ValidateLogicNode(r.RootNode); err != nil {
+		return CompiledRule{Rule: r, err: fmt.Errorf("rule %s: %w", r.ID, err)}
+	}
+	return CompiledRule{Rule: r, root: compileNode(r.RootNode, r), sightings: collectSightings(r.RootNode, nil)}
+}
+
+// Evaluate проверяет правило на событии e.
+func (cr *CompiledRule) Evaluate(e *domain.Event, rce *RuleConditionEvaluator) (bool, error) {
+	if cr.err != nil {
+		return false, cr.err
+	}
+	return cr.root.evaluate(rce, e), nil
+}
+
+func compileNode(node domain.LogicNode, r domain.Rule) compiledNode {
+	cn := compiledNode{
+		operator:   domain.NormalizeLogicOperator(node.Operator),
+		conditions: make([]conditionFunc, 0, len(node.Conditions)),
+		children:   make([]compiledNode, 0, len(node.Children)),
+	}
+	for _, c := range node.Conditions {
+		cn.conditions = append(cn.conditions, compileCondition(c, r))
+	}
+	for _, child := range node.Children {
+		cn.children = append(cn.children, compileNode(child, r))
+	}
+	return cn
+}
+
+// evaluate повторяет domain.EvaluateLogicNode: операнды – conditions, затем children, с тем же ранним выходом.
+func (cn *compiledNode) evaluate(rce *RuleConditionEvaluator, e *domain.Event) bool {
+	matched := 0
+	total := len(cn.conditions) + len(cn.children)
+	for i := 0; i < total; i++ {
+		var ok bool
+		if i < len(cn.conditions) {
+			ok = cn.conditions[i](rce, e)
+		} else {
+			ok = cn.children[i-len(cn.conditions)].evaluate(rce, e)
+		}
+		if ok {
+			matched++
+		}
+		if result, done := domain.LogicShortCircuit(cn.operator, ok, matched); done {
+			return result
+		}
+	}
+	return domain.LogicResult(cn.operator, matched, total)
+}
+
+// compileAccessor повторяет выбор источника поля из getField.
+func compileAccessor(field string) fieldAccessor {
+	switch {
+	case strings.HasPrefix(field, "fields."):
+		keys := strings.Split(field, ".")[1:]
+		return func(rce *RuleConditionEvaluator, e *domain.Event) interface{} {
+			if e.Fields == nil {
+				return nil
+			}
+			return rce.traverseMapDebug(e.Fields, keys)
+		}
+	case strings.HasPrefix(field, "tags."):
+		key := strings.TrimPrefix(field, "tags.")
+		return func(_ *RuleConditionEvaluator, e *domain.Event) interface{} {
+			return getTag(e, key)
+		}
+	case strings.HasPrefix(field, "context."):
+		return func(rce *RuleConditionEvaluator, e *domain.Event) interface{} {
+			return rce.getContextField(e, field)
+		}
+	default:
+		return func(_ *RuleConditionEvaluator, e *domain.Event) interface{} {
+			return getFieldValue(e, field)
+		}
+	}
+}
+
+// compileCondition готовит условие c. Операторы без заранее разбираемых значений
+// (repeat_over, contains и т.д.) проверяются через обычный Evaluate.
+func compileCondition(c domain.Condition, r domain.Rule) conditionFunc {
+	get := compileAccessor(c.Field)
+
+	switch c.Operator {
+	case domain.OpEQ:
+		return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+			return isEqual(get(rce, e), c.Value)
+		}
+	case domain.OpNEQ:
+		return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+			return !isEqual(get(rce, e), c.Value)
+		}
+
+	case domain.OpGT, domain.OpGTE, domain.OpLT, domain.OpLTE:
+		if fn := compileThreshold(c, get); fn != nil {
+			return fn
+		}
+
+	case domain.OpIN, domain.OpNIN:
+		if set, ok := stringSet(c.Value); ok {
+			in := c.Operator == domain.OpIN
+			return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+				s, isStr := get(rce, e).(string)
+				_, found := set[s]
+				return (isStr && found) == in
+			}
+		}
+
+	case domain.OpMatches:
+		if pattern, ok := c.Value.(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				// Битая регулярка: Evaluate залогирует ошибку и вернёт false
+				break
+			}
+			return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+				for _, s := range toStrings(get(rce, e)) {
+					if re.MatchString(s) {
+						return true
+					}
+				}
+				return false
+			}
+		}
+
+	case domain.OpVersionGT, domain.OpVersionGTE, domain.OpVersionLT, domain.OpVersionLTE:
+		if fn := compileVersionCompare(c, get); fn != nil {
+			return fn
+		}
+
+	case domain.OpVersionInRange:
+		if rng, ok := c.Value.(string); ok {
+			constraint, err := semver.NewConstraint(rng)
+			if err != nil {
+				// Битый диапазон: Evaluate залогирует ошибку и вернёт false
+				break
+			}
+			return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+				ver, ok := parseVersion(get(rce, e))
+				return ok && constraint.Check(ver)
+			}
+		}
+
+	case domain.OpExists:
+		return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+			return get(rce, e) != nil
+		}
+	case domain.OpNotExists:
+		return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+			return get(rce, e) == nil
+		}
+	}
+
+	return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+		return rce.Evaluate(e, c, r)
+	}
+}
+
+// compileThreshold – gt/gte/lt/lte с числовым порогом, приведённым к float64 один раз.
+// Для нечислового порога возвращает nil (сравнение через compareNumericOrString).
+func compileThreshold(c domain.Condition, get fieldAccessor) conditionFunc {
+	if _, isStr := c.Value.(string); isStr {
+		return nil
+	}
+	threshold, ok := toFloat(c.Value)
+	if !ok {
+		return nil
+	}
+
+	var accept func(v float64) bool
+	switch c.Operator {
+	case domain.OpGT:
+		accept = func(v float64) bool { return v > threshold }
+	case domain.OpGTE:
+		accept = func(v float64) bool { return v >= threshold }
+	case domain.OpLT:
+		accept = func(v float64) bool { return v < threshold }
+	default: // OpLTE
+		accept = func(v float64) bool { return v <= threshold }
+	}
+	return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+		v, ok := toFloat(get(rce, e))
+		return ok && accept(v)
+	}
+}
+
+// compileVersionCompare – version_gt/gte/lt/lte с версией из правила, разобранной один раз.
+// Для неразборчивой версии возвращает nil (Evaluate залогирует и вернёт false).
+func compileVersionCompare(c domain.Condition, get fieldAccessor) conditionFunc {
+	target, ok := parseVersion(c.Value)
+	if !ok {
+		return nil
+	}
+
+	var accept func(cmp int) bool
+	switch c.Operator {
+	case domain.OpVersionGT:
+		accept = func(cmp int) bool { return cmp > 0 }
+	case domain.OpVersionGTE:
+		accept = func(cmp int) bool { return cmp >= 0 }
+	case domain.OpVersionLT:
+		accept = func(cmp int) bool { return cmp < 0 }
+	default: // OpVersionLTE
+		accept = func(cmp int) bool { return cmp <= 0 }
+	}
+	return func(rce *RuleConditionEvaluator, e *domain.Event) bool {
+		ver, ok := parseVersion(get(rce, e))
+		return ok && accept(ver.Compare(target))
+	}
+}
+
+// stringSet превращает список строк из правила в множество для in/nin.
+// Если в списке есть не только строки, возвращает false (сравнение через inList).
+func stringSet(v interface{}) (map[string]struct{}, bool) {
+	var items []string
+	switch arr := v.(type) {
+	case []string:
+		items = arr
+	case []interface{}:
+		for _, el := range arr {
+			s, ok := el.(string)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, s)
+		}
+	default:
+		return nil, false
+	}
+
+	set := make(map[string]struct{}, len(items))
+	for _, s := range items {
+		set[s] = struct{}{}
+	}
+	return set, true
+}
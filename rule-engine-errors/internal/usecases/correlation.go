@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+)
+
+// correlationRulesTTL – сколько корреляционные правила проекта живут в памяти.
+// Уведомлений об их изменении нет: новое правило начинает работать не позже чем через TTL.
+const correlationRulesTTL = 30 * time.Second
+
+type CorrelationRuleRepository interface {
+	GetCorrelationRules(ctx context.Context, userID, projectID string) ([]domain.CorrelationRule, error)
+}
+
+// compiledCorrelation – корреляционное правило со скомпилированными шагами.
+type compiledCorrelation struct {
+	domain.CorrelationRule
+	steps []CompiledRule
+}
+
+type correlationRulesEntry struct {
+	rules    []compiledCorrelation
+	loadedAt time.Time
+}
+
+// correlationRuleCache – корреляционные правила по (user, project) с TTL.
+type correlationRuleCache struct {
+	mu    sync.Mutex
+	items map[string]correlationRulesEntry
+}
+
+func newCorrelationRuleCache() *correlationRuleCache {
+	return &correlationRuleCache{items: map[string]correlationRulesEntry{}}
+}
+
+// getCorrelationRules возвращает корреляционные правила проекта события.
+func (uc *EvaluateRulesUseCase) getCorrelationRules(ctx context.Context, userID, projectID string) ([]compiledCorrelation, error) {
+	key := userID + ":" + projectID
+	now := time.Now()
+
+	c := uc.correlationRules
+	c.mu.Lock()
+	entry, ok := c.items[key]
+	c.mu.Unlock()
+	if ok && now.Sub(entry.loadedAt) < correlationRulesTTL {
+		return entry.rules, nil
+	}
+
+	rules, err := uc.correlationRepo.GetCorrelationRules(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	compiled := make([]compiledCorrelation, 0, len(rules))
+	for _, r := range rules {
+		cc := compiledCorrelation{CorrelationRule: r, steps: make([]CompiledRule, 0, len(r.Steps))}
+		for i, step := range r.Steps {
+			cc.steps = append(cc.steps, compileRule(domain.Rule{
+				ID:       fmt.Sprintf("correlation-%s-%d", r.ID, i),
+				Name:     step.Name,
+				RootNode: step.RootNode,
+			}))
+		}
+		compiled = append(compiled, cc)
+	}
+
+	c.mu.Lock()
+	c.items[key] = correlationRulesEntry{rules: compiled, loadedAt: now}
+	c.mu.Unlock()
+	return compiled, nil
+}
+
+// correlate проверяет шаги корреляционных правил, которые относятся к этому движку и сервису события,
+// и отмечает совпавшие шаги в общем состоянии Redis. Сработавшие правила добавляются в event.Correlations
+// и возвращаются как обычные правила с их действиями: алерт уходит один раз вместе с остальными.
+// Ошибки корреляции не мешают обработке события – они только логируются.
+func (uc *EvaluateRulesUseCase) correlate(ctx context.Context, event *domain.Event, evaluator *RuleConditionEvaluator) []domain.Rule {
+	if uc.correlationRepo == nil || uc.correlationState == nil {
+		return nil
+	}
+	rules, err := uc.getCorrelationRules(ctx, event.UserID, event.ProjectId)
+	if err != nil {
+		uc.logger.Error().Err(err).Msgf("Failed to fetch correlation rules for project=%s", event.ProjectId)
+		return nil
+	}
+
+	var matched []domain.Rule
+	now := time.Now()
+	for _, r := range rules {
+		var steps []int
+		for i, step := range r.Steps {
+			if step.Engine != ENGINE || (step.ServiceName != "" && step.ServiceName != event.ServiceName) {
+				continue
+			}
+			evaluator.recordSightings(ctx, event, r.steps[i:i+1])
+			ok, err := r.steps[i].Evaluate(event, evaluator)
+			if err != nil {
+				uc.logger.Error().Err(err).Msgf("Correlation rule %q step %d skipped: invalid logic tree", r.Name, i)
+				continue
+			}
+			if ok {
+				steps = append(steps, i)
+			}
+		}
+
+		// С конца: событие, подходящее и под A, и под B, сначала продвигает уже начатую последовательность
+		for j := len(steps) - 1; j >= 0; j-- {
+			events, err := uc.correlationState.Observe(ctx, r.CorrelationRule, steps[j], correlatedEvent(event, r.CorrelationRule, steps[j], evaluator), now)
+			if err != nil {
+				uc.logger.Error().Err(err).Msgf("Failed to observe correlation rule %s", r.ID)
+				break
+			}
+			if events == nil {
+				continue
+			}
+			uc.logger.Debug().Msgf("Correlation rule matched: %s", r.Name)
+			event.Correlations = append(event.Correlations, domain.CorrelationMatch{
+				RuleID:   r.ID,
+				RuleName: r.Name,
+				Events:   events,
+			})
+			matched = append(matched, domain.Rule{
+				ID:      "correlation-" + r.ID,
+				Name:    r.Name,
+				Actions: r.Actions,
+			})
+			break
+		}
+	}
+	return matched
+}
+
+// correlatedEvent – то, что от события сохраняется в частичном совпадении.
+func correlatedEvent(e *domain.Event, r domain.CorrelationRule, step int, evaluator *RuleConditionEvaluator) domain.CorrelatedEvent {
+	ce := domain.CorrelatedEvent{
+		Step:        step,
+		StepName:    r.Steps[step].Name,
+		Engine:      ENGINE,
+		ServiceName: e.ServiceName,
+		Environment: e.Environment,
+		EventType:   e.EventType,
+		Message:     e.ErrorMessage,
+		Timestamp:   e.Timestamp,
+	}
+	if ce.Message == "" {
+		ce.Message = e.EventMessage
+	}
+	if r.DistinctBy != "" {
+		if v := evaluator.ResolveField(e, r.DistinctBy); v != nil {
+			ce.Distinct = fmt.Sprintf("%v", v)
+		}
+	}
+	return ce
+}
@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"rule-engine-errors/internal/domain"
+)
+
+// distinctOverParams – разобранный value оператора distinct_over:
+// {"field": "context.user_id", "threshold": 25, "minutes": 10}
+type distinctOverParams struct {
+	field     string
+	threshold int
+	minutes   int
+}
+
+// parseDistinctOverParams разбирает value. Поле можно указать и в самом условии (field),
+// тогда "field" в value не нужен.
+func parseDistinctOverParams(c domain.Condition) (distinctOverParams, error) {
+	valMap, ok := c.Value.(map[string]interface{})
+	if !ok {
+		return distinctOverParams{}, fmt.Errorf("invalid 'value' (expected map[string]interface{})")
+	}
+
+	params := distinctOverParams{field: c.Field}
+	if f, ok := valMap["field"]; ok {
+		fs, ok := f.(string)
+		if !ok || fs == "" {
+			return distinctOverParams{}, fmt.Errorf("invalid 'field' %v", f)
+		}
+		params.field = fs
+	}
+	if params.field == "" {
+		return distinctOverParams{}, fmt.Errorf("'field' is required")
+	}
+
+	threshold, ok := toFloat(valMap["threshold"])
+	if !ok || threshold < 1 {
+		return distinctOverParams{}, fmt.Errorf("invalid 'threshold' %v", valMap["threshold"])
+	}
+	minutes, ok := toFloat(valMap["minutes"])
+	if !ok || minutes < 1 {
+		return distinctOverParams{}, fmt.Errorf("invalid 'minutes' %v", valMap["minutes"])
+	}
+	params.threshold = int(threshold)
+	params.minutes = int(minutes)
+	return params, nil
+}
+
+// evaluateDistinctOver оценивает число уникальных значений поля за окно (например, сколько разных
+// пользователей получили ошибку) и сравнивает с threshold. Оценка сохраняется в e.DistinctCount,
+// чтобы алерт мог показать «затронуто ~40 пользователей».
+func (rce *RuleConditionEvaluator) evaluateDistinctOver(e *domain.Event, c domain.Condition, r domain.Rule) bool {
+	params, err := parseDistinctOverParams(c)
+	if err != nil {
+		rce.logger.Warn().Err(err).Msg("distinct_over condition: invalid value")
+		return false
+	}
+
+	// Без Redis (dry-run) окно не читается и не меняется
+	if rce.distinctCounter == nil {
+		rce.logger.Debug().Msg("distinct_over is not evaluated without Redis counter")
+		return false
+	}
+
+	var value string
+	if v := rce.getField(e, params.field); v != nil {
+		value = fmt.Sprintf("%v", v)
+	}
+
+	cnt, err := rce.distinctCounter.CountDistinct(context.Background(), e, r, params.field, value, params.minutes)
+	if err != nil {
+		rce.logger.Error().Err(err).Msg("CountDistinct failed")
+		return false
+	}
+	e.DistinctCount = cnt
+	e.DistinctField = params.field
+
+	rce.logger.Debug().Msgf("distinct_over check: field=%s count=~%d, threshold=%d", params.field, cnt, params.threshold)
+	return cnt >= params.threshold
+}
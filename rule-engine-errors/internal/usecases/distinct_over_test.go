@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"testing"
+
+	"rule-engine-errors/internal/domain"
+)
+
+func TestParseDistinctOverParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    domain.Condition
+		want    distinctOverParams
+		wantErr bool
+	}{
+		{
+			name: "field in value",
+			cond: domain.Condition{Value: map[string]interface{}{"field": "context.user_id", "threshold": float64(25), "minutes": float64(10)}},
+			want: distinctOverParams{field: "context.user_id", threshold: 25, minutes: 10},
+		},
+		{
+			name: "field in condition",
+			cond: domain.Condition{Field: "context.user_id", Value: map[string]interface{}{"threshold": 5, "minutes": 1}},
+			want: distinctOverParams{field: "context.user_id", threshold: 5, minutes: 1},
+		},
+		{
+			name: "value field overrides condition field",
+			cond: domain.Condition{Field: "service_name", Value: map[string]interface{}{"field": "tags.tenant", "threshold": 3, "minutes": 15}},
+			want: distinctOverParams{field: "tags.tenant", threshold: 3, minutes: 15},
+		},
+		{
+			name: "fractional numbers are truncated",
+			cond: domain.Condition{Field: "context.user_id", Value: map[string]interface{}{"threshold": 2.9, "minutes": 1.5}},
+			want: distinctOverParams{field: "context.user_id", threshold: 2, minutes: 1},
+		},
+		{name: "not a map", cond: domain.Condition{Field: "context.user_id", Value: float64(25)}, wantErr: true},
+		{name: "no field", cond: domain.Condition{Value: map[string]interface{}{"threshold": 5, "minutes": 10}}, wantErr: true},
+		{name: "empty field", cond: domain.Condition{Value: map[string]interface{}{"field": "", "threshold": 5, "minutes": 10}}, wantErr: true},
+		{name: "field not a string", cond: domain.Condition{Value: map[string]interface{}{"field": 1, "threshold": 5, "minutes": 10}}, wantErr: true},
+		{name: "zero threshold", cond: domain.Condition{Field: "context.user_id", Value: map[string]interface{}{"threshold": 0, "minutes": 10}}, wantErr: true},
+		{name: "missing minutes", cond: domain.Condition{Field: "context.user_id", Value: map[string]interface{}{"threshold": 5}}, wantErr: true},
+		{name: "minutes below one", cond: domain.Condition{Field: "context.user_id", Value: map[string]interface{}{"threshold": 5, "minutes": 0.5}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDistinctOverParams(tt.cond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDistinctOverParams(%+v) error = %v, wantErr %v", tt.cond, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseDistinctOverParams(%+v) = %+v, want %+v", tt.cond, got, tt.want)
+			}
+		})
+	}
+}
@@ -29,8 +29,27 @@ type EvaluateRulesUseCase struct {
 	timeScaleRepo   timescale_repository.TimescaleRepository
 	alertDispatcher AlertDispatcher
 	redisCounter    *redis_repository.RedisRepeatCounter
+	distinctCounter *redis_repository.RedisDistinctCounter
 	redisCache      *redis_repository.RedisCache
-	logger          *zerolog.Logger
+	alertCooldown   *redis_repository.RedisAlertCooldown
+	fingerprints    FingerprintStore
+	regexCache      *regexCache
+	compiledRules   *compiledRuleCache
+
+	// Корреляционные правила проекта: шаги из потоков ошибок и ресурсов
+	correlationRepo  CorrelationRuleRepository
+	correlationState *redis_repository.RedisCorrelationState
+	correlationRules *correlationRuleCache
+
+	// Окна обслуживания проектов: действия не отправляются, срабатывания пишутся в лог
+	maintenanceRepo MaintenanceRepository
+	maintenance     *maintenanceCache
+
+	// Silences: заглушённое правило не отправляет действия, срабатывание пишется в лог с ID silence
+	silenceRepo  SilenceRepository
+	silenceCache *redis_repository.RedisSilenceCache
+
+	logger *zerolog.Logger
 }
 
 func NewEvaluateRulesUseCase(
@@ -38,28 +57,67 @@ func NewEvaluateRulesUseCase(
 	ts timescale_repository.TimescaleRepository,
 	ad AlertDispatcher,
 	rc *redis_repository.RedisRepeatCounter,
+	dc *redis_repository.RedisDistinctCounter,
 	rd *redis_repository.RedisCache,
+	cd *redis_repository.RedisAlertCooldown,
+	fp FingerprintStore,
+	cr CorrelationRuleRepository,
+	cs *redis_repository.RedisCorrelationState,
+	mr MaintenanceRepository,
+	sr SilenceRepository,
+	sc *redis_repository.RedisSilenceCache,
 	logger *zerolog.Logger,
 ) *EvaluateRulesUseCase {
 	return &EvaluateRulesUseCase{
-		ruleRepo:        rr,
-		timeScaleRepo:   ts,
-		alertDispatcher: ad,
-		redisCounter:    rc,
-		redisCache:      rd,
-		logger:          logger,
+		ruleRepo:         rr,
+		timeScaleRepo:    ts,
+		alertDispatcher:  ad,
+		redisCounter:     rc,
+		distinctCounter:  dc,
+		redisCache:       rd,
+		alertCooldown:    cd,
+		fingerprints:     fp,
+		regexCache:       newRegexCache(regexCacheSize),
+		compiledRules:    newCompiledRuleCache(compiledRulesCacheSize),
+		correlationRepo:  cr,
+		correlationState: cs,
+		correlationRules: newCorrelationRuleCache(),
+		maintenanceRepo:  mr,
+		maintenance:      newMaintenanceCache(),
+		silenceRepo:      sr,
+		silenceCache:     sc,
+		logger:           logger,
 	}
 }
 
-// Evaluate – читает event.UserID, event.ServiceName -> загружает только нужные правила
-func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Event) error {
+// Evaluation – результат проверки правил для одного события: какие действия отправить и что записать в лог.
+// Evaluate меняет состояние в Redis (cooldown, окна repeat_over, корреляции), поэтому при ошибке
+// доставки повторяется только Deliver с тем же Evaluation – повторная проверка правил потеряла бы алерт.
+type Evaluation struct {
+	event           *domain.Event
+	triggered       []domain.Action
+	triggeredRules  []domain.Rule
+	suppressedRules []domain.Rule
+	silencedRules   []timescale_repository.SilencedRule
+
+	// Окна cooldown, занятые отправляемыми правилами: освобождаются, если действия так и не отправлены
+	cooldowns []redis_repository.CooldownLease
+
+	// Что уже доставлено: повтор Deliver не дублирует отправленные действия и запись в лог
+	dispatched bool
+	logged     bool
+}
+
+// Evaluate – читает event.UserID, event.ServiceName -> загружает только нужные правила.
+// Ошибка возвращается только до проверки правил (загрузка правил), поэтому Evaluate при ошибке можно повторить.
+func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Event) (*Evaluation, error) {
 	uc.logger.Debug().Msgf("Evaluate: user=%s, service=%s", event.UserID, event.ServiceName)
 
 	// 1. Фильтруем только правила для (user_id, service_name)
-	rules, err := uc.GetRulesByUserAndServiceAndProjectId(ctx, event.UserID, event.ServiceName, event.ProjectId)
+	rules, err := uc.getCompiledRules(ctx, event.UserID, event.ServiceName, event.ProjectId)
 	if err != nil {
 		uc.logger.Error().Err(err).Msg("Failed to fetch rules by user & service")
-		return err
+		return nil, err
 	}
 	uc.logger.Debug().Msgf("Got %d rules for user=%s, service=%s", len(rules), event.UserID, event.ServiceName)
 
@@ -72,31 +130,108 @@ func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Even
 
 	// 3. Готовим evaluator
 	evaluator := &RuleConditionEvaluator{
-		redisCounter: uc.redisCounter,
-		logger:       uc.logger,
+		redisCounter:    uc.redisCounter,
+		distinctCounter: uc.distinctCounter,
+		fingerprints:    uc.fingerprints,
+		regexCache:      uc.regexCache,
+		logger:          uc.logger,
 	}
 
+	// 3.1 Отпечаток ошибки – для всех first_seen правил, до проверки условий:
+	// ранний выход AND/OR и правила вне расписания не должны оставлять отпечаток неотмеченным
+	evaluator.recordSightings(ctx, event, rules)
+
 	// 4. Для каждого правила EvaluateRule -> собираем actions
-	var triggered []domain.Action
-	var triggeredRuleNames []domain.Rule
-	for _, r := range rules {
-		ok := domain.EvaluateRule(event, r, evaluator)
+	ev := &Evaluation{event: event}
+	now := time.Now()
+	silences := uc.newSilenceSet(event.ProjectId)
+	maintenance := uc.newMaintenanceCheck(ctx, event, now)
+	for i := range rules {
+		r := rules[i].Rule
+		if !uc.ruleActive(r, now) {
+			uc.logger.Debug().Msgf("Rule %q skipped: outside of its schedule", r.Name)
+			continue
+		}
+		ok, err := rules[i].Evaluate(event, evaluator)
+		if err != nil {
+			uc.logger.Error().Err(err).Msgf("Rule %q skipped: invalid logic tree", r.Name)
+			continue
+		}
 		if ok {
+			if sl, ok := silences.match(ctx, event, r, now); ok {
+				uc.logger.Debug().Msgf("Rule matched but silenced by silence %s: %s", sl.ID, r.Name)
+				ev.silencedRules = append(ev.silencedRules, timescale_repository.SilencedRule{Rule: r, SilenceID: sl.ID})
+				continue
+			}
+			// Окно обслуживания проверяется до cooldown: срабатывание, которое не отправится, не занимает окно
+			if maintenance() {
+				ev.triggered = append(ev.triggered, r.Actions...)
+				ev.triggeredRules = append(ev.triggeredRules, r)
+				continue
+			}
+			if uc.inCooldown(ctx, ev, r, evaluator) {
+				uc.logger.Debug().Msgf("Rule matched but suppressed by cooldown: %s", r.Name)
+				ev.suppressedRules = append(ev.suppressedRules, r)
+				continue
+			}
 			uc.logger.Debug().Msgf("Rule matched: %s", r.Name)
-			triggered = append(triggered, r.Actions...)
-			triggeredRuleNames = append(triggeredRuleNames, r)
+			ev.triggered = append(ev.triggered, r.Actions...)
+			ev.triggeredRules = append(ev.triggeredRules, r)
+		}
+	}
+
+	// 4.1 Корреляционные правила: сработавшее правило отправляется вместе с остальными
+	for _, r := range uc.correlate(ctx, event, evaluator) {
+		if sl, ok := silences.match(ctx, event, r, now); ok {
+			ev.silencedRules = append(ev.silencedRules, timescale_repository.SilencedRule{Rule: r, SilenceID: sl.ID})
+			continue
 		}
+		maintenance()
+		ev.triggered = append(ev.triggered, r.Actions...)
+		ev.triggeredRules = append(ev.triggeredRules, r)
 	}
 
+	if len(ev.triggered) == 0 {
+		uc.logger.Debug().Msg("No rules matched, no actions triggered")
+	}
+	return ev, nil
+}
+
+// newMaintenanceCheck возвращает проверку окна обслуживания проекта события. Окно ищется один раз,
+// при первом вызове – только когда есть что отправить. В окно обслуживания действия не отправляются,
+// срабатывание только пишется в лог с ID окна (event.MaintenanceWindowID).
+func (uc *EvaluateRulesUseCase) newMaintenanceCheck(ctx context.Context, event *domain.Event, now time.Time) func() bool {
+	checked := false
+	return func() bool {
+		if !checked {
+			checked = true
+			if w, ok := uc.activeMaintenance(ctx, event.ProjectId, now); ok {
+				uc.logger.Info().Msgf("Maintenance window %s (%s) is active for project=%s, actions not dispatched", w.ID, w.Name, event.ProjectId)
+				event.MaintenanceWindowID = w.ID
+			}
+		}
+		return event.MaintenanceWindowID != ""
+	}
+}
+
+// Deliver отправляет действия сработавших правил и пишет срабатывание в TimescaleDB.
+// При ошибке Deliver можно вызвать повторно с тем же Evaluation: уже выполненные шаги пропускаются.
+func (uc *EvaluateRulesUseCase) Deliver(ctx context.Context, ev *Evaluation) error {
+	event := ev.event
+
 	// 5. Если есть actions, вызываем dispatcher
-	if len(triggered) > 0 {
-		uc.logger.Info().Msgf("Triggered %d actions for user=%s, service=%s", len(triggered), event.UserID, event.ServiceName)
-		err = uc.alertDispatcher.DispatchActions(ctx, event, triggered)
-		if err != nil {
+	if len(ev.triggered) > 0 && event.MaintenanceWindowID == "" && !ev.dispatched {
+		uc.logger.Info().Msgf("Triggered %d actions for user=%s, service=%s", len(ev.triggered), event.UserID, event.ServiceName)
+		if err := uc.alertDispatcher.DispatchActions(ctx, event, ev.triggered); err != nil {
 			return err
 		}
+		ev.dispatched = true
+	}
+
+	// не добавляем лог в timescale если не сработало правило. Сейчас такая логика
+	if ev.logged || len(ev.triggeredRules) == 0 && len(ev.suppressedRules) == 0 && len(ev.silencedRules) == 0 {
+		return nil
 	}
-	uc.logger.Debug().Msg("No rules matched, no actions triggered")
 
 	// 6. Сохраняем лог в TimescaleDB
 	raw, err := json.Marshal(event)
@@ -111,34 +246,116 @@ func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Even
 		return err
 	}
 
-	// не добавляем лог в timescale если не сработало правило. Сейчас такая логика
-	if len(triggeredRuleNames) == 0 {
-		return nil
-	}
 	logEntry := timescale_repository.LogEntry{
-		UserID:      userIDInt,
-		ServiceName: event.ServiceName,
-		Timestamp:   time.Now(),
-		Log:         raw,
-		EventType:   event.EventType,
-		UsedRules:   triggeredRuleNames,
-		Language:    event.Language,
-		ActionUsed:  triggered,
-		ProjectId:   event.ProjectId,
-		Engine:      ENGINE,
-	}
-
-	err = uc.timeScaleRepo.InsertLog(ctx, logEntry)
-	if err != nil {
+		UserID:          userIDInt,
+		ServiceName:     event.ServiceName,
+		Timestamp:       time.Now(),
+		Log:             raw,
+		EventType:       event.EventType,
+		UsedRules:       ev.triggeredRules,
+		SuppressedRules: ev.suppressedRules,
+		SilencedRules:   ev.silencedRules,
+		Language:        event.Language,
+		ActionUsed:      ev.triggered,
+		ProjectId:       event.ProjectId,
+		Engine:          ENGINE,
+	}
+
+	if err = uc.timeScaleRepo.InsertLog(ctx, logEntry); err != nil {
 		uc.logger.Error().Err(err).Msg("Failed to insert log into TimescaleDB")
-		// В зависимости от требований, можно не прерывать выполнение
-		// return err
+		return err
 	}
+	ev.logged = true
 
 	return nil
 }
 
-func (uc *EvaluateRulesUseCase) GetRulesByUserAndServiceAndProjectId(ctx context.Context, userID, serviceName, projectId string) ([]domain.Rule, error) {
+// inCooldown проверяет cooldown сработавшего правила.
+// true – алерт подавлен; иначе в event.SuppressedCount добавляется число подавленных с прошлого алерта,
+// а занятое окно запоминается в ev до отправки (см. Release).
+// При ошибке Redis алерт не подавляется: лучше лишнее сообщение, чем потерянное.
+func (uc *EvaluateRulesUseCase) inCooldown(ctx context.Context, ev *Evaluation, r domain.Rule, evaluator *RuleConditionEvaluator) bool {
+	if r.CooldownSec <= 0 || uc.alertCooldown == nil {
+		return false
+	}
+
+	fingerprint := evaluator.dedupFingerprint(ev.event, r.DedupKey)
+	allowed, suppressed, lease, err := uc.alertCooldown.Acquire(ctx, r.ID, fingerprint, time.Duration(r.CooldownSec)*time.Second)
+	if err != nil {
+		uc.logger.Error().Err(err).Msgf("Cooldown check failed for rule %s, dispatching anyway", r.ID)
+		return false
+	}
+	if !allowed {
+		return true
+	}
+	ev.event.SuppressedCount += suppressed
+	ev.cooldowns = append(ev.cooldowns, lease)
+	return false
+}
+
+// Release освобождает окна cooldown, занятые Evaluate, если действия так и не отправлены
+// (доставка исчерпала повторы и событие уходит в DLQ): иначе окно подавило бы следующие алерты правила.
+func (uc *EvaluateRulesUseCase) Release(ctx context.Context, ev *Evaluation) {
+	if ev.dispatched || uc.alertCooldown == nil {
+		return
+	}
+	for _, lease := range ev.cooldowns {
+		if err := uc.alertCooldown.Release(ctx, lease); err != nil {
+			uc.logger.Error().Err(err).Msgf("Failed to release cooldown of rule %s", lease.RuleID)
+		}
+	}
+	ev.cooldowns = nil
+}
+
+// getCompiledRules возвращает скомпилированные правила для (user, service, project).
+// Правила берутся из in-process LRU без обращения к Redis и разбора JSON на каждом событии;
+// при промахе читаются из Redis или Postgres и компилируются заново.
+func (uc *EvaluateRulesUseCase) getCompiledRules(ctx context.Context, userID, serviceName, projectId string) ([]CompiledRule, error) {
+	key := userID + ":" + serviceName + ":" + projectId
+	now := time.Now()
+	if rules, ok := uc.compiledRules.get(key, now); ok {
+		return rules, nil
+	}
+
+	rules, err := uc.loadRules(ctx, userID, serviceName, projectId)
+	if err != nil {
+		return nil, err
+	}
+	compiled := CompileRules(rules)
+	uc.compiledRules.put(key, compiled, now)
+	return compiled, nil
+}
+
+// InvalidateUser сбрасывает правила пользователя userID в памяти и в Redis.
+// Вызывается RuleChangeListener при изменении правил через public API.
+// Redis сбрасывается первым: иначе событие между двумя шагами вернуло бы в память старые правила.
+func (uc *EvaluateRulesUseCase) InvalidateUser(ctx context.Context, userID string) (int, error) {
+	var deleted int
+	if uc.redisCache != nil {
+		n, err := uc.redisCache.InvalidateUser(ctx, userID)
+		if err != nil {
+			return n, err
+		}
+		deleted = n
+	}
+	return deleted + uc.compiledRules.removePrefix(userID+":"), nil
+}
+
+// InvalidateAll сбрасывает правила всех пользователей в памяти и в Redis.
+func (uc *EvaluateRulesUseCase) InvalidateAll(ctx context.Context) (int, error) {
+	var deleted int
+	if uc.redisCache != nil {
+		n, err := uc.redisCache.InvalidateAll(ctx)
+		if err != nil {
+			return n, err
+		}
+		deleted = n
+	}
+	return deleted + uc.compiledRules.clear(), nil
+}
+
+// loadRules достаёт правила из Redis, а при промахе – из Postgres (и кладёт их в Redis).
+func (uc *EvaluateRulesUseCase) loadRules(ctx context.Context, userID, serviceName, projectId string) ([]domain.Rule, error) {
 	// Сначала пробуем получить правила из кеша
 	if uc.redisCache != nil {
 		if cachedRules, err := uc.redisCache.GetRules(ctx, userID, serviceName, projectId); err == nil && cachedRules != nil {
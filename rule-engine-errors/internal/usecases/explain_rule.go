@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"rule-engine-errors/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// ExplainRule – dry-run правила r на событии e: результат и трассировка каждого узла и условия.
+// Redis не используется: алерты не отправляются, счётчики не меняются, repeat_over, distinct_over и first_seen всегда false.
+func ExplainRule(e *domain.Event, r domain.Rule, logger *zerolog.Logger) (domain.NodeTrace, error) {
+	return domain.TraceRule(e, r, NewRuleConditionEvaluator(nil, logger))
+}
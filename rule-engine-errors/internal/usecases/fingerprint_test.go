@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"reflect"
+	"testing"
+
+	"rule-engine-errors/internal/domain"
+)
+
+func TestNormalizeErrorMessage(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{msg: "user 42 not found", want: "user ? not found"},
+		{msg: "  connection refused  ", want: "connection refused"},
+		{msg: "order 3f2504e0-4f89-11d3-9a0c-0305e82c3301 is locked", want: "order <uuid> is locked"},
+		{msg: "nil pointer dereference at 0xc000123abc", want: "nil pointer dereference at <addr>"},
+		{msg: "timeout after 1500ms on shard 7", want: "timeout after ?ms on shard ?"},
+		{msg: "no digits here", want: "no digits here"},
+		{msg: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeErrorMessage(tt.msg); got != tt.want {
+			t.Errorf("normalizeErrorMessage(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestTopStackFrames(t *testing.T) {
+	const goStack = "goroutine 17 [running]:\n" +
+		"main.(*Handler).ServeHTTP(0xc000010000, {0x7f1a2b, 0xc0000a2000})\n" +
+		"\t/app/handler.go:42 +0x1d\n" +
+		"net/http.serverHandler.ServeHTTP({0xc0001b2000?})\n" +
+		"\t/usr/local/go/src/net/http/server.go:2936 +0x316\n" +
+		"net/http.(*conn).serve(0xc0001c4000, {0x7f1a2b, 0xc0001a0000})\n" +
+		"\t/usr/local/go/src/net/http/server.go:1995 +0x612\n" +
+		"created by net/http.(*Server).Serve in goroutine 1\n" +
+		"\t/usr/local/go/src/net/http/server.go:3089 +0x5ed\n"
+
+	tests := []struct {
+		name  string
+		stack string
+		n     int
+		want  []string
+	}{
+		{
+			name:  "go stack",
+			stack: goStack,
+			n:     5,
+			want:  []string{"main.(*Handler).ServeHTTP", "net/http.serverHandler.ServeHTTP", "net/http.(*conn).serve"},
+		},
+		{
+			name:  "limited depth",
+			stack: goStack,
+			n:     1,
+			want:  []string{"main.(*Handler).ServeHTTP"},
+		},
+		{
+			name:  "addresses without call arguments",
+			stack: "panic at 0xdeadbeef\nworker.run",
+			n:     5,
+			want:  []string{"panic at 0x?", "worker.run"},
+		},
+		{
+			name:  "empty stack",
+			stack: "",
+			n:     5,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topStackFrames(tt.stack, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("topStackFrames() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestErrorFingerprintStable проверяет, что одна ошибка с разными данными и адресами
+// даёт один отпечаток, а другая ошибка – другой.
+func TestErrorFingerprintStable(t *testing.T) {
+	a := &domain.Event{ErrorMessage: "user 42 not found", StackTrace: "main.load(0xc000010000)\n\t/app/main.go:10 +0x1d"}
+	b := &domain.Event{ErrorMessage: "user 7 not found", StackTrace: "main.load(0xc0000ff000)\n\t/app/main.go:10 +0x2f"}
+	c := &domain.Event{ErrorMessage: "user 42 is banned", StackTrace: "main.load(0xc000010000)\n\t/app/main.go:10 +0x1d"}
+
+	if errorFingerprint(a) != errorFingerprint(b) {
+		t.Error("same error with different data produced different fingerprints")
+	}
+	if errorFingerprint(a) == errorFingerprint(c) {
+		t.Error("different errors produced the same fingerprint")
+	}
+}
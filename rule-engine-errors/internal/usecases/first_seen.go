@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+)
+
+// FingerprintStore – известные отпечатки ошибок для first_seen и first_seen_in_version.
+type FingerprintStore interface {
+	Observe(ctx context.Context, e *domain.Event, fingerprint, version string, now time.Time) (domain.Sighting, error)
+}
+
+// parseQuietDays разбирает value операторов first_seen / first_seen_in_version:
+// пустое значение – только новые отпечатки, {"quiet_days": N} – ещё и вернувшиеся после N дней тишины.
+func parseQuietDays(v interface{}) (time.Duration, error) {
+	if v == nil {
+		return 0, nil
+	}
+	valMap, ok := v.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("invalid 'value' (expected map[string]interface{})")
+	}
+	q, ok := valMap["quiet_days"]
+	if !ok {
+		return 0, nil
+	}
+	days, ok := toFloat(q)
+	if !ok || days < 0 {
+		return 0, fmt.Errorf("invalid 'quiet_days' %v", q)
+	}
+	return time.Duration(days * float64(24*time.Hour)), nil
+}
+
+// collectSightings собирает операторы first_seen / first_seen_in_version из дерева правила.
+func collectSightings(node domain.LogicNode, out []domain.ConditionOperator) []domain.ConditionOperator {
+	for _, c := range node.Conditions {
+		if c.Operator != domain.OpFirstSeen && c.Operator != domain.OpFirstSeenInVersion {
+			continue
+		}
+		seen := false
+		for _, op := range out {
+			seen = seen || op == c.Operator
+		}
+		if !seen {
+			out = append(out, c.Operator)
+		}
+	}
+	for _, child := range node.Children {
+		out = collectSightings(child, out)
+	}
+	return out
+}
+
+// recordSightings отмечает отпечаток ошибки события для всех first_seen-операторов правил до проверки условий.
+// Известные отпечатки общие для сервиса, а не для правила: ранний выход AND/OR и правила вне расписания
+// не должны оставлять отпечаток «новым» и без обновлённого last_seen. Результат – в e.Sightings.
+func (rce *RuleConditionEvaluator) recordSightings(ctx context.Context, e *domain.Event, rules []CompiledRule) {
+	// Без хранилища (dry-run) известные отпечатки не читаются и не меняются
+	if rce.fingerprints == nil {
+		return
+	}
+	for i := range rules {
+		for _, op := range rules[i].sightings {
+			if _, done := e.Sightings[string(op)]; done {
+				continue
+			}
+			var version string
+			if op == domain.OpFirstSeenInVersion {
+				if e.Version == "" {
+					continue
+				}
+				version = e.Version
+			}
+			sighting, err := rce.fingerprints.Observe(ctx, e, errorFingerprint(e), version, time.Now())
+			if err != nil {
+				rce.logger.Error().Err(err).Msg("Fingerprint Observe failed")
+				continue
+			}
+			e.SetSighting(op, sighting)
+		}
+	}
+}
+
+// evaluateFirstSeen проверяет, что отпечаток ошибки новый для (project, service, environment)
+// или, для first_seen_in_version, для версии сервиса. Отпечаток отмечается заранее, в recordSightings:
+// все правила события видят один результат из e.Sightings.
+func (rce *RuleConditionEvaluator) evaluateFirstSeen(e *domain.Event, c domain.Condition) bool {
+	quiet, err := parseQuietDays(c.Value)
+	if err != nil {
+		rce.logger.Warn().Err(err).Msgf("%s condition: invalid value", c.Operator)
+		return false
+	}
+
+	sighting, ok := e.Sightings[string(c.Operator)]
+	if !ok {
+		// нет хранилища (dry-run), нет версии у события или Observe не удался
+		rce.logger.Debug().Msgf("%s is not evaluated: fingerprint was not recorded", c.Operator)
+		return false
+	}
+
+	if sighting.New {
+		return true
+	}
+	return quiet > 0 && sighting.QuietFor(time.Now()) >= quiet
+}
@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog"
+)
+
+type MonitorRepository interface {
+	GetEnabledMonitors(ctx context.Context) ([]domain.Monitor, error)
+}
+
+// monitorCronParser – стандартный cron из 5 полей и дескрипторы (@daily, @hourly, ...).
+var monitorCronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// scheduledMonitor – монитор с разобранным расписанием.
+type scheduledMonitor struct {
+	domain.Monitor
+	schedule cron.Schedule
+}
+
+// nextAfter – первый запуск после t. Для cron-расписания check-in, пришедший с опозданием
+// в пределах grace или немного раньше срока, засчитывается ближайшему запуску, а не следующему.
+func (sm *scheduledMonitor) nextAfter(t time.Time) time.Time {
+	if sm.ScheduleType == domain.ScheduleCron {
+		return sm.schedule.Next(t.Add(sm.Grace()))
+	}
+	return sm.schedule.Next(t)
+}
+
+// MonitorRegistry – мониторы всех проектов в памяти реплики, индекс по (project_id, slug).
+// Обновляется планировщиком на каждом тике, в том числе на репликах, которые не лидер:
+// check-in'ы обрабатывает любая реплика.
+type MonitorRegistry struct {
+	repo   MonitorRepository
+	logger *zerolog.Logger
+
+	mu       sync.RWMutex
+	monitors map[string]*scheduledMonitor
+}
+
+func NewMonitorRegistry(repo MonitorRepository, logger *zerolog.Logger) *MonitorRegistry {
+	return &MonitorRegistry{
+		repo:     repo,
+		logger:   logger,
+		monitors: map[string]*scheduledMonitor{},
+	}
+}
+
+// Refresh перечитывает мониторы. Монитор с неразбираемым расписанием пропускается с предупреждением.
+func (r *MonitorRegistry) Refresh(ctx context.Context) error {
+	monitors, err := r.repo.GetEnabledMonitors(ctx)
+	if err != nil {
+		return err
+	}
+
+	next := make(map[string]*scheduledMonitor, len(monitors))
+	for _, m := range monitors {
+		schedule, err := parseMonitorSchedule(m)
+		if err != nil {
+			r.logger.Warn().Err(err).Msgf("Skipping monitor %s (%s)", m.ID, m.Slug)
+			continue
+		}
+		next[monitorKey(m.ProjectID, m.Slug)] = &scheduledMonitor{Monitor: m, schedule: schedule}
+	}
+
+	r.mu.Lock()
+	r.monitors = next
+	r.mu.Unlock()
+	return nil
+}
+
+// lookup возвращает монитор проекта по slug.
+func (r *MonitorRegistry) lookup(projectID, slug string) (*scheduledMonitor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sm, ok := r.monitors[monitorKey(projectID, slug)]
+	return sm, ok
+}
+
+// all возвращает снимок всех мониторов.
+func (r *MonitorRegistry) all() []*scheduledMonitor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]*scheduledMonitor, 0, len(r.monitors))
+	for _, sm := range r.monitors {
+		list = append(list, sm)
+	}
+	return list
+}
+
+func parseMonitorSchedule(m domain.Monitor) (cron.Schedule, error) {
+	switch m.ScheduleType {
+	case domain.ScheduleCron:
+		tz := m.Timezone
+		if tz == "" {
+			tz = "UTC"
+		}
+		if _, err := time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+		}
+		return monitorCronParser.Parse(fmt.Sprintf("CRON_TZ=%s %s", tz, m.CronExpr))
+	case domain.ScheduleInterval:
+		if m.IntervalSec <= 0 {
+			return nil, fmt.Errorf("invalid interval_sec %d", m.IntervalSec)
+		}
+		return cron.Every(time.Duration(m.IntervalSec) * time.Second), nil
+	default:
+		return nil, fmt.Errorf("unknown schedule_type %q", m.ScheduleType)
+	}
+}
+
+func monitorKey(projectID, slug string) string {
+	return projectID + ":" + slug
+}
@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rule-engine-errors/internal/dataproviders/redis_repository"
+	"rule-engine-errors/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// maxMissedRunsPerTick – сколько пропущенных запусков подряд пересчитывается за один тик.
+// Дальше ожидаемый запуск переносится сразу на будущее (например, после долгого простоя движка).
+const maxMissedRunsPerTick = 1000
+
+// MonitorScheduler раз в interval обновляет мониторы и, если реплика – лидер,
+// ищет мониторы без check-in к сроку (запланированный запуск + grace).
+// За каждый пропуск отправляется один алерт: ожидаемый запуск сдвигается атомарно,
+// поэтому при смене лидера алерт не дублируется, а при ошибке отправки сдвиг откатывается.
+type MonitorScheduler struct {
+	registry        *MonitorRegistry
+	state           *redis_repository.RedisMonitorState
+	leader          *redis_repository.RedisLeaderLock
+	alertDispatcher AlertDispatcher
+	interval        time.Duration
+	logger          *zerolog.Logger
+}
+
+func NewMonitorScheduler(
+	registry *MonitorRegistry,
+	state *redis_repository.RedisMonitorState,
+	leader *redis_repository.RedisLeaderLock,
+	ad AlertDispatcher,
+	interval time.Duration,
+	logger *zerolog.Logger,
+) *MonitorScheduler {
+	return &MonitorScheduler{
+		registry:        registry,
+		state:           state,
+		leader:          leader,
+		alertDispatcher: ad,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+// Run работает до отмены ctx, затем отдаёт лидерство.
+func (s *MonitorScheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_ = s.leader.Release(releaseCtx)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *MonitorScheduler) tick(ctx context.Context) {
+	if err := s.registry.Refresh(ctx); err != nil {
+		// Работаем со старым списком мониторов
+		s.logger.Error().Err(err).Msg("Failed to refresh monitors")
+	}
+
+	isLeader, err := s.leader.Acquire(ctx)
+	if err != nil || !isLeader {
+		return
+	}
+
+	now := time.Now()
+	for _, sm := range s.registry.all() {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.checkMonitor(ctx, sm, now); err != nil {
+			s.logger.Error().Err(err).Msgf("Failed to check monitor %s", sm.ID)
+		}
+	}
+}
+
+// checkMonitor проверяет один монитор на момент now.
+func (s *MonitorScheduler) checkMonitor(ctx context.Context, sm *scheduledMonitor, now time.Time) error {
+	st, err := s.state.Get(ctx, sm.Monitor)
+	if err != nil {
+		return err
+	}
+	if st.NextDue.IsZero() {
+		// Новый монитор или изменилось расписание: ждём первый запуск после now
+		return s.state.Init(ctx, sm.Monitor, sm.schedule.Next(now))
+	}
+
+	grace := sm.Grace()
+	if now.Before(st.NextDue.Add(grace)) {
+		return nil
+	}
+
+	// Ожидаемый запуск пропущен: переходим к первому запуску, срок которого ещё не наступил
+	missed := 0
+	next := st.NextDue
+	for !now.Before(next.Add(grace)) {
+		missed++
+		if missed == maxMissedRunsPerTick {
+			next = sm.schedule.Next(now)
+			break
+		}
+		next = sm.schedule.Next(next)
+	}
+
+	advanced, err := s.state.AdvanceDue(ctx, sm.Monitor, st.NextDue, next)
+	if err != nil {
+		return err
+	}
+	if !advanced {
+		// Пока проверяли, пришёл check-in
+		return nil
+	}
+
+	message := fmt.Sprintf("Monitor %s missed scheduled run at %s", monitorTitle(sm.Monitor), st.NextDue.UTC().Format(time.RFC3339))
+	if missed > 1 {
+		message = fmt.Sprintf("Monitor %s missed %d scheduled runs since %s", monitorTitle(sm.Monitor), missed, st.NextDue.UTC().Format(time.RFC3339))
+	}
+	event := monitorEvent(sm.Monitor, domain.EventTypeCheckInMissed, st.ServiceName, st.Environment, message, now)
+	event.Fields["expected_at"] = st.NextDue.UTC().Format(time.RFC3339)
+	event.Fields["missed_runs"] = missed
+	if !st.LastCheckIn.IsZero() {
+		event.Fields["last_check_in"] = st.LastCheckIn.UTC().Format(time.RFC3339)
+		event.Fields["last_status"] = string(st.LastStatus)
+	}
+
+	s.logger.Info().Msgf("Monitor %s missed %d run(s), next expected at %s", sm.ID, missed, next.Format(time.RFC3339))
+	if err := s.alertDispatcher.DispatchActions(ctx, event, sm.Actions); err != nil {
+		// Алерт не ушёл: возвращаем пропущенный запуск тем же CAS, следующий тик повторит отправку.
+		// Если за это время пришёл check-in, он уже сдвинул next_due и возвращать нечего.
+		if _, restoreErr := s.state.AdvanceDue(ctx, sm.Monitor, next, st.NextDue); restoreErr != nil {
+			s.logger.Error().Err(restoreErr).Msgf("Failed to restore missed run of monitor %s", sm.ID)
+		}
+		return err
+	}
+	return nil
+}
@@ -2,18 +2,35 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"rule-engine-errors/internal/dataproviders/redis_repository"
 	"rule-engine-errors/internal/domain"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/rs/zerolog"
 )
 
 // RuleConditionEvaluator отвечает за проверку одиночного условия (Condition) на событии (Event).
 type RuleConditionEvaluator struct {
-	redisCounter *redis_repository.RedisRepeatCounter
-	logger       *zerolog.Logger
+	redisCounter    *redis_repository.RedisRepeatCounter
+	distinctCounter *redis_repository.RedisDistinctCounter
+	fingerprints    FingerprintStore
+	regexCache      *regexCache
+	logger          *zerolog.Logger
+}
+
+// NewRuleConditionEvaluator создаёт evaluator со своим кешем регулярок.
+// redisCounter может быть nil – тогда repeat_over не считается (dry-run, бенчмарки).
+// Счётчика уникальных значений и хранилища отпечатков у такого evaluator нет:
+// distinct_over и first_seen в dry-run не срабатывают.
+func NewRuleConditionEvaluator(redisCounter *redis_repository.RedisRepeatCounter, logger *zerolog.Logger) *RuleConditionEvaluator {
+	return &RuleConditionEvaluator{
+		redisCounter: redisCounter,
+		regexCache:   newRegexCache(regexCacheSize),
+		logger:       logger,
+	}
 }
 
 // Evaluate проверяет, выполняется ли условие cond для события e.
@@ -64,17 +81,39 @@ func (rce *RuleConditionEvaluator) Evaluate(e *domain.Event, c domain.Condition,
 			return false
 		}
 
+		// Необязательный group_by: ["error_message"], ["fields.customer_id"], ["tags.region"]
+		groupKey, ok := rce.makeGroupKey(e, valMap["group_by"])
+		if !ok {
+			rce.logger.Warn().Msg("repeat_over condition: invalid type for 'group_by' (expected list of strings)")
+			return false
+		}
+
+		// Без счётчика (dry-run) состояние в Redis не читается и не меняется
+		if rce.redisCounter == nil {
+			rce.logger.Debug().Msg("repeat_over is not evaluated without Redis counter")
+			return false
+		}
+
 		// Получаем счетчик за окно времени minutes
-		cnt, err := rce.redisCounter.CountInWindow(context.Background(), e, r, minutes)
+		cnt, err := rce.redisCounter.CountInWindow(context.Background(), e, r, minutes, groupKey)
 		if err != nil {
 			rce.logger.Error().Err(err).Msg("CountInWindow failed")
 			return false
 		}
 		e.RepeatCount = cnt
+		e.GroupKey = groupKey
 
-		rce.logger.Debug().Msgf("repeat_over check: count=%d, threshold=%d", cnt, threshold)
+		rce.logger.Debug().Msgf("repeat_over check: count=%d, threshold=%d, group=%q", cnt, threshold, groupKey)
 		return cnt >= threshold
 
+	// --- "distinct_over" (уникальные значения поля за окно, HyperLogLog) ---
+	case domain.OpDistinctOver:
+		return rce.evaluateDistinctOver(e, c, r)
+
+	// --- "first_seen" / "first_seen_in_version" (новая ошибка или регрессия) ---
+	case domain.OpFirstSeen, domain.OpFirstSeenInVersion:
+		return rce.evaluateFirstSeen(e, c)
+
 	// --- "eq" (равно) ---
 	case domain.OpEQ:
 		return isEqual(rce.getField(e, c.Field), c.Value)
@@ -113,6 +152,46 @@ func (rce *RuleConditionEvaluator) Evaluate(e *domain.Event, c domain.Condition,
 	case domain.OpCont:
 		return evaluateContains(rce.getField(e, c.Field), c.Value)
 
+	// --- "not_contains" (не содержит) ---
+	case domain.OpNotCont:
+		return evaluateNotContains(rce.getField(e, c.Field), c.Value)
+
+	// --- "icontains" (содержит, без учёта регистра) ---
+	case domain.OpIContains:
+		return evaluateIContains(rce.getField(e, c.Field), c.Value)
+
+	// --- "starts_with" (начинается с) ---
+	case domain.OpStartsWith:
+		return evaluateStringFunc(rce.getField(e, c.Field), c.Value, strings.HasPrefix)
+
+	// --- "ends_with" (заканчивается на) ---
+	case domain.OpEndsWith:
+		return evaluateStringFunc(rce.getField(e, c.Field), c.Value, strings.HasSuffix)
+
+	// --- "matches" (регулярное выражение RE2) ---
+	case domain.OpMatches:
+		return rce.evaluateMatches(rce.getField(e, c.Field), c.Value, r)
+
+	// --- "exists" / "not_exists" (поле или тег присутствует) ---
+	case domain.OpExists:
+		return rce.getField(e, c.Field) != nil
+	case domain.OpNotExists:
+		return rce.getField(e, c.Field) == nil
+
+	// --- "version_gt" / "version_gte" / "version_lt" / "version_lte" (сравнение semver) ---
+	case domain.OpVersionGT:
+		return rce.evaluateVersion(rce.getField(e, c.Field), c.Value, 1)
+	case domain.OpVersionGTE:
+		return rce.evaluateVersion(rce.getField(e, c.Field), c.Value, 1, 0)
+	case domain.OpVersionLT:
+		return rce.evaluateVersion(rce.getField(e, c.Field), c.Value, -1)
+	case domain.OpVersionLTE:
+		return rce.evaluateVersion(rce.getField(e, c.Field), c.Value, -1, 0)
+
+	// --- "version_in_range" (версия в диапазоне ">=1.4.0 <2.0.0") ---
+	case domain.OpVersionInRange:
+		return rce.evaluateVersionInRange(rce.getField(e, c.Field), c.Value)
+
 	// --- неизвестный оператор ---
 	default:
 		rce.logger.Debug().Msgf("Unsupported operator: %s", c.Operator)
@@ -121,14 +200,59 @@ func (rce *RuleConditionEvaluator) Evaluate(e *domain.Event, c domain.Condition,
 }
 
 // getField выбирает способ получения значения поля: если имя поля начинается с "fields.",
-// то используется динамический поиск в evt.Fields, иначе — фиксированная логика.
+// то используется динамический поиск в evt.Fields, "tags." — поиск тега по ключу,
+// "context." — поиск в разобранном ContextJson, иначе — фиксированная логика.
 func (rce *RuleConditionEvaluator) getField(e *domain.Event, field string) interface{} {
 	if strings.HasPrefix(field, "fields.") {
 		return rce.getDynamicField(e, field)
 	}
+	if strings.HasPrefix(field, "tags.") {
+		return getTag(e, strings.TrimPrefix(field, "tags."))
+	}
+	if strings.HasPrefix(field, "context.") {
+		return rce.getContextField(e, field)
+	}
 	return getFieldValue(e, field)
 }
 
+// ResolveField возвращает значение поля так, как его видит условие (для трассировки dry-run).
+func (rce *RuleConditionEvaluator) ResolveField(e *domain.Event, field string) interface{} {
+	return rce.getField(e, field)
+}
+
+// makeGroupKey собирает ключ группы для repeat_over из значений полей, перечисленных в group_by.
+// Например, group_by=["error_message","tags.region"] => "error_message=timeout|tags.region=eu-west-1".
+// Пустой group_by даёт пустой ключ (все события правила считаются вместе).
+func (rce *RuleConditionEvaluator) makeGroupKey(e *domain.Event, groupBy interface{}) (string, bool) {
+	var paths []string
+	switch g := groupBy.(type) {
+	case nil:
+		return "", true
+	case []string:
+		paths = g
+	case []interface{}:
+		for _, p := range g {
+			ps, ok := p.(string)
+			if !ok {
+				return "", false
+			}
+			paths = append(paths, ps)
+		}
+	default:
+		return "", false
+	}
+
+	parts := make([]string, 0, len(paths))
+	for _, p := range paths {
+		val := rce.getField(e, p)
+		if val == nil {
+			val = ""
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", p, val))
+	}
+	return strings.Join(parts, "|"), true
+}
+
 // getFieldValue возвращает значение фиксированного поля события.
 func getFieldValue(e *domain.Event, field string) interface{} {
 	switch field {
@@ -172,6 +296,25 @@ func getFieldValue(e *domain.Event, field string) interface{} {
 
 // ===================== Динамическая проверка полей =====================
 
+// getContextField извлекает значение из ContextJson по dot-path (например, "context.user_info.vip").
+// Если JSON битый или пути нет, возвращается nil.
+func (rce *RuleConditionEvaluator) getContextField(e *domain.Event, fieldPath string) interface{} {
+	ctxMap, err := e.ContextMap()
+	if err != nil {
+		rce.logger.Warn().Err(err).Msg("getContextField: failed to parse context_json")
+		return nil
+	}
+	return traverseMap(ctxMap, strings.Split(strings.TrimPrefix(fieldPath, "context."), "."))
+}
+
+// getTag возвращает значение тега "key=value" по ключу или nil, если тега нет.
+func getTag(e *domain.Event, key string) interface{} {
+	if v, ok := e.TagMap()[key]; ok {
+		return v
+	}
+	return nil
+}
+
 // getDynamicField извлекает значение динамического поля по dot-path (например, "fields.memory_alloc_bytes").
 // Если первый сегмент не равен "fields", возвращается nil.
 func (rce *RuleConditionEvaluator) getDynamicField(evt *domain.Event, fieldPath string) interface{} {
@@ -236,6 +379,20 @@ func (rce *RuleConditionEvaluator) traverseMapDebug(cur interface{}, keys []stri
 	}
 }
 
+// traverseMap проходит по вложенным мапам обычного JSON (ключи без префикса "fields.").
+func traverseMap(cur interface{}, keys []string) interface{} {
+	for _, k := range keys {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if cur, ok = m[k]; !ok {
+			return nil
+		}
+	}
+	return cur
+}
+
 // ===================== Вспомогательные функции =====================
 
 // isEqual сравнивает простые значения (строки, float64, int и т.д.) на равенство.
@@ -352,3 +509,137 @@ func evaluateContains(fieldVal interface{}, condVal interface{}) bool {
 
 	return false
 }
+
+// evaluateNotContains – отрицание contains.
+func evaluateNotContains(fieldVal, condVal interface{}) bool {
+	return !evaluateContains(fieldVal, condVal)
+}
+
+// evaluateIContains – contains без учёта регистра: подстрока для строки, элемент для списка.
+func evaluateIContains(fieldVal, condVal interface{}) bool {
+	cvStr, ok := condVal.(string)
+	if !ok {
+		return false
+	}
+	if fvStr, ok := fieldVal.(string); ok {
+		return strings.Contains(strings.ToLower(fvStr), strings.ToLower(cvStr))
+	}
+	for _, s := range toStrings(fieldVal) {
+		if strings.EqualFold(s, cvStr) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateStringFunc применяет строковую проверку к строке или к любому элементу списка строк.
+func evaluateStringFunc(fieldVal, condVal interface{}, fn func(s, cond string) bool) bool {
+	cvStr, ok := condVal.(string)
+	if !ok {
+		return false
+	}
+	for _, s := range toStrings(fieldVal) {
+		if fn(s, cvStr) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateMatches проверяет значение поля регулярным выражением RE2 из condVal.
+// Регулярка берётся из LRU-кеша, битый паттерн логируется и даёт false.
+func (rce *RuleConditionEvaluator) evaluateMatches(fieldVal, condVal interface{}, r domain.Rule) bool {
+	pattern, ok := condVal.(string)
+	if !ok {
+		rce.logger.Warn().Msgf("matches condition: invalid 'value' type %T (expected string)", condVal)
+		return false
+	}
+	re, err := rce.regexCache.get(pattern)
+	if err != nil {
+		rce.logger.Error().Err(err).Msgf("matches condition: invalid regex %q in rule %s", pattern, r.ID)
+		return false
+	}
+	for _, s := range toStrings(fieldVal) {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// toStrings приводит строку или список строк к []string; остальные типы дают nil.
+func toStrings(v interface{}) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	case []interface{}:
+		out := make([]string, 0, len(t))
+		for _, el := range t {
+			if s, ok := el.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// parseVersion разбирает semver-строку ("1.10.0", "v1.4", "go1.22.3").
+func parseVersion(v interface{}) (*semver.Version, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return nil, false
+	}
+	ver, err := semver.NewVersion(strings.TrimPrefix(s, "go"))
+	if err != nil {
+		return nil, false
+	}
+	return ver, true
+}
+
+// compareVersions сравнивает версии по semver: -1, 0 или 1; при неразборчивых версиях ok=false.
+func compareVersions(a, b interface{}) (int, bool) {
+	av, aok := parseVersion(a)
+	bv, bok := parseVersion(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	return av.Compare(bv), true
+}
+
+// evaluateVersion проверяет semver-сравнение fieldVal с condVal; accept – допустимые результаты Compare.
+func (rce *RuleConditionEvaluator) evaluateVersion(fieldVal, condVal interface{}, accept ...int) bool {
+	cmp, ok := compareVersions(fieldVal, condVal)
+	if !ok {
+		rce.logger.Debug().Msgf("version compare: cannot parse %v or %v as semver", fieldVal, condVal)
+		return false
+	}
+	for _, a := range accept {
+		if cmp == a {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateVersionInRange проверяет, что версия попадает в диапазон вида ">=1.4.0 <2.0.0".
+func (rce *RuleConditionEvaluator) evaluateVersionInRange(fieldVal, condVal interface{}) bool {
+	rng, ok := condVal.(string)
+	if !ok {
+		rce.logger.Warn().Msgf("version_in_range condition: invalid 'value' type %T (expected string)", condVal)
+		return false
+	}
+	constraint, err := semver.NewConstraint(rng)
+	if err != nil {
+		rce.logger.Error().Err(err).Msgf("version_in_range condition: invalid range %q", rng)
+		return false
+	}
+	ver, ok := parseVersion(fieldVal)
+	if !ok {
+		rce.logger.Debug().Msgf("version_in_range: cannot parse %v as semver", fieldVal)
+		return false
+	}
+	return constraint.Check(ver)
+}
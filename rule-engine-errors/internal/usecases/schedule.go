@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rule-engine-errors/internal/domain"
+)
+
+// maintenanceWindowsTTL – сколько окна обслуживания проекта живут в памяти.
+// Новое окно начинает действовать не позже чем через TTL после создания.
+const maintenanceWindowsTTL = 30 * time.Second
+
+type MaintenanceRepository interface {
+	GetMaintenanceWindows(ctx context.Context, projectID string) ([]domain.MaintenanceWindow, error)
+}
+
+type maintenanceEntry struct {
+	windows  []domain.MaintenanceWindow
+	loadedAt time.Time
+}
+
+// maintenanceCache – окна обслуживания по проекту с TTL.
+type maintenanceCache struct {
+	mu    sync.Mutex
+	items map[string]maintenanceEntry
+}
+
+func newMaintenanceCache() *maintenanceCache {
+	return &maintenanceCache{items: map[string]maintenanceEntry{}}
+}
+
+// ruleActive проверяет расписание правила. Правило с битым расписанием остаётся активным:
+// лучше лишний алерт, чем молча выключенное правило (API проверяет расписание при сохранении).
+func (uc *EvaluateRulesUseCase) ruleActive(r domain.Rule, now time.Time) bool {
+	if r.Schedule == nil {
+		return true
+	}
+	active, err := r.Schedule.Active(now)
+	if err != nil {
+		uc.logger.Warn().Err(err).Msgf("Rule %s has invalid schedule, treating as always active", r.ID)
+		return true
+	}
+	return active
+}
+
+// activeMaintenance возвращает окно обслуживания проекта события, которое действует сейчас.
+// Ошибка чтения окон не подавляет алерты.
+func (uc *EvaluateRulesUseCase) activeMaintenance(ctx context.Context, projectID string, now time.Time) (domain.MaintenanceWindow, bool) {
+	if uc.maintenanceRepo == nil {
+		return domain.MaintenanceWindow{}, false
+	}
+
+	c := uc.maintenance
+	c.mu.Lock()
+	entry, ok := c.items[projectID]
+	c.mu.Unlock()
+	if !ok || now.Sub(entry.loadedAt) >= maintenanceWindowsTTL {
+		windows, err := uc.maintenanceRepo.GetMaintenanceWindows(ctx, projectID)
+		if err != nil {
+			uc.logger.Error().Err(err).Msgf("Failed to fetch maintenance windows for project=%s", projectID)
+			return domain.MaintenanceWindow{}, false
+		}
+		entry = maintenanceEntry{windows: windows, loadedAt: now}
+		c.mu.Lock()
+		c.items[projectID] = entry
+		c.mu.Unlock()
+	}
+
+	for _, w := range entry.windows {
+		active, err := w.Active(now)
+		if err != nil {
+			uc.logger.Warn().Err(err).Msgf("Maintenance window %s has invalid schedule", w.ID)
+			continue
+		}
+		if active {
+			return w, true
+		}
+	}
+	return domain.MaintenanceWindow{}, false
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -15,6 +17,7 @@ import (
 	postgres "rule-engine-resources/internal/dataproviders/postgres_repository"
 	redisRepository "rule-engine-resources/internal/dataproviders/redis_repository"
 	timescaleRepository "rule-engine-resources/internal/dataproviders/timescale_repository"
+	"rule-engine-resources/internal/transport"
 	"rule-engine-resources/internal/usecases"
 
 	"github.com/redis/go-redis/v9"
@@ -73,10 +76,16 @@ func main() {
 	//
 	//// Инициализируем репозиторий правил из коллекции "rules_resources"
 	//ruleRepo := mongoRepository.NewMongoRuleRepository(db, "rules_resources", logger)
-	ruleRepo, err := postgres.NewPostgresRuleRepository(&logger, cfg)
+	db, err := postgres.NewPostgresDB(&logger, cfg)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to init Postgres repo")
+		logger.Fatal().Err(err).Msg("Failed to init Postgres")
 	}
+	defer db.Close()
+	ruleRepo := postgres.NewPostgresRuleRepository(&logger, db)
+	correlationRepo := postgres.NewPostgresCorrelationRuleRepository(&logger, db)
+	maintenanceRepo := postgres.NewPostgresMaintenanceRepository(&logger, db)
+	silenceRepo := postgres.NewPostgresSilenceRepository(&logger, db)
+
 	// Подключаемся к Redis
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     cfg.Redis.Addr,
@@ -84,8 +93,15 @@ func main() {
 		DB:       cfg.Redis.DB,
 	})
 	defer rdb.Close()
-	repeatCounter := redisRepository.NewRedisRepeatCounter(rdb, 300, &logger)
+	repeatCounter := redisRepository.NewRedisRepeatCounter(rdb, internal.RepeatMaxWindowSec, &logger)
+	metricWindow := redisRepository.NewRedisMetricWindow(rdb, internal.MetricWindowMaxSec, &logger)
+	anomalyBaseline := redisRepository.NewRedisAnomalyBaseline(rdb, &logger)
+	fleetState := redisRepository.NewRedisFleetState(rdb, internal.FleetInstanceTTLSec, &logger)
 	redisCache := redisRepository.NewRedisRuleCache(rdb, internal.RulesCacheTTL, &logger)
+	alertCooldown := redisRepository.NewRedisAlertCooldown(rdb, usecases.ENGINE, &logger)
+	correlationState := redisRepository.NewRedisCorrelationState(rdb, &logger)
+	silenceCache := redisRepository.NewRedisSilenceCache(rdb, internal.SilencesCacheTTL, &logger)
+	alertState := redisRepository.NewRedisAlertStateStore(rdb, &logger)
 
 	// Разбиваем список брокеров (ожидается, что они разделены запятыми)
 	kafkaBrokers := strings.Split(cfg.Kafka.Brokers, ",")
@@ -107,16 +123,36 @@ func main() {
 		timeScaleRepo,
 		dispatcher,
 		repeatCounter,
+		metricWindow,
+		anomalyBaseline,
+		fleetState,
 		redisCache,
+		alertCooldown,
+		alertState,
+		correlationRepo,
+		correlationState,
+		maintenanceRepo,
+		silenceRepo,
+		silenceCache,
 		&logger,
 	)
 
+	// Dead-letter очередь для сообщений, которые не удалось обработать
+	dlq := kafkaRepository.NewDeadLetterProducer(kafkaBrokers, cfg.DLQTopic(), &logger)
+	defer dlq.Close()
+
 	// Инициализируем Kafka consumer с ручным коммитом (CommitInterval: 0)
 	consumer := kafkaRepository.NewRuleEngineConsumer(
 		kafkaBrokers,
 		cfg.Kafka.ConsumerGroup,
 		cfg.Kafka.ResourceTopic,
 		evalUC,
+		dlq,
+		kafkaRepository.RetryPolicy{
+			MaxAttempts:    cfg.Kafka.RetryMaxAttempts,
+			InitialBackoff: cfg.Kafka.RetryInitialBackoff,
+			MaxBackoff:     cfg.Kafka.RetryMaxBackoff,
+		},
 		&logger,
 	)
 	defer consumer.Close()
@@ -132,12 +168,38 @@ func main() {
 		}
 	}()
 
+	// Сброс кеша правил по уведомлениям public API об изменениях
+	ruleChanges := postgres.NewRuleChangeListener(cfg, usecases.ENGINE, evalUC, silenceCache, &logger)
+	defer ruleChanges.Close()
+	go func() {
+		if err := ruleChanges.Run(ctx); err != nil {
+			logger.Error().Err(err).Msg("Rule change listener stopped with error")
+		}
+	}()
+
+	// Служебный HTTP-сервер для dry-run правил
+	httpServer, err := transport.NewHTTPServer(cfg.HTTPAddr, cfg.HTTPToken, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid HTTP server config")
+	}
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error().Err(err).Msg("HTTP server stopped with error")
+		}
+	}()
+
 	logger.Info().Msg("Resource Rule Engine running. Waiting for signal...")
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 
 	logger.Info().Msg("Shutting down Resource Rule Engine gracefully...")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("Failed to shut down HTTP server")
+	}
 }
 
 // initMongoWithAuth устанавливает соединение с MongoDB с аутентификацией.
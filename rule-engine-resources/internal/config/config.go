@@ -3,6 +3,8 @@ package config
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -11,6 +13,11 @@ type Config struct {
 	LogLevel  string `envconfig:"LOG_LEVEL" default:"debug"`
 	LogFormat string `envconfig:"LOG_FORMAT" default:"json"` // Новое поле для формата логирования
 
+	// Служебный HTTP-сервер (dry-run правил). HTTPToken – общий с public API токен (заголовок X-Rule-Engine-Token);
+	// без токена сервер слушает только loopback
+	HTTPAddr  string `envconfig:"HTTP_ADDR" default:"127.0.0.1:8090"`
+	HTTPToken string `envconfig:"HTTP_TOKEN" default:""`
+
 	// MongoDB
 	Mongo struct {
 		User       string `envconfig:"MONGO_USER" required:"true"`
@@ -32,6 +39,12 @@ type Config struct {
 		Brokers       string `envconfig:"KAFKA_BROKERS" default:"localhost:9092"`
 		ConsumerGroup string `envconfig:"KAFKA_CONSUMER_GROUP" default:"resource-rule-group"`
 		ResourceTopic string `envconfig:"KAFKA_RESOURCE_TOPIC" default:"kafka-resource-usage-topic"`
+		// DLQTopic – куда уходят сообщения, которые не удалось обработать; пусто – "<KAFKA_RESOURCE_TOPIC>-dlq"
+		DLQTopic string `envconfig:"KAFKA_DLQ_TOPIC" default:""`
+		// Повторы обработки сообщения перед отправкой в DLQ (backoff удваивается до RetryMaxBackoff)
+		RetryMaxAttempts    int           `envconfig:"KAFKA_RETRY_MAX_ATTEMPTS" default:"3"`
+		RetryInitialBackoff time.Duration `envconfig:"KAFKA_RETRY_INITIAL_BACKOFF" default:"200ms"`
+		RetryMaxBackoff     time.Duration `envconfig:"KAFKA_RETRY_MAX_BACKOFF" default:"5s"`
 		//ProducerTopics []string `envconfig:"KAFKA_PRODUCER_TOPICS" required:"true"` // Например: "mail,tele,disc"
 	} `envconfig:"KAFKA"`
 
@@ -52,9 +65,21 @@ type Config struct {
 		User     string `envconfig:"POSTGRES_USER" required:"true"`
 		Password string `envconfig:"POSTGRES_PASSWORD" required:"true"`
 		DBName   string `envconfig:"POSTGRES_DB" required:"true"`
+		// Пул соединений, общий для всех репозиториев
+		MaxOpenConns    int           `envconfig:"POSTGRES_MAX_OPEN_CONNS" default:"20"`
+		MaxIdleConns    int           `envconfig:"POSTGRES_MAX_IDLE_CONNS" default:"10"`
+		ConnMaxLifetime time.Duration `envconfig:"POSTGRES_CONN_MAX_LIFETIME" default:"30m"`
 	} `envconfig:"POSTGRES"`
 }
 
+// DLQTopic возвращает топик dead-letter очереди.
+func (c *Config) DLQTopic() string {
+	if c.Kafka.DLQTopic != "" {
+		return c.Kafka.DLQTopic
+	}
+	return c.Kafka.ResourceTopic + "-dlq"
+}
+
 func LoadConfig() (*Config, error) {
 	var cfg Config
 	err := envconfig.Process("", &cfg)
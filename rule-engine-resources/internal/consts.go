@@ -2,4 +2,13 @@ package internal
 
 const (
 	RulesCacheTTL = 300
+	// SilencesCacheTTL – сколько silences проекта живут в Redis: новый или истёкший через API silence
+	// начинает (перестаёт) действовать не позже чем через это время
+	SilencesCacheTTL = 15
+	// RepeatMaxWindowSec – максимальное окно для repeat_over (сутки)
+	RepeatMaxWindowSec = 86400
+	// MetricWindowMaxSec – сколько хранятся сэмплы для оконных операторов (avg_over, max_over, ...)
+	MetricWindowMaxSec = 3600
+	// FleetInstanceTTLSec – экземпляр без сэмплов дольше этого времени не учитывается fleet-условиями
+	FleetInstanceTTLSec = 180
 )
@@ -3,6 +3,7 @@ package kafka_repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"rule-engine-resources/internal/domain"
 	"rule-engine-resources/internal/usecases"
 
@@ -11,14 +12,18 @@ import (
 )
 
 // RuleEngineConsumer читает сообщения из Kafka и передаёт события в EvaluateRulesUseCase.
+// Сообщение, которое не удалось обработать за retry.MaxAttempts попыток, уходит в DLQ,
+// и только после этого коммитится – так ни одно событие не теряется молча.
 type RuleEngineConsumer struct {
 	reader  *kafka.Reader
 	useCase *usecases.EvaluateRulesUseCase
+	dlq     *DeadLetterProducer
+	retry   RetryPolicy
 	logger  *zerolog.Logger
 }
 
 // NewRuleEngineConsumer создаёт нового потребителя с ручным коммитом (CommitInterval: 0).
-func NewRuleEngineConsumer(brokers []string, groupID, topic string, uc *usecases.EvaluateRulesUseCase, logger *zerolog.Logger) *RuleEngineConsumer {
+func NewRuleEngineConsumer(brokers []string, groupID, topic string, uc *usecases.EvaluateRulesUseCase, dlq *DeadLetterProducer, retry RetryPolicy, logger *zerolog.Logger) *RuleEngineConsumer {
 	readerConfig := kafka.ReaderConfig{
 		Brokers:        brokers,
 		GroupID:        groupID,
@@ -28,9 +33,14 @@ func NewRuleEngineConsumer(brokers []string, groupID, topic string, uc *usecases
 		CommitInterval: 0,    // ручной коммит
 	}
 	reader := kafka.NewReader(readerConfig)
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
 	return &RuleEngineConsumer{
 		reader:  reader,
 		useCase: uc,
+		dlq:     dlq,
+		retry:   retry,
 		logger:  logger,
 	}
 }
@@ -39,25 +49,19 @@ func NewRuleEngineConsumer(brokers []string, groupID, topic string, uc *usecases
 func (rec *RuleEngineConsumer) Run(ctx context.Context) error {
 	rec.logger.Info().Msg("Started resources worker")
 	for {
-		m, err := rec.reader.ReadMessage(ctx)
+		m, err := rec.reader.FetchMessage(ctx)
 		if err != nil {
 			rec.logger.Error().Err(err).Msg("Error reading Kafka message")
 			return err
 		}
-		var evt domain.Event
-		if err := json.Unmarshal(m.Value, &evt); err != nil {
-			rec.logger.Warn().Err(err).Msg("Failed to unmarshal event from Kafka")
-			continue
-		}
-		rec.logger.Debug().Msgf("Received Event: service=%s environment=%s level=%s", evt.ServiceName, evt.Environment, evt.EventType)
 
-		// Обработка события и отправка сообщений в соответствующие топики
-		if err := rec.useCase.Evaluate(ctx, &evt); err != nil {
-			rec.logger.Error().Err(err).Msg("EvaluateRulesUseCase returned error")
-			continue
+		if err := rec.handle(ctx, m); err != nil {
+			// Контекст отменён до того, как сообщение обработано или попало в DLQ:
+			// не коммитим, после рестарта сообщение будет прочитано снова
+			return err
 		}
 
-		// После успешной отправки сообщений выполняем ручной коммит
+		// После успешной обработки (или переноса в DLQ) выполняем ручной коммит
 		if err := rec.reader.CommitMessages(ctx, m); err != nil {
 			rec.logger.Error().Err(err).Msg("Failed to commit message")
 		} else {
@@ -66,6 +70,71 @@ func (rec *RuleEngineConsumer) Run(ctx context.Context) error {
 	}
 }
 
+// handle обрабатывает сообщение с повторами. Ошибка разбора JSON не повторяется:
+// такое сообщение сразу уходит в DLQ. Возвращает ошибку только при отмене ctx.
+//
+// Правила проверяются один раз: Evaluate меняет состояние в Redis (cooldown, состояние алертов,
+// окна), и повторная проверка увидела бы алерт уже подавленным. Повторяется только доставка
+// (Deliver) с уже посчитанным результатом; Evaluate повторяется, только пока не загрузились правила.
+func (rec *RuleEngineConsumer) handle(ctx context.Context, m kafka.Message) error {
+	var evt domain.Event
+	if err := json.Unmarshal(m.Value, &evt); err != nil {
+		rec.logger.Warn().Err(err).Msg("Failed to unmarshal event from Kafka")
+		return rec.deadLetter(ctx, m, DLQStageDecode, 1, err)
+	}
+	rec.logger.Debug().Msgf("Received Event: service=%s environment=%s level=%s", evt.ServiceName, evt.Environment, evt.EventType)
+
+	var ev *usecases.Evaluation
+	var lastErr error
+	for attempt := 1; attempt <= rec.retry.MaxAttempts; attempt++ {
+		if ev == nil {
+			ev, lastErr = rec.useCase.Evaluate(ctx, &evt)
+		}
+		// Отправка сообщений в соответствующие топики и запись лога
+		if ev != nil {
+			lastErr = rec.useCase.Deliver(ctx, ev)
+		}
+		if lastErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rec.logger.Error().Err(lastErr).Msgf("EvaluateRulesUseCase returned error (attempt %d/%d)", attempt, rec.retry.MaxAttempts)
+		if attempt < rec.retry.MaxAttempts && !sleep(ctx, rec.retry.backoff(attempt)) {
+			return ctx.Err()
+		}
+	}
+	stage := DLQStageEvaluate
+	if ev != nil {
+		stage = DLQStageDeliver
+		// Алерт не отправлен: занятые им окна cooldown не должны подавлять следующие
+		rec.useCase.Release(ctx, ev)
+	}
+	return rec.deadLetter(ctx, m, stage, rec.retry.MaxAttempts, lastErr)
+}
+
+// deadLetter пишет сообщение в DLQ. Пока DLQ недоступен, запись повторяется с backoff:
+// коммитить сообщение, которое не попало ни в обработку, ни в DLQ, нельзя.
+func (rec *RuleEngineConsumer) deadLetter(ctx context.Context, m kafka.Message, stage string, attempts int, cause error) error {
+	if rec.dlq == nil {
+		rec.logger.Error().Err(cause).Msgf("DLQ is not configured, dropping message %s/%d/%d", m.Topic, m.Partition, m.Offset)
+		return nil
+	}
+	for i := 1; ; i++ {
+		err := rec.dlq.Publish(ctx, m, stage, attempts, cause)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("publish to DLQ: %w", ctx.Err())
+		}
+		if !sleep(ctx, rec.retry.backoff(i)) {
+			return fmt.Errorf("publish to DLQ: %w", ctx.Err())
+		}
+	}
+}
+
 // Close закрывает потребителя.
 func (rec *RuleEngineConsumer) Close() error {
 	return rec.reader.Close()
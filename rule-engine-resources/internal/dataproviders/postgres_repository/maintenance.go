@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"rule-engine-resources/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// PostgresMaintenanceRepository читает окна обслуживания проектов, которые создаются в public API.
+type PostgresMaintenanceRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// NewPostgresMaintenanceRepository возвращает репозиторий окон обслуживания на общем пуле соединений db.
+func NewPostgresMaintenanceRepository(logger *zerolog.Logger, db *sqlx.DB) *PostgresMaintenanceRepository {
+	return &PostgresMaintenanceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetMaintenanceWindows возвращает окна обслуживания проекта, которые ещё могут начаться:
+// повторяющиеся и разовые, которые не закончились.
+func (mr *PostgresMaintenanceRepository) GetMaintenanceWindows(ctx context.Context, projectID string) ([]domain.MaintenanceWindow, error) {
+	query := `
+		SELECT w.id, w.name, w.starts_at, w.ends_at, w.schedule
+		FROM rule_engine.maintenance_windows w
+		WHERE w.project_id::text = $1 AND (w.schedule IS NOT NULL OR w.ends_at > now());
+	`
+	rows, err := mr.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		mr.logger.Error().Err(err).Msg("Failed to fetch maintenance windows")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var windows []domain.MaintenanceWindow
+	for rows.Next() {
+		var (
+			id          int
+			w           domain.MaintenanceWindow
+			scheduleRaw []byte
+		)
+		if err := rows.Scan(&id, &w.Name, &w.StartsAt, &w.EndsAt, &scheduleRaw); err != nil {
+			mr.logger.Warn().Err(err).Msg("Failed to scan maintenance window row")
+			continue
+		}
+		if len(scheduleRaw) > 0 {
+			if err := json.Unmarshal(scheduleRaw, &w.Schedule); err != nil {
+				mr.logger.Warn().Err(err).Msgf("Failed to unmarshal schedule of maintenance window %d", id)
+				continue
+			}
+		}
+		w.ID = strconv.Itoa(id)
+		windows = append(windows, w)
+	}
+	return windows, rows.Err()
+}
@@ -18,20 +18,11 @@ type PostgresRuleRepository struct {
 	logger *zerolog.Logger
 }
 
-// NewPostgresRuleRepository создаёт и инициализирует подключение к PostgreSQL
-// и возвращает репозиторий, реализующий usecases.RuleRepository.
-func NewPostgresRuleRepository(logger *zerolog.Logger, cfg *config.Config) (usecases.RuleRepository, error) {
-	user := cfg.Postgres.User
-	pass := cfg.Postgres.Password
-	host := cfg.Postgres.Host
-	dbName := cfg.Postgres.DBName
-	port := cfg.Postgres.Port
-	if port == 0 {
-		port = 5432
-	}
-
+// NewPostgresDB создаёт и инициализирует подключение к PostgreSQL. Пул один на процесс
+// и передаётся во все репозитории; LISTEN/NOTIFY держит собственное соединение.
+func NewPostgresDB(logger *zerolog.Logger, cfg *config.Config) (*sqlx.DB, error) {
 	// Формируем DSN
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", user, pass, host, port, dbName)
+	dsn := makeDSN(cfg)
 
 	// Подключаемся через sqlx
 	db, err := sqlx.Connect("postgres", dsn)
@@ -39,6 +30,9 @@ func NewPostgresRuleRepository(logger *zerolog.Logger, cfg *config.Config) (usec
 		logger.Error().Err(err).Msg("Failed to connect to Postgres")
 		return nil, err
 	}
+	db.SetMaxOpenConns(cfg.Postgres.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.Postgres.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.Postgres.ConnMaxLifetime)
 
 	// Проверяем соединение
 	if err := db.Ping(); err != nil {
@@ -47,10 +41,25 @@ func NewPostgresRuleRepository(logger *zerolog.Logger, cfg *config.Config) (usec
 	}
 
 	logger.Info().Msgf("Connected to Postgres successfully %v", dsn)
+	return db, nil
+}
+
+// NewPostgresRuleRepository возвращает репозиторий, реализующий usecases.RuleRepository.
+func NewPostgresRuleRepository(logger *zerolog.Logger, db *sqlx.DB) usecases.RuleRepository {
 	return &PostgresRuleRepository{
 		db:     db,
 		logger: logger,
-	}, nil
+	}
+}
+
+// makeDSN формирует строку подключения к Postgres из конфигурации.
+func makeDSN(cfg *config.Config) string {
+	port := cfg.Postgres.Port
+	if port == 0 {
+		port = 5432
+	}
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		cfg.Postgres.User, cfg.Postgres.Password, cfg.Postgres.Host, port, cfg.Postgres.DBName)
 }
 
 // GetRulesByUserAndServiceAndProjectId возвращает error-правила для заданного userID, serviceName и projectId.
@@ -78,7 +87,7 @@ func (pr *PostgresRuleRepository) GetRulesByUserAndServiceAndProjectId(
 
 	// Запрос для получения error-правил из таблицы error_rules, с join по services для фильтрации по service_name и project_id
 	query := `
-		SELECT r.id, r.name, r.actions, r.root_node, r.user_id, s.service_name
+		SELECT r.id, r.name, r.actions, r.root_node, r.user_id, s.service_name, r.cooldown_sec, r.dedup_key, r.for_samples, r.for_minutes, r.schedule
 		FROM rule_engine.resource_rules r
 		JOIN rule_engine.services s ON r.service_id = s.id
 		WHERE r.user_id = $1 AND s.service_name = $2 AND s.project_id = $3;
@@ -99,8 +108,13 @@ func (pr *PostgresRuleRepository) GetRulesByUserAndServiceAndProjectId(
 			rootNodeRaw       []byte
 			userIdFromDB      int
 			serviceNameFromDB string
+			cooldownSec       int
+			dedupKey          string
+			forSamples        int
+			forMinutes        int
+			scheduleRaw       []byte
 		)
-		if err := rows.Scan(&id, &name, &actionsRaw, &rootNodeRaw, &userIdFromDB, &serviceNameFromDB); err != nil {
+		if err := rows.Scan(&id, &name, &actionsRaw, &rootNodeRaw, &userIdFromDB, &serviceNameFromDB, &cooldownSec, &dedupKey, &forSamples, &forMinutes, &scheduleRaw); err != nil {
 			pr.logger.Warn().Err(err).Msg("Failed to scan rule row")
 			continue
 		}
@@ -118,6 +132,15 @@ func (pr *PostgresRuleRepository) GetRulesByUserAndServiceAndProjectId(
 			continue
 		}
 
+		// schedule – NULL, если правило активно всегда
+		var schedule *domain.Schedule
+		if len(scheduleRaw) > 0 {
+			if err := json.Unmarshal(scheduleRaw, &schedule); err != nil {
+				pr.logger.Warn().Err(err).Msg("Failed to unmarshal schedule JSON")
+				continue
+			}
+		}
+
 		rule := domain.Rule{
 			ID:          strconv.Itoa(id),
 			UserID:      userIdFromDB,
@@ -126,6 +149,11 @@ func (pr *PostgresRuleRepository) GetRulesByUserAndServiceAndProjectId(
 			Actions:     actions,
 			RootNode:    rootNode,
 			Conditions:  rootNode.Conditions, // при необходимости
+			CooldownSec: cooldownSec,
+			DedupKey:    dedupKey,
+			ForSamples:  forSamples,
+			ForMinutes:  forMinutes,
+			Schedule:    schedule,
 		}
 		rules = append(rules, rule)
 	}
@@ -0,0 +1,177 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// alertStateTTL – сколько хранится состояние, если от экземпляра перестали приходить метрики.
+const alertStateTTL = 24 * time.Hour
+
+// alertStateScript атомарно применяет результат проверки правила к состоянию алерта.
+// KEYS[1] – ключ состояния (hash: state, count, since, notified)
+// ARGV[1] – "1" если условие выполнено, ARGV[2] – текущее время (ms),
+// ARGV[3] – for_samples, ARGV[4] – for (ms), ARGV[5] – TTL (ms)
+// Возвращает {переход, уведомление}: новое состояние, если был переход, и состояние (firing / resolved),
+// о котором нужно уведомить, пока уведомление не отмечено через alertNotifiedScript; иначе пустые строки.
+// Состояние resolved хранится, только пока не отправлено уведомление о восстановлении.
+var alertStateScript = redis.NewScript(`
+local key = KEYS[1]
+local matched = ARGV[1] == '1'
+local now = tonumber(ARGV[2])
+local forSamples = tonumber(ARGV[3])
+local forMs = tonumber(ARGV[4])
+local ttl = tonumber(ARGV[5])
+
+local h = redis.call('HMGET', key, 'state', 'notified')
+local state = h[1]
+local notified = h[2] == '1'
+
+if not matched then
+	if state == 'firing' then
+		if notified then
+			redis.call('HSET', key, 'state', 'resolved')
+			redis.call('PEXPIRE', key, ttl)
+			return {'resolved', 'resolved'}
+		end
+		-- о firing никто не узнал – и о восстановлении сообщать не нужно
+		redis.call('DEL', key)
+		return {'resolved', ''}
+	end
+	if state == 'resolved' then
+		redis.call('PEXPIRE', key, ttl)
+		return {'', 'resolved'}
+	end
+	if state == 'pending' then
+		redis.call('DEL', key)
+		return {'inactive', ''}
+	end
+	return {'', ''}
+end
+
+if state == 'firing' then
+	redis.call('PEXPIRE', key, ttl)
+	if notified then
+		return {'', ''}
+	end
+	return {'', 'firing'}
+end
+if state == 'resolved' then
+	-- восстановление ещё не отправлено, а условие снова выполнено: для получателей алерт не прекращался
+	redis.call('HSET', key, 'state', 'firing')
+	redis.call('PEXPIRE', key, ttl)
+	return {'firing', ''}
+end
+
+local count, since
+if state == 'pending' then
+	count = redis.call('HINCRBY', key, 'count', 1)
+	since = tonumber(redis.call('HGET', key, 'since'))
+else
+	count = 1
+	since = now
+	redis.call('HSET', key, 'state', 'pending', 'count', 1, 'since', now)
+end
+redis.call('PEXPIRE', key, ttl)
+
+if count >= forSamples and now - since >= forMs then
+	redis.call('HSET', key, 'state', 'firing', 'notified', '0')
+	return {'firing', 'firing'}
+end
+if state == 'pending' then
+	return {'', ''}
+end
+return {'pending', ''}
+`)
+
+// alertNotifiedScript отмечает, что уведомление о состоянии ARGV[1] отправлено.
+// Если состояние за это время изменилось, ничего не делает. Отправленный resolved удаляет ключ.
+var alertNotifiedScript = redis.NewScript(`
+local key = KEYS[1]
+local state = redis.call('HGET', key, 'state')
+if state ~= ARGV[1] then
+	return 0
+end
+if state == 'resolved' then
+	redis.call('DEL', key)
+else
+	redis.call('HSET', key, 'notified', '1')
+end
+return 1
+`)
+
+// RedisAlertStateStore хранит состояние алертов ресурсных правил
+// по (rule, service, environment, instance): pending -> firing -> resolved.
+// Для fleet-правил состояние одно на (rule, service, environment).
+type RedisAlertStateStore struct {
+	rdb    *redis.Client
+	logger *zerolog.Logger
+}
+
+func NewRedisAlertStateStore(rdb *redis.Client, logger *zerolog.Logger) *RedisAlertStateStore {
+	return &RedisAlertStateStore{
+		rdb:    rdb,
+		logger: logger,
+	}
+}
+
+// Transition применяет результат проверки правила r к событию e и возвращает переход состояния
+// (пустой – состояние не изменилось) и состояние, о котором нужно уведомить (пустое – не нужно).
+// Условие считается устойчивым, когда выполнено r.ForSamples сэмплов подряд и держится r.ForMinutes минут.
+// Уведомление о firing / resolved возвращается на каждом сэмпле, пока его не отметят через MarkNotified:
+// алерт, подавленный окном обслуживания или silence, отправится после их окончания.
+func (s *RedisAlertStateStore) Transition(ctx context.Context, e *domain.Event, r domain.Rule, matched bool) (transition, notify domain.AlertState, err error) {
+	key := s.makeKey(e, r.ID, r.AlertInstance(e))
+
+	matchedArg := "0"
+	if matched {
+		matchedArg = "1"
+	}
+	forSamples := r.ForSamples
+	if forSamples < 1 {
+		forSamples = 1
+	}
+	forDuration := time.Duration(r.ForMinutes) * time.Minute
+
+	res, err := alertStateScript.Run(ctx, s.rdb, []string{key},
+		matchedArg,
+		time.Now().UnixMilli(),
+		forSamples,
+		forDuration.Milliseconds(),
+		alertStateTTL.Milliseconds(),
+	).StringSlice()
+	if err != nil {
+		s.logger.Error().Err(err).Msgf("Failed to run alert state script for key=%s", key)
+		return "", "", err
+	}
+	if len(res) != 2 {
+		return "", "", fmt.Errorf("unexpected alert state script result: %v", res)
+	}
+
+	if res[0] != "" {
+		s.logger.Debug().Msgf("Alert state transition for key=%s => %s", key, res[0])
+	}
+	return domain.AlertState(res[0]), domain.AlertState(res[1]), nil
+}
+
+// MarkNotified отмечает, что уведомление о состоянии state (firing / resolved) алерта отправлено.
+func (s *RedisAlertStateStore) MarkNotified(ctx context.Context, e *domain.Event, r domain.Rule, state domain.AlertState) error {
+	key := s.makeKey(e, r.ID, r.AlertInstance(e))
+	if err := alertNotifiedScript.Run(ctx, s.rdb, []string{key}, string(state)).Err(); err != nil {
+		s.logger.Error().Err(err).Msgf("Failed to mark alert %s notified for key=%s", state, key)
+		return err
+	}
+	return nil
+}
+
+// makeKey
+// alert-state:rule_id:service_name:environment:instance (у fleet-правил instance пустой)
+func (s *RedisAlertStateStore) makeKey(e *domain.Event, ruleID, instance string) string {
+	return fmt.Sprintf("alert-state:%s:%s:%s:%s", ruleID, e.ServiceName, e.Environment, instance)
+}
@@ -0,0 +1,114 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// anomalyBaselineTTL – сколько хранится базовая линия метрики, от которой перестали приходить сэмплы.
+const anomalyBaselineTTL = 7 * 24 * time.Hour
+
+// anomalyBaselineScript атомарно возвращает базовую линию до текущего сэмпла и обновляет
+// экспоненциально взвешенные среднее и дисперсию.
+// KEYS[1] – ключ (hash: mean, var, n, last_id, last_mean, last_var, last_n)
+// ARGV[1] – значение, ARGV[2] – alpha, ARGV[3] – id сэмпла, ARGV[4] – TTL (ms)
+// Возвращает {mean, var, n} базовой линии до сэмпла (строками). Повторный вызов с тем же id сэмпла
+// (несколько условий на одну метрику) состояние не меняет и возвращает ту же базовую линию.
+var anomalyBaselineScript = redis.NewScript(`
+local key = KEYS[1]
+local x = tonumber(ARGV[1])
+local alpha = tonumber(ARGV[2])
+local id = ARGV[3]
+
+local h = redis.call('HMGET', key, 'mean', 'var', 'n', 'last_id', 'last_mean', 'last_var', 'last_n')
+if h[4] == id then
+	return {h[5], h[6], h[7]}
+end
+
+local mean = tonumber(h[1]) or 0
+local var = tonumber(h[2]) or 0
+local n = tonumber(h[3]) or 0
+
+local newMean, newVar
+if n == 0 then
+	newMean = x
+	newVar = 0
+else
+	local diff = x - mean
+	local incr = alpha * diff
+	newMean = mean + incr
+	newVar = (1 - alpha) * (var + diff * incr)
+end
+
+redis.call('HSET', key,
+	'mean', tostring(newMean), 'var', tostring(newVar), 'n', n + 1,
+	'last_id', id, 'last_mean', tostring(mean), 'last_var', tostring(var), 'last_n', n)
+redis.call('PEXPIRE', key, ARGV[4])
+
+return {tostring(mean), tostring(var), tostring(n)}
+`)
+
+// RedisAnomalyBaseline хранит экспоненциально взвешенные среднее и дисперсию метрики
+// по (project, service, environment, metric) для оператора anomaly.
+type RedisAnomalyBaseline struct {
+	rdb    *redis.Client
+	logger *zerolog.Logger
+}
+
+func NewRedisAnomalyBaseline(rdb *redis.Client, logger *zerolog.Logger) *RedisAnomalyBaseline {
+	return &RedisAnomalyBaseline{
+		rdb:    rdb,
+		logger: logger,
+	}
+}
+
+// Observe учитывает значение метрики из события e и возвращает базовую линию до него,
+// чтобы сэмпл сравнивался с «нормой», на которую сам ещё не повлиял.
+// alpha – вес нового сэмпла (0..1]; условия с разным alpha ведут разные базовые линии.
+func (b *RedisAnomalyBaseline) Observe(ctx context.Context, e *domain.Event, metric string, value, alpha float64) (domain.Baseline, error) {
+	key := b.makeKey(e, metric, alpha)
+	sampleID := fmt.Sprintf("%d|%s", e.SampleTime().UnixMilli(), e.InstanceID())
+
+	res, err := anomalyBaselineScript.Run(ctx, b.rdb, []string{key},
+		strconv.FormatFloat(value, 'g', -1, 64),
+		strconv.FormatFloat(alpha, 'g', -1, 64),
+		sampleID,
+		anomalyBaselineTTL.Milliseconds(),
+	).StringSlice()
+	if err != nil {
+		b.logger.Error().Err(err).Msgf("Failed to run anomaly baseline script for key=%s", key)
+		return domain.Baseline{}, err
+	}
+	if len(res) != 3 {
+		return domain.Baseline{}, fmt.Errorf("unexpected anomaly baseline script result: %v", res)
+	}
+
+	mean, err1 := strconv.ParseFloat(res[0], 64)
+	variance, err2 := strconv.ParseFloat(res[1], 64)
+	n, err3 := strconv.ParseFloat(res[2], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return domain.Baseline{}, fmt.Errorf("malformed anomaly baseline for key=%s: %v", key, res)
+	}
+
+	baseline := domain.Baseline{
+		Mean:    mean,
+		StdDev:  math.Sqrt(math.Max(variance, 0)),
+		Samples: int(n),
+	}
+	b.logger.Debug().Msgf("Anomaly baseline for key=%s: mean=%g std=%g n=%d", key, baseline.Mean, baseline.StdDev, baseline.Samples)
+	return baseline, nil
+}
+
+// makeKey
+// anomaly:user_id:project_id:service_name:environment:metric:alpha
+func (b *RedisAnomalyBaseline) makeKey(e *domain.Event, metric string, alpha float64) string {
+	return fmt.Sprintf("anomaly:%s:%s:%s:%s:%s:%g", e.UserID, e.ProjectId, e.ServiceName, e.Environment, metric, alpha)
+}
@@ -0,0 +1,112 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// fleetListScript атомарно удаляет экземпляры, от которых давно не было сэмплов,
+// и возвращает живые экземпляры.
+// KEYS[1] – ключ флота (hash: instance -> "at_ms|value")
+// ARGV[1] – текущее время (ms), ARGV[2] – время жизни экземпляра (ms)
+// Возвращает плоский список {instance, "at_ms|value", ...}.
+var fleetListScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local ttl = tonumber(ARGV[2])
+
+local all = redis.call('HGETALL', key)
+local live = {}
+for i = 1, #all, 2 do
+	local at = tonumber(string.match(all[i + 1], '^(%d+)|'))
+	if at and at >= now - ttl then
+		table.insert(live, all[i])
+		table.insert(live, all[i + 1])
+	else
+		redis.call('HDEL', key, all[i])
+	end
+end
+
+return live
+`)
+
+// RedisFleetState хранит последний сэмпл метрики каждого экземпляра сервиса
+// по (service, environment, metric) для fleet-условий (count_where, ratio_where, avg_across).
+// Экземпляр без сэмплов дольше instanceTTLSec считается выключенным.
+// Сэмплы пишутся отдельно от чтения: каждое событие обновляет экземпляр, даже если условие не проверялось.
+type RedisFleetState struct {
+	rdb            *redis.Client
+	instanceTTLSec int
+	logger         *zerolog.Logger
+}
+
+func NewRedisFleetState(rdb *redis.Client, instanceTTLSec int, logger *zerolog.Logger) *RedisFleetState {
+	return &RedisFleetState{
+		rdb:            rdb,
+		instanceTTLSec: instanceTTLSec,
+		logger:         logger,
+	}
+}
+
+// Update записывает значение метрики экземпляра e.InstanceID() как его последний сэмпл.
+func (f *RedisFleetState) Update(ctx context.Context, e *domain.Event, metric string, value float64) error {
+	key := f.makeKey(e, metric)
+	ttl := time.Duration(f.instanceTTLSec) * time.Second
+	sample := fmt.Sprintf("%d|%s", e.SampleTime().UnixMilli(), strconv.FormatFloat(value, 'g', -1, 64))
+
+	pipe := f.rdb.TxPipeline()
+	pipe.HSet(ctx, key, e.InstanceID(), sample)
+	pipe.PExpire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		f.logger.Error().Err(err).Msgf("Failed to update fleet state key=%s", key)
+		return err
+	}
+	return nil
+}
+
+// List возвращает последние значения всех живых экземпляров сервиса.
+func (f *RedisFleetState) List(ctx context.Context, e *domain.Event, metric string) ([]domain.InstanceSample, error) {
+	key := f.makeKey(e, metric)
+	ttl := time.Duration(f.instanceTTLSec) * time.Second
+
+	res, err := fleetListScript.Run(ctx, f.rdb, []string{key},
+		time.Now().UnixMilli(),
+		ttl.Milliseconds(),
+	).StringSlice()
+	if err != nil {
+		f.logger.Error().Err(err).Msgf("Failed to run fleet state script for key=%s", key)
+		return nil, err
+	}
+
+	samples := make([]domain.InstanceSample, 0, len(res)/2)
+	for i := 0; i+1 < len(res); i += 2 {
+		atStr, valStr, ok := strings.Cut(res[i+1], "|")
+		if !ok {
+			continue
+		}
+		atMs, err1 := strconv.ParseInt(atStr, 10, 64)
+		v, err2 := strconv.ParseFloat(valStr, 64)
+		if err1 != nil || err2 != nil {
+			f.logger.Warn().Msgf("Skipping malformed fleet sample %q for instance %q in key=%s", res[i+1], res[i], key)
+			continue
+		}
+		samples = append(samples, domain.InstanceSample{Instance: res[i], At: time.UnixMilli(atMs), Value: v})
+	}
+
+	f.logger.Debug().Msgf("List for key=%s => %d live instances", key, len(samples))
+	return samples, nil
+}
+
+// makeKey
+// fleet:user_id:project_id:service_name:environment:metric
+func (f *RedisFleetState) makeKey(e *domain.Event, metric string) string {
+	return fmt.Sprintf("fleet:%s:%s:%s:%s:%s", e.UserID, e.ProjectId, e.ServiceName, e.Environment, metric)
+}
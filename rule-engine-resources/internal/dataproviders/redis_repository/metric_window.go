@@ -0,0 +1,163 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// metricWindowMaxSamples – сколько сэмплов максимум хранится в одном окне (защита от шумных сервисов).
+const metricWindowMaxSamples = 10000
+
+// metricWindowScript атомарно добавляет сэмпл в ZSet окна и удаляет устаревшие.
+// KEYS[1] – ключ окна; ARGV[1] – время сэмпла (ms), ARGV[2] – member, ARGV[3] – текущее время (ms),
+// ARGV[4] – хранение (ms), ARGV[5] – максимум сэмплов
+var metricWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local retention = tonumber(ARGV[4])
+local maxLen = tonumber(ARGV[5])
+
+redis.call('ZADD', key, tonumber(ARGV[1]), ARGV[2])
+redis.call('ZREMRANGEBYSCORE', key, '-inf', tonumber(ARGV[3]) - retention)
+local n = redis.call('ZCARD', key)
+if n > maxLen then
+	redis.call('ZREMRANGEBYRANK', key, 0, n - maxLen - 1)
+end
+redis.call('PEXPIRE', key, retention)
+return n
+`)
+
+// RedisMetricWindow хранит скользящее окно значений метрики по (service, environment, metric)
+// для оконных операторов (avg_over, max_over, ...), а также отдельное окно на каждый экземпляр
+// сервиса для операторов роста (rate_over, increasing_for, predict_breach).
+// Сэмплы пишутся отдельно от чтения: каждое событие попадает в окно, даже если условие не проверялось.
+type RedisMetricWindow struct {
+	rdb          *redis.Client
+	retentionSec int
+	logger       *zerolog.Logger
+}
+
+func NewRedisMetricWindow(rdb *redis.Client, retentionSec int, logger *zerolog.Logger) *RedisMetricWindow {
+	return &RedisMetricWindow{
+		rdb:          rdb,
+		retentionSec: retentionSec,
+		logger:       logger,
+	}
+}
+
+// Add добавляет значение метрики из события e в окно (service, environment, metric).
+// Сэмпл идентифицируется временем события и экземпляром, поэтому повторная запись того же события
+// окно не искажает. Окно хранится не дольше retentionSec.
+func (w *RedisMetricWindow) Add(ctx context.Context, e *domain.Event, metric string, value float64) error {
+	return w.add(ctx, w.makeKey(e, metric), e, metric, value)
+}
+
+// AddByInstance – то же, что Add, но окно ведётся отдельно для каждого экземпляра
+// сервиса (e.InstanceID()): рост памяти одного пода не размывается сэмплами остальных.
+func (w *RedisMetricWindow) AddByInstance(ctx context.Context, e *domain.Event, metric string, value float64) error {
+	return w.add(ctx, w.instanceKey(e, metric), e, metric, value)
+}
+
+// Range возвращает сэмплы окна (service, environment, metric) за последние window.
+func (w *RedisMetricWindow) Range(ctx context.Context, e *domain.Event, metric string, window time.Duration) ([]domain.MetricSample, error) {
+	return w.rangeWindow(ctx, w.makeKey(e, metric), metric, window)
+}
+
+// RangeByInstance возвращает сэмплы окна экземпляра e.InstanceID() за последние window.
+func (w *RedisMetricWindow) RangeByInstance(ctx context.Context, e *domain.Event, metric string, window time.Duration) ([]domain.MetricSample, error) {
+	return w.rangeWindow(ctx, w.instanceKey(e, metric), metric, window)
+}
+
+// MaxWindow – на сколько назад хранятся сэмплы.
+func (w *RedisMetricWindow) MaxWindow() time.Duration {
+	return time.Duration(w.retentionSec) * time.Second
+}
+
+func (w *RedisMetricWindow) add(ctx context.Context, key string, e *domain.Event, metric string, value float64) error {
+	at := e.SampleTime()
+	member := fmt.Sprintf("%d|%s|%s", at.UnixMilli(), e.InstanceID(), strconv.FormatFloat(value, 'g', -1, 64))
+
+	err := metricWindowScript.Run(ctx, w.rdb, []string{key},
+		at.UnixMilli(),
+		member,
+		time.Now().UnixMilli(),
+		w.MaxWindow().Milliseconds(),
+		metricWindowMaxSamples,
+	).Err()
+	if err != nil {
+		w.logger.Error().Err(err).Msgf("Failed to run metric window script for key=%s", key)
+		return err
+	}
+	w.logger.Debug().Msgf("Added sample to metric window key=%s, metric=%s", key, metric)
+	return nil
+}
+
+func (w *RedisMetricWindow) rangeWindow(ctx context.Context, key, metric string, window time.Duration) ([]domain.MetricSample, error) {
+	retention := w.MaxWindow()
+	if window > retention {
+		w.logger.Warn().Msgf("metric window %s exceeds max %s for metric=%s, clamping", window, retention, metric)
+		window = retention
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("metric window must be positive, got %s", window)
+	}
+
+	res, err := w.rdb.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: strconv.FormatInt(time.Now().Add(-window).UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		w.logger.Error().Err(err).Msgf("Failed to read metric window key=%s", key)
+		return nil, err
+	}
+
+	samples := make([]domain.MetricSample, 0, len(res))
+	for _, m := range res {
+		s, ok := parseSampleMember(m)
+		if !ok {
+			w.logger.Warn().Msgf("Skipping malformed metric sample %q in key=%s", m, key)
+			continue
+		}
+		samples = append(samples, s)
+	}
+
+	w.logger.Debug().Msgf("Range for key=%s, window=%s => %d samples", key, window, len(samples))
+	return samples, nil
+}
+
+// parseSampleMember разбирает member "at_ms|instance|value".
+func parseSampleMember(m string) (domain.MetricSample, bool) {
+	first := strings.IndexByte(m, '|')
+	last := strings.LastIndexByte(m, '|')
+	if first < 0 || last == first {
+		return domain.MetricSample{}, false
+	}
+	at, err := strconv.ParseInt(m[:first], 10, 64)
+	if err != nil {
+		return domain.MetricSample{}, false
+	}
+	v, err := strconv.ParseFloat(m[last+1:], 64)
+	if err != nil {
+		return domain.MetricSample{}, false
+	}
+	return domain.MetricSample{At: time.UnixMilli(at), Value: v}, true
+}
+
+// makeKey
+// metric-window:user_id:project_id:service_name:environment:metric
+func (w *RedisMetricWindow) makeKey(e *domain.Event, metric string) string {
+	return fmt.Sprintf("metric-window:%s:%s:%s:%s:%s", e.UserID, e.ProjectId, e.ServiceName, e.Environment, metric)
+}
+
+// instanceKey
+// metric-window:user_id:project_id:service_name:environment:metric:instance
+func (w *RedisMetricWindow) instanceKey(e *domain.Event, metric string) string {
+	return w.makeKey(e, metric) + ":" + e.InstanceID()
+}
@@ -0,0 +1,41 @@
+package redis_repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSampleMember(t *testing.T) {
+	tests := []struct {
+		name      string
+		member    string
+		wantOK    bool
+		wantAt    int64
+		wantValue float64
+	}{
+		{name: "plain", member: "1700000000000|host-1|42.5", wantOK: true, wantAt: 1700000000000, wantValue: 42.5},
+		{name: "empty instance", member: "1700000000000||0", wantOK: true, wantAt: 1700000000000, wantValue: 0},
+		{name: "instance with separator", member: "1700000000000|pod|a|1e3", wantOK: true, wantAt: 1700000000000, wantValue: 1000},
+		{name: "negative value", member: "1700000000000|host-1|-3", wantOK: true, wantAt: 1700000000000, wantValue: -3},
+		{name: "no separators", member: "1700000000000", wantOK: false},
+		{name: "single separator", member: "1700000000000|42", wantOK: false},
+		{name: "bad timestamp", member: "now|host-1|42", wantOK: false},
+		{name: "bad value", member: "1700000000000|host-1|high", wantOK: false},
+		{name: "empty", member: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := parseSampleMember(tt.member)
+			if ok != tt.wantOK {
+				t.Fatalf("parseSampleMember(%q) ok = %v, want %v", tt.member, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !s.At.Equal(time.UnixMilli(tt.wantAt)) || s.Value != tt.wantValue {
+				t.Errorf("parseSampleMember(%q) = {%s, %v}, want {%s, %v}",
+					tt.member, s.At, s.Value, time.UnixMilli(tt.wantAt), tt.wantValue)
+			}
+		})
+	}
+}
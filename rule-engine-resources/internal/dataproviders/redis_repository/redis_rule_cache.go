@@ -66,6 +66,36 @@ func (rc *RedisCache) SetRules(ctx context.Context, userID, serviceName, project
 	return nil
 }
 
+// InvalidateUser удаляет из кеша все правила пользователя userID (по всем сервисам и проектам).
+func (rc *RedisCache) InvalidateUser(ctx context.Context, userID string) (int, error) {
+	return rc.deleteByPattern(ctx, fmt.Sprintf("rules-resources:%s:*", userID))
+}
+
+// InvalidateAll удаляет из кеша правила всех пользователей.
+// Нужен, когда уведомления об изменениях могли потеряться (например, при переподключении к Postgres).
+func (rc *RedisCache) InvalidateAll(ctx context.Context) (int, error) {
+	return rc.deleteByPattern(ctx, "rules-resources:*")
+}
+
+// deleteByPattern удаляет ключи по шаблону через SCAN, не блокируя Redis как KEYS.
+func (rc *RedisCache) deleteByPattern(ctx context.Context, pattern string) (int, error) {
+	deleted := 0
+	iter := rc.rdb.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		if err := rc.rdb.Del(ctx, iter.Val()).Err(); err != nil {
+			rc.logger.Error().Err(err).Msgf("Failed to delete cache key %s", iter.Val())
+			return deleted, err
+		}
+		deleted++
+	}
+	if err := iter.Err(); err != nil {
+		rc.logger.Error().Err(err).Msgf("Failed to scan cache keys by pattern %s", pattern)
+		return deleted, err
+	}
+	rc.logger.Debug().Msgf("Cache invalidated: %d keys by pattern %s", deleted, pattern)
+	return deleted, nil
+}
+
 // makeKey формирует ключ кеша для комбинации userID и serviceName.
 func (rc *RedisCache) makeKey(userID, serviceName, projectId string) string {
 	return fmt.Sprintf("rules-resources:%s:%s:%s", userID, serviceName, projectId)
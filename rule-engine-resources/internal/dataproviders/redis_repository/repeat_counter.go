@@ -2,8 +2,10 @@ package redis_repository
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
-	"strconv"
+	"math/rand"
 	"time"
 
 	"rule-engine-resources/internal/domain"
@@ -12,6 +14,21 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// slidingWindowScript атомарно удаляет из ZSet события старше окна, добавляет текущее
+// и возвращает количество событий в окне.
+// KEYS[1] – ключ счётчика; ARGV[1] – текущее время (ms), ARGV[2] – окно (ms), ARGV[3] – member
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+redis.call('ZADD', key, now, ARGV[3])
+redis.call('PEXPIRE', key, window)
+
+return redis.call('ZCARD', key)
+`)
+
 type RedisRepeatCounter struct {
 	rdb              *redis.Client
 	maxTimeWindowSec int
@@ -26,69 +43,49 @@ func NewRedisRepeatCounter(rdb *redis.Client, maxSec int, logger *zerolog.Logger
 	}
 }
 
-// UpdateKey – добавляет событие в ZSet, чистит старое
-//func (rc *RedisRepeatCounter) UpdateKey(ctx context.Context, e *domain.Event) error {
-//	rc.logger.Debug().Msgf("UpdateKey in Redis for event: %s|%s|%s|%s",
-//		e.ServiceName, e.Environment, e.EventType, e.EventMessage)
-//	key := rc.makeKey(e)
-//	now := float64(time.Now().Unix())
-//	member := fmt.Sprintf("%f-%d", now, time.Now().UnixNano())
-//
-//	if err := rc.rdb.ZAdd(ctx, key, redis.Z{Score: now, Member: member}).Err(); err != nil {
-//		rc.logger.Error().Err(err).Msg("Failed ZAdd in Redis")
-//		return err
-//	}
-//	cutoff := now - float64(rc.maxTimeWindowSec)
-//	err := rc.rdb.ZRemRangeByScore(ctx, key, "0", fmt.Sprintf("(%f", cutoff)).Err()
-//	if err != nil {
-//		rc.logger.Error().Err(err).Msg("Failed ZRemRangeByScore in Redis")
-//	}
-//	return err
-//}
-
-//Есть ключ
-// 1) Проверяем есть ли ключ
-// a) Если нет то создаем со значением 1 и TTL min и возвращаем 1
-// b) Если есть то INC 1 и возвращаем значение
-
-// CountInWindow – zcount
-func (rc *RedisRepeatCounter) CountInWindow(ctx context.Context, e *domain.Event, r domain.Rule, minutes int) (int, error) {
-	key := rc.makeKey(e, r.ID)
-
-	result, err := rc.rdb.Exists(ctx, key).Result()
-	if err != nil {
-		rc.logger.Error().Err(err).Msg("Failed Check key Exists in Redis")
-		return 0, err
+// CountInWindow – регистрирует событие и возвращает число событий за последние minutes минут.
+// Окно скользящее: каждое событие хранится в ZSet со своим временем, старые записи удаляются.
+// Всё выполняется одним Lua-скриптом, поэтому несколько реплик не гоняются за один ключ.
+// Окно ограничено сверху maxTimeWindowSec. groupKey (из group_by) разбивает счётчик на группы.
+func (rc *RedisRepeatCounter) CountInWindow(ctx context.Context, e *domain.Event, r domain.Rule, minutes int, groupKey string) (int, error) {
+	window := time.Duration(minutes) * time.Minute
+	maxWindow := time.Duration(rc.maxTimeWindowSec) * time.Second
+	if window > maxWindow {
+		rc.logger.Warn().Msgf("repeat_over window %s exceeds max %s for rule=%s, clamping", window, maxWindow, r.ID)
+		window = maxWindow
 	}
-
-	if result == 0 {
-		rc.logger.Debug().Msgf("Key %s not exists, creating", key)
-		if err = rc.rdb.Set(ctx, key, 1, time.Duration(minutes)*time.Minute).Err(); err != nil {
-			rc.logger.Error().Err(err).Msg("Failed Set key in Redis")
-		}
-		return 1, nil
-	} else {
-		rc.logger.Debug().Msgf("Key %s exists, incrementing", key)
-		if err = rc.rdb.Incr(ctx, key).Err(); err != nil {
-			rc.logger.Error().Err(err).Msg("Failed Incr key in Redis")
-		}
+	if window <= 0 {
+		return 0, fmt.Errorf("repeat_over window must be positive, got %d minutes", minutes)
 	}
+	key := rc.makeKey(e, r.ID, window, groupKey)
 
-	cnt := rc.rdb.Get(ctx, key).Val()
+	now := time.Now()
+	// member должен быть уникальным даже для одновременных событий с разных реплик
+	member := fmt.Sprintf("%d-%d", now.UnixNano(), rand.Int63())
 
-	rc.logger.Debug().Msgf("CountInWindow for key=%s, minutes=%d", key, minutes)
-	cntInt, err := strconv.Atoi(cnt)
+	cnt, err := slidingWindowScript.Run(ctx, rc.rdb, []string{key},
+		now.UnixMilli(),
+		window.Milliseconds(),
+		member,
+	).Int()
 	if err != nil {
-		rc.logger.Error().Err(err).Msg("Failed to convert cnt to int")
+		rc.logger.Error().Err(err).Msgf("Failed to run sliding window script for key=%s", key)
 		return 0, err
 	}
 
-	return cntInt, nil
-
+	rc.logger.Debug().Msgf("CountInWindow for key=%s, minutes=%d => %d", key, minutes, cnt)
+	return cnt, nil
 }
 
 // makeKey
-// cache-key: rule_id-user_id-service_name_environment
-func (rc *RedisRepeatCounter) makeKey(e *domain.Event, ruleId string) string {
-	return fmt.Sprintf("%s-%s-%s-%s", ruleId, e.UserID, e.ServiceName, e.Environment)
+// cache-key: rule_id-user_id-service_name_environment-window_sec[-group_hash]
+// Окно входит в ключ, чтобы условия одного правила с разными окнами не обрезали друг другу историю.
+// Ключ группы хешируется: в нём может оказаться длинный error_message.
+func (rc *RedisRepeatCounter) makeKey(e *domain.Event, ruleId string, window time.Duration, groupKey string) string {
+	key := fmt.Sprintf("%s-%s-%s-%s-%d", ruleId, e.UserID, e.ServiceName, e.Environment, int(window.Seconds()))
+	if groupKey == "" {
+		return key
+	}
+	sum := sha1.Sum([]byte(groupKey))
+	return key + "-" + hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,85 @@
+package redis_repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// RedisSilenceCache кеширует активные silences проекта. Ключ общий для обоих движков:
+// silences создаются в public API в Postgres, движок, первым не нашедший ключ, перечитывает их.
+type RedisSilenceCache struct {
+	rdb    *redis.Client
+	ttl    time.Duration
+	logger *zerolog.Logger
+}
+
+// NewRedisSilenceCache создаёт кеш silences. Изменения из API сбрасывают ключ через
+// RuleChangeListener, ttlSec – страховка на случай потерянного уведомления.
+func NewRedisSilenceCache(rdb *redis.Client, ttlSec int, logger *zerolog.Logger) *RedisSilenceCache {
+	return &RedisSilenceCache{
+		rdb:    rdb,
+		ttl:    time.Duration(ttlSec) * time.Second,
+		logger: logger,
+	}
+}
+
+// GetSilences возвращает закешированные silences проекта. ok = false – ключа нет (кеш промах),
+// пустой список с ok = true – у проекта нет активных silences.
+func (sc *RedisSilenceCache) GetSilences(ctx context.Context, projectID string) ([]domain.Silence, bool, error) {
+	key := sc.makeKey(projectID)
+	data, err := sc.rdb.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	} else if err != nil {
+		sc.logger.Error().Err(err).Msgf("Failed to get key %s from Redis", key)
+		return nil, false, err
+	}
+
+	var silences []domain.Silence
+	if err := json.Unmarshal(data, &silences); err != nil {
+		sc.logger.Error().Err(err).Msgf("Failed to unmarshal silences for key %s", key)
+		return nil, false, err
+	}
+	return silences, true, nil
+}
+
+// SetSilences записывает silences проекта в кеш.
+func (sc *RedisSilenceCache) SetSilences(ctx context.Context, projectID string, silences []domain.Silence) error {
+	if silences == nil {
+		silences = []domain.Silence{}
+	}
+	key := sc.makeKey(projectID)
+	data, err := json.Marshal(silences)
+	if err != nil {
+		sc.logger.Error().Err(err).Msg("Failed to marshal silences for cache")
+		return err
+	}
+	if err := sc.rdb.Set(ctx, key, data, sc.ttl).Err(); err != nil {
+		sc.logger.Error().Err(err).Msgf("Failed to set cache for key %s", key)
+		return err
+	}
+	return nil
+}
+
+// InvalidateProject удаляет silences проекта из кеша, следующее событие перечитает их из Postgres.
+func (sc *RedisSilenceCache) InvalidateProject(ctx context.Context, projectID string) error {
+	key := sc.makeKey(projectID)
+	if err := sc.rdb.Del(ctx, key).Err(); err != nil {
+		sc.logger.Error().Err(err).Msgf("Failed to delete key %s from Redis", key)
+		return err
+	}
+	return nil
+}
+
+// makeKey
+// silences:project_id
+func (sc *RedisSilenceCache) makeKey(projectID string) string {
+	return fmt.Sprintf("silences:%s", projectID)
+}
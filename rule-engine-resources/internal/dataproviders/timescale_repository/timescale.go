@@ -16,6 +16,7 @@ import (
 // Интерфейс для репозитория
 type TimescaleRepository interface {
 	InsertLog(ctx context.Context, entry LogEntry) error
+	InsertAlertTransition(ctx context.Context, t AlertTransition) error
 	Close() error
 }
 
@@ -27,10 +28,28 @@ type LogEntry struct {
 	Log         json.RawMessage `db:"log"`
 	EventType   string          `db:"event_type"`
 	UsedRules   []domain.Rule   `db:"used_rules"`
-	Language    string          `db:"language"`
-	ActionUsed  []domain.Action `db:"action"`
-	ProjectId   string          `db:"project_id"`
-	Engine      string          `db:"engine"`
+	// SuppressedRules – сработавшие правила, алерт по которым подавлен cooldown
+	SuppressedRules []domain.Rule   `db:"-"`
+	Language        string          `db:"language"`
+	ActionUsed      []domain.Action `db:"action"`
+	ProjectId       string          `db:"project_id"`
+	Engine          string          `db:"engine"`
+	// SilencedRules – сработавшие правила, алерт по которым заглушён silence
+	SilencedRules []SilencedRule `db:"-"`
+}
+
+// AlertTransition – переход состояния алерта ресурсного правила (таблица alert_state_transitions)
+type AlertTransition struct {
+	Timestamp   time.Time         `db:"timestamp"`
+	UserID      int               `db:"user_id"`
+	ProjectId   string            `db:"project_id"`
+	RuleID      string            `db:"rule_id"`
+	RuleName    string            `db:"rule_name"`
+	ServiceName string            `db:"service_name"`
+	Environment string            `db:"environment"`
+	Instance    string            `db:"instance"`
+	State       domain.AlertState `db:"state"`
+	Log         json.RawMessage   `db:"log"`
 }
 
 // Реализация репозитория
@@ -78,7 +97,7 @@ func NewTimescaleRepository(logger *zerolog.Logger, config *config.Config) (Time
 // Вставляет лог в таблицу logs_events
 func (r *timescaleRepository) InsertLog(ctx context.Context, entry LogEntry) error {
 
-	usedRules := r.mapUsedRules(entry.UsedRules)
+	usedRules := r.mapUsedRules(entry.UsedRules, entry.SuppressedRules, entry.SilencedRules)
 	usedRulesJson, err := json.Marshal(usedRules)
 	if err != nil {
 		r.logger.Error().Err(err).Msg("Failed to marshal used rules")
@@ -114,6 +133,31 @@ func (r *timescaleRepository) InsertLog(ctx context.Context, entry LogEntry) err
 	return nil
 }
 
+// InsertAlertTransition сохраняет переход состояния алерта в таблицу alert_state_transitions
+func (r *timescaleRepository) InsertAlertTransition(ctx context.Context, t AlertTransition) error {
+	query := `
+        INSERT INTO alert_state_transitions (timestamp, user_id, project_id, rule_id, rule_name, service_name, environment, instance, state, log)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		t.Timestamp,
+		t.UserID,
+		t.ProjectId,
+		t.RuleID,
+		t.RuleName,
+		t.ServiceName,
+		t.Environment,
+		t.Instance,
+		string(t.State),
+		t.Log,
+	)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to insert alert transition into TimescaleDB")
+		return err
+	}
+	return nil
+}
+
 // Закрывает подключение к базе
 func (r *timescaleRepository) Close() error {
 	return r.db.Close()
@@ -121,18 +165,41 @@ func (r *timescaleRepository) Close() error {
 
 // UsedRule – структура для хранения id и name правила
 type UsedRule struct {
-	RuleID   string `json:"rule_id"`
-	RuleName string `json:"rule_name"`
+	RuleID     string `json:"rule_id"`
+	RuleName   string `json:"rule_name"`
+	Suppressed bool   `json:"suppressed,omitempty"`
+	SilenceID  string `json:"silence_id,omitempty"`
+}
+
+// SilencedRule – правило, алерт по которому заглушён silence SilenceID.
+type SilencedRule struct {
+	Rule      domain.Rule
+	SilenceID string
 }
 
-// mapUsedRules преобразует срез domain.Rule в массив объектов UsedRule
-func (r *timescaleRepository) mapUsedRules(rules []domain.Rule) []UsedRule {
-	usedRules := make([]UsedRule, 0, len(rules))
+// mapUsedRules преобразует срезы domain.Rule в массив объектов UsedRule.
+// Подавленные cooldown правила помечаются флагом suppressed, заглушённые – ID silence.
+func (r *timescaleRepository) mapUsedRules(rules, suppressed []domain.Rule, silenced []SilencedRule) []UsedRule {
+	usedRules := make([]UsedRule, 0, len(rules)+len(suppressed)+len(silenced))
 	for _, rule := range rules {
 		usedRules = append(usedRules, UsedRule{
 			RuleID:   rule.ID,
 			RuleName: rule.Name,
 		})
 	}
+	for _, rule := range suppressed {
+		usedRules = append(usedRules, UsedRule{
+			RuleID:     rule.ID,
+			RuleName:   rule.Name,
+			Suppressed: true,
+		})
+	}
+	for _, s := range silenced {
+		usedRules = append(usedRules, UsedRule{
+			RuleID:    s.Rule.ID,
+			RuleName:  s.Rule.Name,
+			SilenceID: s.SilenceID,
+		})
+	}
 	return usedRules
 }
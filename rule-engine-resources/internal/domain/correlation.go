@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Тип корреляционного правила.
+const (
+	// CorrelationSequence – шаги должны произойти по порядку в пределах окна (A, затем B, затем C)
+	CorrelationSequence = "sequence"
+	// CorrelationCoOccurrence – все шаги произошли в пределах окна в любом порядке
+	CorrelationCoOccurrence = "co_occurrence"
+)
+
+// CorrelationStep – шаг корреляционного правила: условие на событие одного из движков.
+// Пустой ServiceName – событие любого сервиса проекта.
+type CorrelationStep struct {
+	Name        string    `json:"name"`
+	Engine      string    `json:"engine"`
+	ServiceName string    `json:"service_name"`
+	RootNode    LogicNode `json:"root_node"`
+}
+
+// CorrelationRule – правило на несколько событий проекта из потоков ошибок и ресурсов.
+// Частичные совпадения хранятся в Redis (см. redis_repository.RedisCorrelationState), поэтому
+// оба движка видят шаги друг друга.
+type CorrelationRule struct {
+	ID          string
+	UserID      string
+	ProjectID   string
+	Name        string
+	Kind        string
+	WindowSec   int
+	Steps       []CorrelationStep
+	DistinctBy  string
+	MinDistinct int
+	Actions     []Action
+	// Version – хеш определения правила: после изменения шагов или окна частичные совпадения начинаются заново
+	Version string
+}
+
+// Window – окно, в котором должны произойти все шаги.
+func (r CorrelationRule) Window() time.Duration {
+	return time.Duration(r.WindowSec) * time.Second
+}
+
+// CorrelationVersion считает Version правила. Шаги берутся как JSON из базы,
+// чтобы оба движка получили одинаковую версию независимо от своих доменных типов.
+func CorrelationVersion(kind string, windowSec int, rawSteps []byte, distinctBy string, minDistinct int) string {
+	h := sha1.Sum([]byte(fmt.Sprintf("%s|%d|%s|%s|%d", kind, windowSec, rawSteps, distinctBy, minDistinct)))
+	return hex.EncodeToString(h[:4])
+}
+
+// CorrelatedEvent – событие, закрывшее шаг корреляционного правила.
+type CorrelatedEvent struct {
+	Step        int    `json:"step"`
+	StepName    string `json:"step_name"`
+	Engine      string `json:"engine"`
+	ServiceName string `json:"service_name"`
+	Environment string `json:"environment"`
+	EventType   string `json:"event_type"`
+	Message     string `json:"message"`
+	Timestamp   string `json:"timestamp"`
+	// Distinct – значение поля distinct_by правила (например, tags.instance)
+	Distinct string `json:"distinct,omitempty"`
+}
+
+// CorrelationMatch – сработавшее корреляционное правило и все события, которые его собрали.
+type CorrelationMatch struct {
+	RuleID   string            `json:"rule_id"`
+	RuleName string            `json:"rule_name"`
+	Events   []CorrelatedEvent `json:"events"`
+}
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
 // Event – входящее сообщение, которое нужно проверить правилами.
 // Можно добавить много полей (memoryUsage, goroutineCount, timestamp, etc.)
 type Event struct {
@@ -25,24 +31,255 @@ type Event struct {
 	// Поле для повторов (сколько таких ошибок за период)
 	// Если мы хотим хранить здесь, а не рассчитывать "на лету".
 	RepeatCount int `json:"repeat_count"`
+
+	// Ключ группы repeat_over (значения полей из group_by), по которому сработал счётчик
+	GroupKey string `json:"group_key,omitempty"`
+
+	// Сколько срабатываний было подавлено cooldown с прошлого алерта
+	SuppressedCount int `json:"suppressed_count,omitempty"`
+
+	// Состояние алерта, с которым событие отправлено в действия: firing / resolved
+	AlertState AlertState `json:"alert_state,omitempty"`
+
+	// Значения оконных агрегатов, посчитанные условиями правила: "avg_over(fields.cpu_percent,5m)" => 83.2
+	Aggregates map[string]float64 `json:"aggregates,omitempty"`
+
+	// Аномалии, посчитанные оператором anomaly, по полю метрики: насколько значение далеко от нормы
+	Anomalies map[string]AnomalyScore `json:"anomalies,omitempty"`
+
+	// Прогнозы predict_breach по полю метрики: когда метрика дойдёт до порога
+	Predictions map[string]BreachPrediction `json:"predictions,omitempty"`
+
+	// Состояние флота экземпляров, посчитанное fleet-условиями: "ratio_where(fields.heap_percent gt 80)" => ...
+	Fleet map[string]FleetStats `json:"fleet,omitempty"`
+
+	// Сработавшие корреляционные правила и события, которые их собрали
+	Correlations []CorrelationMatch `json:"correlations,omitempty"`
+
+	// Окно обслуживания проекта, из-за которого действия не отправлены (срабатывание только в логе)
+	MaintenanceWindowID string `json:"maintenance_window_id,omitempty"`
+
+	// sampleTime – время сэмпла, заполняется лениво в SampleTime()
+	sampleTime time.Time
+
+	// tagMap – разобранные Tags, заполняется лениво в TagMap()
+	tagMap map[string]string
+
+	// contextMap – разобранный ContextJson, заполняется лениво в ContextMap()
+	contextMap    map[string]interface{}
+	contextErr    error
+	contextParsed bool
+}
+
+// TagMap возвращает теги события в виде map. SDK (utils.FormatTags) шлёт теги
+// строками "key=value"; тег без "=" попадает в map с пустым значением.
+// Список разбирается один раз на событие.
+func (e *Event) TagMap() map[string]string {
+	if e.tagMap != nil {
+		return e.tagMap
+	}
+	e.tagMap = make(map[string]string, len(e.Tags))
+	for _, t := range e.Tags {
+		k, v, _ := strings.Cut(t, "=")
+		e.tagMap[k] = v
+	}
+	return e.tagMap
+}
+
+// InstanceTag – тег SDK с идентификатором экземпляра сервиса.
+const InstanceTag = "instance"
+
+// InstanceID возвращает идентификатор экземпляра сервиса, приславшего событие:
+// тег "instance" (SDK проставляет hostname), иначе тег "hostname"; пустая строка – экземпляр неизвестен.
+func (e *Event) InstanceID() string {
+	tags := e.TagMap()
+	if v, ok := tags[InstanceTag]; ok {
+		return v
+	}
+	return tags["hostname"]
+}
+
+// maxSampleClockSkew – насколько время из события может расходиться с часами движка,
+// прежде чем вместо него берётся время получения.
+const maxSampleClockSkew = time.Minute
+
+// SampleTime возвращает время сэмпла метрик: Timestamp события (RFC3339), если он разбирается
+// и не расходится с часами движка больше чем на maxSampleClockSkew, иначе время первого вызова.
+// Значение вычисляется один раз на событие, поэтому все условия видят один и тот же сэмпл.
+func (e *Event) SampleTime() time.Time {
+	if !e.sampleTime.IsZero() {
+		return e.sampleTime
+	}
+	now := time.Now()
+	e.sampleTime = now
+	if ts, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+		if d := now.Sub(ts); d < maxSampleClockSkew && d > -maxSampleClockSkew {
+			e.sampleTime = ts
+		}
+	}
+	return e.sampleTime
+}
+
+// SetAggregate сохраняет значение оконного агрегата, чтобы оно ушло вместе с алертом.
+func (e *Event) SetAggregate(name string, value float64) {
+	if e.Aggregates == nil {
+		e.Aggregates = make(map[string]float64)
+	}
+	e.Aggregates[name] = value
+}
+
+// SetAnomaly сохраняет оценку аномалии поля, чтобы она ушла вместе с алертом.
+func (e *Event) SetAnomaly(field string, a AnomalyScore) {
+	if e.Anomalies == nil {
+		e.Anomalies = make(map[string]AnomalyScore)
+	}
+	e.Anomalies[field] = a
+}
+
+// SetPrediction сохраняет прогноз predict_breach для поля, чтобы он ушёл вместе с алертом.
+func (e *Event) SetPrediction(field string, p BreachPrediction) {
+	if e.Predictions == nil {
+		e.Predictions = make(map[string]BreachPrediction)
+	}
+	e.Predictions[field] = p
+}
+
+// SetFleetStats сохраняет состояние флота для fleet-условия, чтобы оно ушло вместе с алертом.
+func (e *Event) SetFleetStats(name string, st FleetStats) {
+	if e.Fleet == nil {
+		e.Fleet = make(map[string]FleetStats)
+	}
+	e.Fleet[name] = st
+}
+
+// ContextMap возвращает ContextJson (контекст из CaptureException) в виде map.
+// JSON разбирается один раз на событие; пустой ContextJson даёт пустую map.
+func (e *Event) ContextMap() (map[string]interface{}, error) {
+	if e.contextParsed {
+		return e.contextMap, e.contextErr
+	}
+	e.contextParsed = true
+	e.contextMap = map[string]interface{}{}
+	if e.ContextJson != "" {
+		e.contextErr = json.Unmarshal([]byte(e.ContextJson), &e.contextMap)
+	}
+	return e.contextMap, e.contextErr
 }
 
 // ConditionOperator – тип оператора в правилах.
 type ConditionOperator string
 
 const (
-	OpEQ         ConditionOperator = "eq"
-	OpNEQ        ConditionOperator = "neq"
-	OpGT         ConditionOperator = "gt"
-	OpGTE        ConditionOperator = "gte"
-	OpLT         ConditionOperator = "lt"
-	OpLTE        ConditionOperator = "lte"
-	OpIN         ConditionOperator = "in"
-	OpNIN        ConditionOperator = "nin"
-	OpCont       ConditionOperator = "contains"
-	OpRepeatOver ConditionOperator = "repeat_over" // нужный нам оператор
+	OpEQ             ConditionOperator = "eq"
+	OpNEQ            ConditionOperator = "neq"
+	OpGT             ConditionOperator = "gt"
+	OpGTE            ConditionOperator = "gte"
+	OpLT             ConditionOperator = "lt"
+	OpLTE            ConditionOperator = "lte"
+	OpIN             ConditionOperator = "in"
+	OpNIN            ConditionOperator = "nin"
+	OpCont           ConditionOperator = "contains"
+	OpNotCont        ConditionOperator = "not_contains"
+	OpIContains      ConditionOperator = "icontains" // contains без учёта регистра
+	OpStartsWith     ConditionOperator = "starts_with"
+	OpEndsWith       ConditionOperator = "ends_with"
+	OpMatches        ConditionOperator = "matches"    // регулярное выражение RE2
+	OpExists         ConditionOperator = "exists"     // поле/тег присутствует
+	OpNotExists      ConditionOperator = "not_exists" // поля/тега нет
+	OpVersionGT      ConditionOperator = "version_gt" // сравнение версий по semver
+	OpVersionGTE     ConditionOperator = "version_gte"
+	OpVersionLT      ConditionOperator = "version_lt"
+	OpVersionLTE     ConditionOperator = "version_lte"
+	OpVersionInRange ConditionOperator = "version_in_range" // диапазон вида ">=1.4.0 <2.0.0"
+	OpRepeatOver     ConditionOperator = "repeat_over"      // нужный нам оператор
+
+	// Оконные агрегаты числовой метрики за window_minutes по (service, environment, metric):
+	// "value": {"window_minutes": 5, "threshold": 80, "compare": "gte", "p": 95}
+	OpAvgOver        ConditionOperator = "avg_over"
+	OpMaxOver        ConditionOperator = "max_over"
+	OpMinOver        ConditionOperator = "min_over"
+	OpPercentileOver ConditionOperator = "percentile_over" // p – перцентиль (0..100)
+
+	// Операторы роста по окну экземпляра сервиса (утечки памяти, горутин):
+	// rate_over – скорость изменения метрики в единицах в минуту (наклон МНК по окну),
+	// "value": {"window_minutes": 10, "threshold": 5, "compare": "gte"};
+	// increasing_for – метрика строго растёт N сэмплов подряд, "value": {"samples": 6}
+	OpRateOver      ConditionOperator = "rate_over"
+	OpIncreasingFor ConditionOperator = "increasing_for"
+
+	// anomaly – значение отклоняется от экспоненциально взвешенной нормы метрики больше чем на sigmas σ
+	// (после warmup_samples сэмплов), "value": {"sigmas": 3, "alpha": 0.1, "warmup_samples": 30, "direction": "up"}
+	OpAnomaly ConditionOperator = "anomaly"
+
+	// predict_breach – по линейной регрессии за window_minutes метрика экземпляра дойдёт до threshold
+	// быстрее чем за horizon_minutes. threshold – число или путь к полю с лимитом:
+	// "value": {"window_minutes": 30, "threshold": "fields.memory_limit_bytes", "horizon_minutes": 60}
+	OpPredictBreach ConditionOperator = "predict_breach"
+
+	// Fleet-операторы: по последнему сэмплу каждого живого экземпляра сервиса в окружении.
+	// count_where – сколько экземпляров удовлетворяют where, ratio_where – их доля (0..1),
+	// avg_across – среднее по экземплярам:
+	// "value": {"where": {"operator": "gt", "value": 80}, "threshold": 0.5, "compare": "gt", "min_instances": 2}
+	OpCountWhere ConditionOperator = "count_where"
+	OpRatioWhere ConditionOperator = "ratio_where"
+	OpAvgAcross  ConditionOperator = "avg_across"
 )
 
+// IsFleetOperator – оператор смотрит на все экземпляры сервиса, а не на один сэмпл.
+func IsFleetOperator(op ConditionOperator) bool {
+	switch op {
+	case OpCountWhere, OpRatioWhere, OpAvgAcross:
+		return true
+	}
+	return false
+}
+
+// Baseline – норма метрики: экспоненциально взвешенные среднее и стандартное отклонение.
+type Baseline struct {
+	Mean    float64
+	StdDev  float64
+	Samples int // сколько сэмплов учтено
+}
+
+// AnomalyScore – результат оператора anomaly для алерта.
+type AnomalyScore struct {
+	Value    float64 `json:"value"`
+	Baseline float64 `json:"baseline"`
+	StdDev   float64 `json:"std_dev"`
+	Score    float64 `json:"score"` // (value - baseline) / std_dev
+	Samples  int     `json:"samples"`
+}
+
+// BreachPrediction – результат predict_breach для алерта.
+type BreachPrediction struct {
+	Value         float64   `json:"value"`           // текущее значение по прямой регрессии
+	Threshold     float64   `json:"threshold"`       // порог (лимит)
+	RatePerMinute float64   `json:"rate_per_minute"` // наклон прямой
+	EtaMinutes    float64   `json:"eta_minutes"`     // через сколько минут порог будет достигнут (0 – уже)
+	EtaAt         time.Time `json:"eta_at"`
+}
+
+// FleetStats – состояние флота экземпляров по метрике для алерта.
+type FleetStats struct {
+	Instances int     `json:"instances"`          // живых экземпляров
+	Matching  int     `json:"matching,omitempty"` // удовлетворяют where (count_where, ratio_where)
+	Ratio     float64 `json:"ratio,omitempty"`    // Matching / Instances
+	Avg       float64 `json:"avg"`                // среднее значение по экземплярам
+}
+
+// InstanceSample – последнее значение метрики экземпляра сервиса.
+type InstanceSample struct {
+	Instance string
+	At       time.Time
+	Value    float64
+}
+
+// MetricSample – значение метрики в момент времени (элемент окна в Redis).
+type MetricSample struct {
+	At    time.Time
+	Value float64
+}
+
 // Condition – условие
 type Condition struct {
 	Field    string            `bson:"field"    json:"field"`
@@ -50,16 +287,35 @@ type Condition struct {
 	Value    interface{}       `bson:"value"    json:"value"`
 }
 
+// Операторы узла логического дерева.
+const (
+	LogicAND    = "AND"
+	LogicOR     = "OR"
+	LogicNOT    = "NOT"     // отрицание единственного операнда
+	LogicXOR    = "XOR"     // ровно один операнд true
+	LogicNoneOf = "NONE_OF" // ни один операнд не true
+)
+
 // LogicNode – узел логического дерева.
 // Он может содержать несколько Conditions (одним списком),
 // и может содержать дочерние узлы (Children).
-// Поле Operator определяет, как связаны *все* эти элементы: AND или OR.
+// Поле Operator определяет, как связаны *все* эти элементы: AND, OR, NOT, XOR или NONE_OF.
 type LogicNode struct {
-	Operator   string      `json:"operator"   bson:"operator"`   // "AND" / "OR"
+	Operator   string      `json:"operator"   bson:"operator"`   // "AND" / "OR" / "NOT" / "XOR" / "NONE_OF"
 	Conditions []Condition `json:"conditions" bson:"conditions"` // условия на этом уровне
 	Children   []LogicNode `json:"children"   bson:"children"`   // подузлы
 }
 
+// AlertState – состояние алерта ресурсного правила для (rule, service, environment, instance).
+type AlertState string
+
+const (
+	AlertPending  AlertState = "pending"  // условие выполнено, но ещё не держится for
+	AlertFiring   AlertState = "firing"   // алерт отправлен
+	AlertResolved AlertState = "resolved" // условие перестало выполняться после firing
+	AlertInactive AlertState = "inactive" // условие перестало выполняться, не дойдя до firing
+)
+
 // ActionType – какое действие
 type ActionType string
 
@@ -88,4 +344,46 @@ type Rule struct {
 
 	// RootNode – корень "дерева" логики (AND/OR + conditions + children)
 	RootNode LogicNode `bson:"root_node"     json:"root_node"`
+
+	// CooldownSec – после отправленного алерта повторные срабатывания подавляются столько секунд (0 – без подавления)
+	CooldownSec int `bson:"cooldown_sec" json:"cooldown_sec"`
+	// DedupKey – поля события через "+", по которым cooldown считается отдельно (например, "error_message+service_name")
+	DedupKey string `bson:"dedup_key" json:"dedup_key"`
+
+	// ForSamples / ForMinutes – сколько сэмплов подряд и сколько минут условие должно держаться,
+	// прежде чем алерт перейдёт из pending в firing (0 – сразу)
+	ForSamples int `bson:"for_samples" json:"for_samples"`
+	ForMinutes int `bson:"for_minutes" json:"for_minutes"`
+	// Schedule – когда правило активно; nil – всегда
+	Schedule *Schedule `bson:"schedule" json:"schedule,omitempty"`
+}
+
+// IsFleet – правило содержит fleet-условия (count_where, ratio_where, avg_across).
+// Состояние алерта такого правила ведётся на весь сервис, а не на экземпляр:
+// алерт срабатывает и восстанавливается один раз на изменение состояния флота.
+func (r Rule) IsFleet() bool {
+	return hasFleetCondition(r.RootNode)
+}
+
+func hasFleetCondition(node LogicNode) bool {
+	for _, c := range node.Conditions {
+		if IsFleetOperator(c.Operator) {
+			return true
+		}
+	}
+	for _, child := range node.Children {
+		if hasFleetCondition(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertInstance – экземпляр, к которому относится состояние алерта правила r по событию e:
+// для fleet-правил пустая строка (весь сервис), иначе e.InstanceID().
+func (r Rule) AlertInstance(e *Event) string {
+	if r.IsFleet() {
+		return ""
+	}
+	return e.InstanceID()
 }
@@ -1,64 +1,108 @@
 package domain
 
-// ConditionEvaluator – интерфейс/или структурный тип
-// для проверки одиночного Condition (как в предыдущих примерах).
-//type ConditionEvaluator interface {
-//	EvaluateCondition(e Event, c Condition) bool
-//}
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateLogicNode – рекурсивно проверяет, что во всём дереве используются только
+// известные операторы и что у NOT ровно один операнд.
+// Регистр оператора не важен ("and" == "AND"), пустой или неизвестный оператор – ошибка.
+func ValidateLogicNode(node LogicNode) error {
+	op := NormalizeLogicOperator(node.Operator)
+	switch op {
+	case LogicAND, LogicOR, LogicXOR, LogicNoneOf:
+	case LogicNOT:
+		if n := len(node.Conditions) + len(node.Children); n != 1 {
+			return fmt.Errorf("NOT node must have exactly one condition or child, got %d", n)
+		}
+	default:
+		return fmt.Errorf("unknown logic operator %q", node.Operator)
+	}
+
+	for _, child := range node.Children {
+		if err := ValidateLogicNode(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
 // EvaluateLogicNode – рекурсивно проверяет LogicNode.
 // Возвращает true, если узел "выполнился".
-func EvaluateLogicNode(e *Event, node LogicNode, evaluator ConditionEvaluator, r Rule) bool {
-	op := node.Operator // "AND" или "OR"
+// Операнды узла – его conditions и children (в этом порядке):
+//   - AND: все операнды true (пустой узел – true);
+//   - OR: хотя бы один операнд true (пустой узел – false);
+//   - NOT: единственный операнд false;
+//   - XOR: ровно один операнд true;
+//   - NONE_OF: ни один операнд не true.
+//
+// Неизвестный оператор возвращает ошибку, а не трактуется как OR.
+func EvaluateLogicNode(e *Event, node LogicNode, evaluator ConditionEvaluator, r Rule) (bool, error) {
+	op := NormalizeLogicOperator(node.Operator)
+	switch op {
+	case LogicAND, LogicOR, LogicNOT, LogicXOR, LogicNoneOf:
+	default:
+		return false, fmt.Errorf("unknown logic operator %q", node.Operator)
+	}
 
-	// 1. Проверим все conditions в этом узле
-	var condResult bool
-	if op == "AND" {
-		// Нужно, чтобы все были true
-		condResult = true
-		for _, c := range node.Conditions {
-			if !evaluator.Evaluate(e, c, r) {
-				condResult = false
-				break
+	matched := 0
+	total := len(node.Conditions) + len(node.Children)
+	for i := 0; i < total; i++ {
+		var ok bool
+		if i < len(node.Conditions) {
+			ok = evaluator.Evaluate(e, node.Conditions[i], r)
+		} else {
+			var err error
+			ok, err = EvaluateLogicNode(e, node.Children[i-len(node.Conditions)], evaluator, r)
+			if err != nil {
+				return false, err
 			}
 		}
-	} else {
-		// Считаем "OR" по умолчанию
-		condResult = false
-		for _, c := range node.Conditions {
-			if evaluator.Evaluate(e, c, r) {
-				condResult = true
-				break
-			}
+		if ok {
+			matched++
 		}
-	}
 
-	// 2. Проверим children
-	var childResult bool
-	if op == "AND" {
-		childResult = true
-		for _, child := range node.Children {
-			if !EvaluateLogicNode(e, child, evaluator, r) {
-				childResult = false
-				break
-			}
-		}
-	} else {
-		childResult = false
-		for _, child := range node.Children {
-			if EvaluateLogicNode(e, child, evaluator, r) {
-				childResult = true
-				break
-			}
+		// Ранний выход, как только результат узла уже известен
+		if result, done := LogicShortCircuit(op, ok, matched); done {
+			return result, nil
 		}
 	}
 
-	// 3. Соединяем results:
-	// При "AND" нужно, чтобы condResult и childResult были true.
-	// При "OR" – достаточно одного.
-	if op == "AND" {
-		return condResult && childResult
-	} else {
-		return condResult || childResult
+	return LogicResult(op, matched, total), nil
+}
+
+// LogicShortCircuit – известен ли итог узла op досрочно после очередного операнда ok
+// (matched – сколько операндов оказались true, включая текущий).
+func LogicShortCircuit(op string, ok bool, matched int) (result bool, done bool) {
+	switch {
+	case op == LogicAND && !ok:
+		return false, true
+	case op == LogicOR && ok:
+		return true, true
+	case (op == LogicNOT || op == LogicNoneOf) && ok:
+		return false, true
+	case op == LogicXOR && matched > 1:
+		return false, true
+	}
+	return false, false
+}
+
+// LogicResult – итог узла op, когда matched из total операндов оказались true.
+func LogicResult(op string, matched, total int) bool {
+	switch op {
+	case LogicAND:
+		return matched == total
+	case LogicNOT, LogicNoneOf:
+		return matched == 0
+	case LogicXOR:
+		return matched == 1
+	default: // OR
+		return matched > 0
 	}
 }
+
+// NormalizeLogicOperator приводит оператор узла к каноническому виду ("and " => "AND").
+func NormalizeLogicOperator(op string) string {
+	return strings.ToUpper(strings.TrimSpace(op))
+}
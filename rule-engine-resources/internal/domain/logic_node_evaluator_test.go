@@ -0,0 +1,106 @@
+package domain
+
+import "testing"
+
+func TestLogicShortCircuit(t *testing.T) {
+	tests := []struct {
+		name       string
+		op         string
+		ok         bool
+		matched    int
+		wantResult bool
+		wantDone   bool
+	}{
+		{name: "AND stops on false", op: LogicAND, ok: false, matched: 0, wantResult: false, wantDone: true},
+		{name: "AND continues on true", op: LogicAND, ok: true, matched: 1, wantDone: false},
+		{name: "OR stops on true", op: LogicOR, ok: true, matched: 1, wantResult: true, wantDone: true},
+		{name: "OR continues on false", op: LogicOR, ok: false, matched: 0, wantDone: false},
+		{name: "NOT stops on true", op: LogicNOT, ok: true, matched: 1, wantResult: false, wantDone: true},
+		{name: "NOT continues on false", op: LogicNOT, ok: false, matched: 0, wantDone: false},
+		{name: "NONE_OF stops on true", op: LogicNoneOf, ok: true, matched: 1, wantResult: false, wantDone: true},
+		{name: "NONE_OF continues on false", op: LogicNoneOf, ok: false, matched: 0, wantDone: false},
+		{name: "XOR continues on first true", op: LogicXOR, ok: true, matched: 1, wantDone: false},
+		{name: "XOR stops on second true", op: LogicXOR, ok: true, matched: 2, wantResult: false, wantDone: true},
+		{name: "XOR continues on false after true", op: LogicXOR, ok: false, matched: 1, wantDone: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, done := LogicShortCircuit(tt.op, tt.ok, tt.matched)
+			if done != tt.wantDone || (done && result != tt.wantResult) {
+				t.Errorf("LogicShortCircuit(%s, %v, %d) = (%v, %v), want (%v, %v)",
+					tt.op, tt.ok, tt.matched, result, done, tt.wantResult, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestLogicResult(t *testing.T) {
+	tests := []struct {
+		op      string
+		matched int
+		total   int
+		want    bool
+	}{
+		{op: LogicAND, matched: 0, total: 0, want: true},
+		{op: LogicAND, matched: 3, total: 3, want: true},
+		{op: LogicAND, matched: 2, total: 3, want: false},
+		{op: LogicOR, matched: 0, total: 0, want: false},
+		{op: LogicOR, matched: 1, total: 3, want: true},
+		{op: LogicOR, matched: 0, total: 3, want: false},
+		{op: LogicNOT, matched: 0, total: 1, want: true},
+		{op: LogicNOT, matched: 1, total: 1, want: false},
+		{op: LogicNoneOf, matched: 0, total: 0, want: true},
+		{op: LogicNoneOf, matched: 0, total: 3, want: true},
+		{op: LogicNoneOf, matched: 1, total: 3, want: false},
+		{op: LogicXOR, matched: 1, total: 3, want: true},
+		{op: LogicXOR, matched: 0, total: 3, want: false},
+		{op: LogicXOR, matched: 2, total: 3, want: false},
+	}
+	for _, tt := range tests {
+		if got := LogicResult(tt.op, tt.matched, tt.total); got != tt.want {
+			t.Errorf("LogicResult(%s, %d, %d) = %v, want %v", tt.op, tt.matched, tt.total, got, tt.want)
+		}
+	}
+}
+
+// operandEvaluator возвращает результат условия по его значению (true / false).
+type operandEvaluator struct{}
+
+func (operandEvaluator) Evaluate(_ *Event, c Condition, _ Rule) bool {
+	ok, _ := c.Value.(bool)
+	return ok
+}
+
+// TestEvaluateLogicNodeShortCircuit проверяет, что ранний выход не меняет итог узла:
+// для каждого набора операндов результат совпадает с LogicResult по всем операндам.
+func TestEvaluateLogicNodeShortCircuit(t *testing.T) {
+	operands := [][]bool{
+		{},
+		{false},
+		{true},
+		{true, false, false},
+		{false, true, false},
+		{true, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+	for _, op := range []string{LogicAND, LogicOR, LogicXOR, LogicNoneOf} {
+		for _, values := range operands {
+			node := LogicNode{Operator: op}
+			matched := 0
+			for _, v := range values {
+				node.Conditions = append(node.Conditions, Condition{Value: v})
+				if v {
+					matched++
+				}
+			}
+			got, err := EvaluateLogicNode(&Event{}, node, operandEvaluator{}, Rule{})
+			if err != nil {
+				t.Fatalf("%s %v: unexpected error: %v", op, values, err)
+			}
+			if want := LogicResult(op, matched, len(values)); got != want {
+				t.Errorf("%s %v = %v, want %v", op, values, got, want)
+			}
+		}
+	}
+}
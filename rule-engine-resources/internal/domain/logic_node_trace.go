@@ -0,0 +1,84 @@
+package domain
+
+import "fmt"
+
+// ConditionTrace – как проверилось одно условие: значение поля в событии,
+// оператор, ожидаемое значение из правила и результат.
+type ConditionTrace struct {
+	Field    string            `json:"field"`
+	Operator ConditionOperator `json:"operator"`
+	Expected interface{}       `json:"expected"`
+	Actual   interface{}       `json:"actual"`
+	Result   bool              `json:"result"`
+}
+
+// NodeTrace – как проверился узел логического дерева: итог узла и трассировка его операндов.
+type NodeTrace struct {
+	Operator   string           `json:"operator"`
+	Result     bool             `json:"result"`
+	Conditions []ConditionTrace `json:"conditions"`
+	Children   []NodeTrace      `json:"children"`
+}
+
+// FieldResolver – необязательное расширение ConditionEvaluator: отдаёт значение поля события
+// так, как его видит условие. Если evaluator его не реализует, Actual в трассировке пустой.
+type FieldResolver interface {
+	ResolveField(e *Event, field string) interface{}
+}
+
+// TraceRule – то же, что EvaluateRule, но вместо bool возвращает дерево трассировки.
+func TraceRule(e *Event, r Rule, evaluator ConditionEvaluator) (NodeTrace, error) {
+	if err := ValidateLogicNode(r.RootNode); err != nil {
+		return NodeTrace{}, fmt.Errorf("rule %s: %w", r.ID, err)
+	}
+	return TraceLogicNode(e, r.RootNode, evaluator, r)
+}
+
+// TraceLogicNode – проверяет узел по тем же правилам, что и EvaluateLogicNode, но без раннего выхода:
+// каждое условие и каждый подузел попадают в трассировку, даже если итог узла уже известен.
+// Итог узла всегда совпадает с EvaluateLogicNode.
+func TraceLogicNode(e *Event, node LogicNode, evaluator ConditionEvaluator, r Rule) (NodeTrace, error) {
+	op := NormalizeLogicOperator(node.Operator)
+	switch op {
+	case LogicAND, LogicOR, LogicNOT, LogicXOR, LogicNoneOf:
+	default:
+		return NodeTrace{}, fmt.Errorf("unknown logic operator %q", node.Operator)
+	}
+
+	resolver, _ := evaluator.(FieldResolver)
+	trace := NodeTrace{
+		Operator:   op,
+		Conditions: make([]ConditionTrace, 0, len(node.Conditions)),
+		Children:   make([]NodeTrace, 0, len(node.Children)),
+	}
+
+	matched := 0
+	for _, c := range node.Conditions {
+		ct := ConditionTrace{
+			Field:    c.Field,
+			Operator: c.Operator,
+			Expected: c.Value,
+			Result:   evaluator.Evaluate(e, c, r),
+		}
+		if resolver != nil {
+			ct.Actual = resolver.ResolveField(e, c.Field)
+		}
+		if ct.Result {
+			matched++
+		}
+		trace.Conditions = append(trace.Conditions, ct)
+	}
+	for _, child := range node.Children {
+		ct, err := TraceLogicNode(e, child, evaluator, r)
+		if err != nil {
+			return NodeTrace{}, err
+		}
+		if ct.Result {
+			matched++
+		}
+		trace.Children = append(trace.Children, ct)
+	}
+
+	trace.Result = LogicResult(op, matched, len(node.Conditions)+len(node.Children))
+	return trace, nil
+}
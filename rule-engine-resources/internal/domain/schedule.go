@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schedule – когда правило активно (или когда действует повторяющееся окно обслуживания):
+// дни недели и часы в часовом поясе IANA. End раньше Start – интервал через полночь ("22:00"–"06:00"),
+// End равен Start – весь день. Пустой Days – каждый день, пустой Timezone – UTC.
+type Schedule struct {
+	Timezone string   `json:"timezone" bson:"timezone"`
+	Days     []string `json:"days,omitempty" bson:"days"` // mon, tue, wed, thu, fri, sat, sun
+	Start    string   `json:"start" bson:"start"`         // "09:00"
+	End      string   `json:"end" bson:"end"`             // "18:00"
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// locations – разобранные часовые пояса: LoadLocation читает базу зон на каждом вызове.
+var locations sync.Map // string -> *time.Location
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// parseClock разбирает "HH:MM" в минуты от начала суток.
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid time %q: expected HH:MM", s)
+	}
+	hours, err := strconv.Atoi(h)
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, fmt.Errorf("invalid time %q: expected HH:MM", s)
+	}
+	minutes, err := strconv.Atoi(m)
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("invalid time %q: expected HH:MM", s)
+	}
+	return hours*60 + minutes, nil
+}
+
+// Active – попадает ли момент t в расписание. Интервал через полночь относится к дню, в который начался.
+func (s *Schedule) Active(t time.Time) (bool, error) {
+	loc, err := loadLocation(s.Timezone)
+	if err != nil {
+		return false, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+	}
+	start, err := parseClock(s.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseClock(s.End)
+	if err != nil {
+		return false, err
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+
+	switch {
+	case start == end:
+		return s.onDay(day)
+	case start < end:
+		if minute < start || minute >= end {
+			return false, nil
+		}
+		return s.onDay(day)
+	default:
+		// через полночь: вечер текущего дня или утро после дня из расписания
+		if minute >= start {
+			return s.onDay(day)
+		}
+		if minute < end {
+			return s.onDay((day + 6) % 7)
+		}
+		return false, nil
+	}
+}
+
+func (s *Schedule) onDay(day time.Weekday) (bool, error) {
+	if len(s.Days) == 0 {
+		return true, nil
+	}
+	for _, d := range s.Days {
+		wd, ok := weekdays[strings.ToLower(d)]
+		if !ok {
+			return false, fmt.Errorf("invalid day %q: expected mon..sun", d)
+		}
+		if wd == day {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MaintenanceWindow – окно обслуживания проекта: алерты не отправляются, срабатывания пишутся в лог.
+// Разовое окно задаётся StartsAt/EndsAt (деплой), повторяющееся – Schedule (ночные батчи).
+type MaintenanceWindow struct {
+	ID       string
+	Name     string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	Schedule *Schedule
+}
+
+// Active – действует ли окно в момент t.
+func (w MaintenanceWindow) Active(t time.Time) (bool, error) {
+	if w.StartsAt != nil && w.EndsAt != nil && !t.Before(*w.StartsAt) && t.Before(*w.EndsAt) {
+		return true, nil
+	}
+	if w.Schedule != nil {
+		return w.Schedule.Active(t)
+	}
+	return false, nil
+}
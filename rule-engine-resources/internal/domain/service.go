@@ -1,16 +1,14 @@
 package domain
 
-// EvaluateRule – проверяем, выполняются ли все условия
-//
-//	func EvaluateRule(e Event, r Rule, evaluator ConditionEvaluator) bool {
-//		for _, c := range r.Conditions {
-//			if !evaluator.Evaluate(e, c, r) {
-//				return false
-//			}
-//		}
-//		return true
-//	}
-func EvaluateRule(e *Event, r Rule, evaluator ConditionEvaluator) bool {
+import "fmt"
+
+// EvaluateRule – проверяет правило по дереву RootNode.
+// Перед проверкой дерево валидируется целиком, чтобы ошибка в операторе
+// не пряталась за ранним выходом из соседних узлов.
+func EvaluateRule(e *Event, r Rule, evaluator ConditionEvaluator) (bool, error) {
+	if err := ValidateLogicNode(r.RootNode); err != nil {
+		return false, fmt.Errorf("rule %s: %w", r.ID, err)
+	}
 	return EvaluateLogicNode(e, r.RootNode, evaluator, r)
 }
 
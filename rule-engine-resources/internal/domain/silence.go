@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// SilenceMatchers – условия silence. Задаются в public API; все непустые условия должны совпасть.
+type SilenceMatchers struct {
+	RuleID       string            `json:"rule_id,omitempty"`
+	ServiceName  string            `json:"service_name,omitempty"`
+	Environment  string            `json:"environment,omitempty"`
+	ErrorMessage string            `json:"error_message,omitempty"` // регулярное выражение
+	Tags         map[string]string `json:"tags,omitempty"`          // теги "key=value" события
+}
+
+// Silence – временное заглушение алертов: сработавшее правило, подходящее под Matchers,
+// не отправляет действия до EndsAt, а срабатывание пишется в лог с ID silence.
+type Silence struct {
+	ID        string          `json:"id"`
+	Matchers  SilenceMatchers `json:"matchers"`
+	EndsAt    time.Time       `json:"ends_at"`
+	CreatedBy string          `json:"created_by"`
+	Comment   string          `json:"comment,omitempty"`
+}
@@ -0,0 +1,117 @@
+package transport
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+	"rule-engine-resources/internal/usecases"
+
+	"github.com/rs/zerolog"
+)
+
+// maxExplainBodyBytes – ограничение на размер тела запроса dry-run.
+const maxExplainBodyBytes = 1 << 20
+
+// TokenHeader – заголовок с общим токеном public API и движка.
+const TokenHeader = "X-Rule-Engine-Token"
+
+// ExplainRequest – тело POST /v1/rules/explain: правило и пример события.
+type ExplainRequest struct {
+	Rule  domain.Rule  `json:"rule"`
+	Event domain.Event `json:"event"`
+}
+
+// ExplainResponse – результат dry-run: сработало ли правило и трассировка по узлам и условиям.
+type ExplainResponse struct {
+	Matched bool             `json:"matched"`
+	Trace   domain.NodeTrace `json:"trace"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ExplainHandler проверяет правило на присланном событии без побочных эффектов (см. usecases.ExplainRule).
+type ExplainHandler struct {
+	logger *zerolog.Logger
+}
+
+func NewExplainHandler(logger *zerolog.Logger) *ExplainHandler {
+	return &ExplainHandler{logger: logger}
+}
+
+func (h *ExplainHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return
+	}
+
+	var body ExplainRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxExplainBodyBytes)).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+
+	trace, err := usecases.ExplainRule(&body.Event, body.Rule, h.logger)
+	if err != nil {
+		h.logger.Debug().Err(err).Msg("Explain: invalid rule")
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ExplainResponse{Matched: trace.Result, Trace: trace})
+}
+
+// NewHTTPServer – служебный HTTP-сервер движка (dry-run правил для public API).
+// С непустым token запросы без него в TokenHeader отклоняются. Без token сервер
+// можно поднять только на loopback: иначе dry-run был бы открыт всей сети.
+func NewHTTPServer(addr, token string, logger *zerolog.Logger) (*http.Server, error) {
+	if token == "" && !isLoopback(addr) {
+		return nil, fmt.Errorf("HTTP_TOKEN is required to serve on %q, bind HTTP_ADDR to 127.0.0.1 otherwise", addr)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/v1/rules/explain", requireToken(token, NewExplainHandler(logger)))
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}, nil
+}
+
+// requireToken пропускает только запросы с token в TokenHeader. Пустой token – проверки нет.
+func requireToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get(TokenHeader)), []byte(token)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "unauthorized"})
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// isLoopback – адрес addr слушает только локальный интерфейс (":8090" – все интерфейсы).
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
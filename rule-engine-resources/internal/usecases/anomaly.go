@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"rule-engine-resources/internal/domain"
+)
+
+// Параметры anomaly по умолчанию.
+const (
+	anomalyDefaultAlpha  = 0.1
+	anomalyDefaultWarmup = 30
+)
+
+// anomalyParams – разобранный value оператора anomaly:
+// {"sigmas": 3, "alpha": 0.1, "warmup_samples": 30, "direction": "both", "min_std": 0}
+type anomalyParams struct {
+	sigmas    float64
+	alpha     float64 // вес нового сэмпла в EWMA
+	warmup    int     // пока сэмплов меньше, норма считается, но условие не срабатывает
+	direction string  // up / down / both
+	minStd    float64 // нижняя граница σ: у почти постоянной метрики любая мелочь дала бы огромный score
+}
+
+func parseAnomalyParams(v interface{}) (anomalyParams, error) {
+	valMap, ok := v.(map[string]interface{})
+	if !ok {
+		return anomalyParams{}, fmt.Errorf("invalid 'value' (expected map[string]interface{})")
+	}
+
+	sigmas, ok := toFloat(valMap["sigmas"])
+	if !ok || sigmas <= 0 {
+		return anomalyParams{}, fmt.Errorf("invalid 'sigmas' %v", valMap["sigmas"])
+	}
+	params := anomalyParams{
+		sigmas:    sigmas,
+		alpha:     anomalyDefaultAlpha,
+		warmup:    anomalyDefaultWarmup,
+		direction: "both",
+	}
+
+	if a, ok := valMap["alpha"]; ok {
+		alpha, ok := toFloat(a)
+		if !ok || alpha <= 0 || alpha > 1 {
+			return anomalyParams{}, fmt.Errorf("invalid 'alpha' %v (expected 0 < alpha <= 1)", a)
+		}
+		params.alpha = alpha
+	}
+	if w, ok := valMap["warmup_samples"]; ok {
+		warmup, ok := toFloat(w)
+		if !ok || warmup < 0 {
+			return anomalyParams{}, fmt.Errorf("invalid 'warmup_samples' %v", w)
+		}
+		params.warmup = int(warmup)
+	}
+	if d, ok := valMap["direction"]; ok {
+		ds, _ := d.(string)
+		switch ds {
+		case "up", "down", "both":
+			params.direction = ds
+		default:
+			return anomalyParams{}, fmt.Errorf("invalid 'direction' %v (expected up, down or both)", d)
+		}
+	}
+	if m, ok := valMap["min_std"]; ok {
+		minStd, ok := toFloat(m)
+		if !ok || minStd < 0 {
+			return anomalyParams{}, fmt.Errorf("invalid 'min_std' %v", m)
+		}
+		params.minStd = minStd
+	}
+	return params, nil
+}
+
+// evaluateAnomaly сравнивает значение метрики с её нормой (EWMA по project, service, environment, metric):
+// score = (value - mean) / σ, условие выполнено, если |score| >= sigmas в нужном направлении.
+// Каждый сэмпл обновляет норму (recordSamples, до проверки правил), в том числе во время warm-up,
+// когда условие не выполнено или не проверялось. Повторный Observe того же сэмпла возвращает ту же норму.
+// Норма и score сохраняются в e.Anomalies и уходят вместе с алертом.
+func (rce *RuleConditionEvaluator) evaluateAnomaly(e *domain.Event, c domain.Condition) bool {
+	params, err := parseAnomalyParams(c.Value)
+	if err != nil {
+		rce.logger.Warn().Err(err).Msg("anomaly condition: invalid value")
+		return false
+	}
+
+	value, ok := toFloat(rce.getDynamicField(e, c.Field))
+	if !ok {
+		rce.logger.Debug().Msgf("anomaly: field %s is missing or not numeric", c.Field)
+		return false
+	}
+
+	// Без Redis (dry-run) норма не читается и не меняется
+	if rce.anomalyBaseline == nil {
+		rce.logger.Debug().Msg("anomaly is not evaluated without Redis baseline")
+		return false
+	}
+
+	baseline, err := rce.anomalyBaseline.Observe(context.Background(), e, c.Field, value, params.alpha)
+	if err != nil {
+		rce.logger.Error().Err(err).Msg("Anomaly baseline Observe failed")
+		return false
+	}
+	if baseline.Samples < params.warmup {
+		rce.logger.Debug().Msgf("anomaly: warming up %s, %d of %d samples", c.Field, baseline.Samples, params.warmup)
+		return false
+	}
+
+	std := baseline.StdDev
+	if std < params.minStd {
+		std = params.minStd
+	}
+	if std == 0 {
+		rce.logger.Debug().Msgf("anomaly: %s has zero deviation, set min_std to score it", c.Field)
+		return false
+	}
+
+	score := (value - baseline.Mean) / std
+	e.SetAnomaly(c.Field, domain.AnomalyScore{
+		Value:    value,
+		Baseline: baseline.Mean,
+		StdDev:   std,
+		Score:    score,
+		Samples:  baseline.Samples,
+	})
+
+	rce.logger.Debug().Msgf("anomaly check: field=%s value=%g baseline=%g std=%g score=%.2f sigmas=%g",
+		c.Field, value, baseline.Mean, std, score, params.sigmas)
+	switch params.direction {
+	case "up":
+		return score >= params.sigmas
+	case "down":
+		return score <= -params.sigmas
+	default:
+		return score >= params.sigmas || score <= -params.sigmas
+	}
+}
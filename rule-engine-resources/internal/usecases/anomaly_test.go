@@ -0,0 +1,50 @@
+package usecases
+
+import "testing"
+
+func TestParseAnomalyParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    anomalyParams
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			value: map[string]interface{}{"sigmas": float64(3)},
+			want:  anomalyParams{sigmas: 3, alpha: anomalyDefaultAlpha, warmup: anomalyDefaultWarmup, direction: "both"},
+		},
+		{
+			name: "all fields",
+			value: map[string]interface{}{
+				"sigmas": 2.5, "alpha": 0.3, "warmup_samples": float64(10), "direction": "up", "min_std": 0.5,
+			},
+			want: anomalyParams{sigmas: 2.5, alpha: 0.3, warmup: 10, direction: "up", minStd: 0.5},
+		},
+		{
+			name:  "alpha of one and no warm-up",
+			value: map[string]interface{}{"sigmas": 4, "alpha": 1, "warmup_samples": 0, "direction": "down"},
+			want:  anomalyParams{sigmas: 4, alpha: 1, warmup: 0, direction: "down"},
+		},
+		{name: "not a map", value: float64(3), wantErr: true},
+		{name: "missing sigmas", value: map[string]interface{}{}, wantErr: true},
+		{name: "zero sigmas", value: map[string]interface{}{"sigmas": 0}, wantErr: true},
+		{name: "zero alpha", value: map[string]interface{}{"sigmas": 3, "alpha": 0}, wantErr: true},
+		{name: "alpha above one", value: map[string]interface{}{"sigmas": 3, "alpha": 1.5}, wantErr: true},
+		{name: "negative warm-up", value: map[string]interface{}{"sigmas": 3, "warmup_samples": -1}, wantErr: true},
+		{name: "unknown direction", value: map[string]interface{}{"sigmas": 3, "direction": "sideways"}, wantErr: true},
+		{name: "direction not a string", value: map[string]interface{}{"sigmas": 3, "direction": 1}, wantErr: true},
+		{name: "negative min_std", value: map[string]interface{}{"sigmas": 3, "min_std": -0.1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAnomalyParams(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAnomalyParams(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseAnomalyParams(%v) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// compiledRulesCacheSize – сколько наборов правил (user, service, project) держать в памяти.
+const compiledRulesCacheSize = 10000
+
+// compiledRulesTTL – сколько живёт запись, если уведомление об изменении правил потерялось.
+const compiledRulesTTL = 5 * time.Minute
+
+// compiledRuleCache – LRU скомпилированных правил по (user, service, project).
+// Изменения правил из public API сбрасывают записи пользователя через RuleChangeListener,
+// поэтому на событие не нужно ни одного обращения к Redis; TTL лишь ограничивает устаревание.
+type compiledRuleCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List               // от недавно использованных к давно
+	items    map[string]*list.Element // key -> *compiledRuleEntry
+}
+
+type compiledRuleEntry struct {
+	key      string
+	rules    []CompiledRule
+	loadedAt time.Time
+}
+
+func newCompiledRuleCache(capacity int) *compiledRuleCache {
+	return &compiledRuleCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+// get возвращает правила, если запись есть и не старше compiledRulesTTL.
+func (c *compiledRuleCache) get(key string, now time.Time) ([]CompiledRule, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*compiledRuleEntry)
+	if now.Sub(entry.loadedAt) >= compiledRulesTTL {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.rules, true
+}
+
+// put сохраняет правила, вытесняя самую давнюю запись при переполнении.
+func (c *compiledRuleCache) put(key string, rules []CompiledRule, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*compiledRuleEntry)
+		entry.rules = rules
+		entry.loadedAt = now
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&compiledRuleEntry{key: key, rules: rules, loadedAt: now})
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*compiledRuleEntry).key)
+	}
+}
+
+// removePrefix удаляет записи, ключ которых начинается с prefix, и возвращает их число.
+func (c *compiledRuleCache) removePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, el := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.ll.Remove(el)
+			delete(c.items, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// clear удаляет все записи и возвращает их число.
+func (c *compiledRuleCache) clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := c.ll.Len()
+	c.ll.Init()
+	c.items = make(map[string]*list.Element, c.capacity)
+	return removed
+}
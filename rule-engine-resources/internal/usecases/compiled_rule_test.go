@@ -0,0 +1,115 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// benchRulesCount – сколько правил у сервиса в бенчмарках горячего пути.
+// Сетевые вызовы (Kafka, TimescaleDB) в замер не входят: на горячем пути правила
+// берутся из in-process LRU, Redis читается только при промахе или после инвалидации.
+const benchRulesCount = 20
+
+// BenchmarkEvaluateInterpreted – как движок работал раньше: JSON списка правил из Redis
+// разбирается на каждом событии, условия проверяются через domain.EvaluateRule.
+func BenchmarkEvaluateInterpreted(b *testing.B) {
+	logger := zerolog.Nop()
+	rulesJSON, err := json.Marshal(benchRules(benchRulesCount))
+	if err != nil {
+		b.Fatal(err)
+	}
+	evaluator := NewRuleConditionEvaluator(nil, &logger)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var decoded []domain.Rule
+		if err := json.Unmarshal(rulesJSON, &decoded); err != nil {
+			b.Fatal(err)
+		}
+		event := benchEvent()
+		for _, r := range decoded {
+			if _, err := domain.EvaluateRule(event, r, evaluator); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkEvaluateCompiled – правила скомпилированы один раз и берутся из in-process LRU
+// через getCompiledRules, как в Evaluate.
+func BenchmarkEvaluateCompiled(b *testing.B) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	uc := &EvaluateRulesUseCase{compiledRules: newCompiledRuleCache(compiledRulesCacheSize), logger: &logger}
+	event := benchEvent()
+	uc.compiledRules.put(event.UserID+":"+event.ServiceName+":"+event.ProjectId, CompileRules(benchRules(benchRulesCount)), time.Now())
+	evaluator := NewRuleConditionEvaluator(nil, &logger)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		event := benchEvent()
+		compiled, err := uc.getCompiledRules(ctx, event.UserID, event.ServiceName, event.ProjectId)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for j := range compiled {
+			if _, err := compiled[j].Evaluate(event, evaluator); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// benchRules собирает типичные правила: сервис из списка, пороги по метрикам, регулярка по хосту
+// и тег. Событие из benchEvent не проходит последнее условие, поэтому проверяется всё дерево.
+func benchRules(n int) []domain.Rule {
+	rules := make([]domain.Rule, 0, n)
+	for i := 0; i < n; i++ {
+		rules = append(rules, domain.Rule{
+			ID:   fmt.Sprintf("%d", i+1),
+			Name: fmt.Sprintf("rule-%d", i+1),
+			RootNode: domain.LogicNode{
+				Operator: domain.LogicAND,
+				Conditions: []domain.Condition{
+					{Field: "service_name", Operator: domain.OpIN, Value: []interface{}{"checkout", "payments"}},
+					{Field: "fields.cpu_percent", Operator: domain.OpGT, Value: float64(50 + i)},
+					{Field: "fields.memory.heap_alloc_bytes", Operator: domain.OpGTE, Value: float64(1 << 20)},
+					{Field: "fields.hostname", Operator: domain.OpMatches, Value: `^checkout-\d+$`},
+				},
+				Children: []domain.LogicNode{{
+					Operator: domain.LogicOR,
+					Conditions: []domain.Condition{
+						{Field: "tags.region", Operator: domain.OpEQ, Value: "eu-west-1"},
+						{Field: "tags.region", Operator: domain.OpEQ, Value: "eu-central-1"},
+					},
+				}},
+			},
+			Actions: []domain.Action{{Type: domain.ActionNone}},
+		})
+	}
+	return rules
+}
+
+func benchEvent() *domain.Event {
+	return &domain.Event{
+		UserID:      "1",
+		ProjectId:   "1",
+		ServiceName: "checkout",
+		Environment: "production",
+		Tags:        []string{"region=us-east-1", "instance=checkout-1"},
+		Fields: map[string]interface{}{
+			"cpu_percent": float64(92),
+			"memory":      map[string]interface{}{"heap_alloc_bytes": float64(64 << 20)},
+			"hostname":    "checkout-1",
+		},
+	}
+}
@@ -29,8 +29,29 @@ type EvaluateRulesUseCase struct {
 	timeScaleRepo   timescale_repository.TimescaleRepository
 	alertDispatcher AlertDispatcher
 	redisCounter    *redis_repository.RedisRepeatCounter
+	metricWindow    *redis_repository.RedisMetricWindow
+	anomalyBaseline *redis_repository.RedisAnomalyBaseline
+	fleetState      *redis_repository.RedisFleetState
 	redisCache      *redis_repository.RedisCache
-	logger          *zerolog.Logger
+	alertCooldown   *redis_repository.RedisAlertCooldown
+	alertState      *redis_repository.RedisAlertStateStore
+	regexCache      *regexCache
+	compiledRules   *compiledRuleCache
+
+	// Корреляционные правила проекта: шаги из потоков ошибок и ресурсов
+	correlationRepo  CorrelationRuleRepository
+	correlationState *redis_repository.RedisCorrelationState
+	correlationRules *correlationRuleCache
+
+	// Окна обслуживания проектов: действия не отправляются, срабатывания пишутся в лог
+	maintenanceRepo MaintenanceRepository
+	maintenance     *maintenanceCache
+
+	// Silences: заглушённое правило не отправляет действия, срабатывание пишется в лог с ID silence
+	silenceRepo  SilenceRepository
+	silenceCache *redis_repository.RedisSilenceCache
+
+	logger *zerolog.Logger
 }
 
 func NewEvaluateRulesUseCase(
@@ -38,28 +59,76 @@ func NewEvaluateRulesUseCase(
 	ts timescale_repository.TimescaleRepository,
 	ad AlertDispatcher,
 	rc *redis_repository.RedisRepeatCounter,
+	mw *redis_repository.RedisMetricWindow,
+	ab *redis_repository.RedisAnomalyBaseline,
+	fs *redis_repository.RedisFleetState,
 	rd *redis_repository.RedisCache,
+	cd *redis_repository.RedisAlertCooldown,
+	as *redis_repository.RedisAlertStateStore,
+	cr CorrelationRuleRepository,
+	cs *redis_repository.RedisCorrelationState,
+	mr MaintenanceRepository,
+	sr SilenceRepository,
+	sc *redis_repository.RedisSilenceCache,
 	logger *zerolog.Logger,
 ) *EvaluateRulesUseCase {
 	return &EvaluateRulesUseCase{
-		ruleRepo:        rr,
-		timeScaleRepo:   ts,
-		alertDispatcher: ad,
-		redisCounter:    rc,
-		redisCache:      rd,
-		logger:          logger,
+		ruleRepo:         rr,
+		timeScaleRepo:    ts,
+		alertDispatcher:  ad,
+		redisCounter:     rc,
+		metricWindow:     mw,
+		anomalyBaseline:  ab,
+		fleetState:       fs,
+		redisCache:       rd,
+		alertCooldown:    cd,
+		alertState:       as,
+		regexCache:       newRegexCache(regexCacheSize),
+		compiledRules:    newCompiledRuleCache(compiledRulesCacheSize),
+		correlationRepo:  cr,
+		correlationState: cs,
+		correlationRules: newCorrelationRuleCache(),
+		maintenanceRepo:  mr,
+		maintenance:      newMaintenanceCache(),
+		silenceRepo:      sr,
+		silenceCache:     sc,
+		logger:           logger,
 	}
 }
 
-// Evaluate – читает event.UserID, event.ServiceName -> загружает только нужные правила
-func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Event) error {
+// Evaluation – результат проверки правил для одного события: какие действия отправить и что записать в лог.
+// Evaluate меняет состояние в Redis (cooldown, состояние алертов, окна метрик), поэтому при ошибке
+// доставки повторяется только Deliver с тем же Evaluation – повторная проверка правил потеряла бы алерт.
+type Evaluation struct {
+	event           *domain.Event
+	triggered       []domain.Action
+	triggeredRules  []domain.Rule
+	suppressedRules []domain.Rule
+	silencedRules   []timescale_repository.SilencedRule
+	resolvedRules   []domain.Rule
+
+	// Алерты, уведомление о firing которых отправляет это событие: отмечаются после отправки
+	firingRules []domain.Rule
+
+	// Окна cooldown, занятые отправляемыми правилами: освобождаются, если действия так и не отправлены
+	cooldowns []redis_repository.CooldownLease
+
+	// Что уже доставлено: повтор Deliver не дублирует отправленные действия и запись в лог
+	dispatched         bool
+	resolvedDispatched bool
+	logged             bool
+}
+
+// Evaluate – читает event.UserID, event.ServiceName -> загружает только нужные правила.
+// Ошибка возвращается только до проверки правил (загрузка правил), поэтому Evaluate при ошибке можно повторить.
+func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Event) (*Evaluation, error) {
 	uc.logger.Debug().Msgf("Evaluate: user=%s, service=%s", event.UserID, event.ServiceName)
 
 	// 1. Фильтруем только правила для (user_id, service_name)
-	rules, err := uc.GetRulesByUserAndServiceAndProjectId(ctx, event.UserID, event.ServiceName, event.ProjectId)
+	rules, err := uc.getCompiledRules(ctx, event.UserID, event.ServiceName, event.ProjectId)
 	if err != nil {
 		uc.logger.Error().Err(err).Msg("Failed to fetch rules by user & service")
-		return err
+		return nil, err
 	}
 	uc.logger.Debug().Msgf("Got %d rules for user=%s, service=%s", len(rules), event.UserID, event.ServiceName)
 
@@ -72,33 +141,152 @@ func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Even
 
 	// 3. Готовим evaluator
 	evaluator := &RuleConditionEvaluator{
-		redisCounter: uc.redisCounter,
-		logger:       uc.logger,
+		redisCounter:    uc.redisCounter,
+		metricWindow:    uc.metricWindow,
+		anomalyBaseline: uc.anomalyBaseline,
+		fleetState:      uc.fleetState,
+		regexCache:      uc.regexCache,
+		logger:          uc.logger,
 	}
 
+	// 3.1 Сэмплы метрик события – во все окна, нормы и состояние флота правил, до проверки условий:
+	// ранний выход AND/OR и правила вне расписания не должны оставлять дыр в окнах
+	evaluator.recordSamples(ctx, event, rules)
+
 	// 4. Для каждого правила EvaluateRule -> собираем actions
-	var triggered []domain.Action
-	var triggeredRuleNames []domain.Rule
-	for _, r := range rules {
-		ok := domain.EvaluateRule(event, r, evaluator)
-		if ok {
+	ev := &Evaluation{event: event}
+	now := time.Now()
+	silences := uc.newSilenceSet(event.ProjectId)
+	maintenance := uc.newMaintenanceCheck(ctx, event, now)
+	for i := range rules {
+		r := rules[i].Rule
+		if !uc.ruleActive(r, now) {
+			uc.logger.Debug().Msgf("Rule %q skipped: outside of its schedule", r.Name)
+			continue
+		}
+		ok, err := rules[i].Evaluate(event, evaluator)
+		if err != nil {
+			uc.logger.Error().Err(err).Msgf("Rule %q skipped: invalid logic tree", r.Name)
+			continue
+		}
+
+		// Жизненный цикл алерта: действия отправляются, пока не отправлено уведомление о firing или resolved.
+		// Подавленное silence или окном обслуживания уведомление остаётся неотправленным
+		// и уходит на первом сэмпле после их окончания; в лог пишется только сам переход
+		transition, notify := uc.alertTransition(ctx, event, r, ok)
+		if notify == domain.AlertResolved {
+			// Заглушённое правило не шлёт и уведомление о восстановлении
+			if sl, ok := silences.match(ctx, event, r, now); ok {
+				uc.logger.Debug().Msgf("Rule resolved but silenced by silence %s: %s", sl.ID, r.Name)
+				continue
+			}
+			if maintenance() {
+				continue
+			}
+			uc.logger.Debug().Msgf("Rule resolved: %s", r.Name)
+			ev.resolvedRules = append(ev.resolvedRules, r)
+			continue
+		}
+		if notify == domain.AlertFiring {
+			if sl, ok := silences.match(ctx, event, r, now); ok {
+				uc.logger.Debug().Msgf("Rule matched but silenced by silence %s: %s", sl.ID, r.Name)
+				if transition == domain.AlertFiring {
+					ev.silencedRules = append(ev.silencedRules, timescale_repository.SilencedRule{Rule: r, SilenceID: sl.ID})
+				}
+				continue
+			}
+			if maintenance() {
+				// срабатывание пишется в лог с ID окна, действия не отправляются
+				if transition == domain.AlertFiring {
+					ev.triggered = append(ev.triggered, r.Actions...)
+					ev.triggeredRules = append(ev.triggeredRules, r)
+				}
+				continue
+			}
+			if uc.inCooldown(ctx, ev, r, evaluator) {
+				uc.logger.Debug().Msgf("Rule matched but suppressed by cooldown: %s", r.Name)
+				if transition == domain.AlertFiring {
+					ev.suppressedRules = append(ev.suppressedRules, r)
+				}
+				continue
+			}
 			uc.logger.Debug().Msgf("Rule matched: %s", r.Name)
-			triggered = append(triggered, r.Actions...)
-			triggeredRuleNames = append(triggeredRuleNames, r)
+			ev.triggered = append(ev.triggered, r.Actions...)
+			ev.triggeredRules = append(ev.triggeredRules, r)
+			ev.firingRules = append(ev.firingRules, r)
 		}
 	}
 
+	// 4.1 Корреляционные правила: сработавшее правило отправляется вместе с остальными
+	for _, r := range uc.correlate(ctx, event, evaluator) {
+		if sl, ok := silences.match(ctx, event, r, now); ok {
+			ev.silencedRules = append(ev.silencedRules, timescale_repository.SilencedRule{Rule: r, SilenceID: sl.ID})
+			continue
+		}
+		maintenance()
+		ev.triggered = append(ev.triggered, r.Actions...)
+		ev.triggeredRules = append(ev.triggeredRules, r)
+	}
+
+	if len(ev.triggered) > 0 {
+		event.AlertState = domain.AlertFiring
+	} else if len(ev.resolvedRules) == 0 {
+		uc.logger.Debug().Msg("No rules matched, no actions triggered")
+	}
+	return ev, nil
+}
+
+// newMaintenanceCheck возвращает проверку окна обслуживания проекта события. Окно ищется один раз,
+// при первом вызове – только когда есть что отправить. В окно обслуживания действия (и firing, и resolved)
+// не отправляются, срабатывание пишется в лог с ID окна (event.MaintenanceWindowID).
+func (uc *EvaluateRulesUseCase) newMaintenanceCheck(ctx context.Context, event *domain.Event, now time.Time) func() bool {
+	checked := false
+	return func() bool {
+		if !checked {
+			checked = true
+			if w, ok := uc.activeMaintenance(ctx, event.ProjectId, now); ok {
+				uc.logger.Info().Msgf("Maintenance window %s (%s) is active for project=%s, actions not dispatched", w.ID, w.Name, event.ProjectId)
+				event.MaintenanceWindowID = w.ID
+			}
+		}
+		return event.MaintenanceWindowID != ""
+	}
+}
+
+// Deliver отправляет действия сработавших и восстановившихся правил и пишет срабатывание в TimescaleDB.
+// При ошибке Deliver можно вызвать повторно с тем же Evaluation: уже выполненные шаги пропускаются.
+func (uc *EvaluateRulesUseCase) Deliver(ctx context.Context, ev *Evaluation) error {
+	event := ev.event
+	inMaintenance := event.MaintenanceWindowID != ""
+
 	// 5. Если есть actions, вызываем dispatcher
-	if len(triggered) > 0 {
-		uc.logger.Info().Msgf("Triggered %d actions for user=%s, service=%s", len(triggered), event.UserID, event.ServiceName)
-		err = uc.alertDispatcher.DispatchActions(ctx, event, triggered)
-		if err != nil {
+	if len(ev.triggered) > 0 && !inMaintenance && !ev.dispatched {
+		uc.logger.Info().Msgf("Triggered %d actions for user=%s, service=%s", len(ev.triggered), event.UserID, event.ServiceName)
+		if err := uc.alertDispatcher.DispatchActions(ctx, event, ev.triggered); err != nil {
 			return err
 		}
+		ev.dispatched = true
+		uc.markNotified(ctx, event, ev.firingRules, domain.AlertFiring)
 	}
-	uc.logger.Debug().Msg("No rules matched, no actions triggered")
+
+	// 5.1 Уведомляем о восстановлении по тем же действиям правила
+	if len(ev.resolvedRules) > 0 && !inMaintenance && !ev.resolvedDispatched {
+		var resolvedActions []domain.Action
+		for _, r := range ev.resolvedRules {
+			resolvedActions = append(resolvedActions, r.Actions...)
+		}
+		resolvedEvent := *event
+		resolvedEvent.AlertState = domain.AlertResolved
+		uc.logger.Info().Msgf("Resolved %d rules for user=%s, service=%s", len(ev.resolvedRules), event.UserID, event.ServiceName)
+		if err := uc.alertDispatcher.DispatchActions(ctx, &resolvedEvent, resolvedActions); err != nil {
+			return err
+		}
+		ev.resolvedDispatched = true
+		uc.markNotified(ctx, event, ev.resolvedRules, domain.AlertResolved)
+	}
+
 	// не добавляем лог в timescale если не сработало правило. Сейчас такая логика
-	if len(triggeredRuleNames) == 0 {
+	if ev.logged || len(ev.triggeredRules) == 0 && len(ev.suppressedRules) == 0 && len(ev.silencedRules) == 0 {
 		return nil
 	}
 	// 6. Сохраняем лог в TimescaleDB
@@ -115,29 +303,182 @@ func (uc *EvaluateRulesUseCase) Evaluate(ctx context.Context, event *domain.Even
 	}
 
 	logEntry := timescale_repository.LogEntry{
+		UserID:          userIDInt,
+		ServiceName:     event.ServiceName,
+		Timestamp:       time.Now(),
+		Log:             raw,
+		EventType:       event.EventType,
+		UsedRules:       ev.triggeredRules,
+		SuppressedRules: ev.suppressedRules,
+		SilencedRules:   ev.silencedRules,
+		Language:        event.Language,
+		ActionUsed:      ev.triggered,
+		ProjectId:       event.ProjectId,
+		Engine:          ENGINE,
+	}
+
+	if err = uc.timeScaleRepo.InsertLog(ctx, logEntry); err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to insert log into TimescaleDB")
+		return err
+	}
+	ev.logged = true
+
+	return nil
+}
+
+// alertTransition применяет результат проверки правила к состоянию алерта
+// (rule, service, environment, instance) и сохраняет переход в TimescaleDB.
+// Возвращает переход и состояние, о котором нужно уведомить.
+// Без хранилища состояний (или при ошибке Redis) правило срабатывает на каждом сэмпле, как раньше.
+func (uc *EvaluateRulesUseCase) alertTransition(ctx context.Context, event *domain.Event, r domain.Rule, matched bool) (transition, notify domain.AlertState) {
+	fallback := domain.AlertState("")
+	if matched {
+		fallback = domain.AlertFiring
+	}
+	if uc.alertState == nil {
+		return fallback, fallback
+	}
+
+	transition, notify, err := uc.alertState.Transition(ctx, event, r, matched)
+	if err != nil {
+		uc.logger.Error().Err(err).Msgf("Alert state transition failed for rule %s", r.ID)
+		return fallback, fallback
+	}
+	if transition != "" {
+		uc.recordTransition(ctx, event, r, transition)
+	}
+	return transition, notify
+}
+
+// markNotified отмечает отправленные уведомления о состоянии state алертов правил rules.
+// При ошибке Redis уведомление повторится на следующем сэмпле.
+func (uc *EvaluateRulesUseCase) markNotified(ctx context.Context, event *domain.Event, rules []domain.Rule, state domain.AlertState) {
+	if uc.alertState == nil {
+		return
+	}
+	for _, r := range rules {
+		if err := uc.alertState.MarkNotified(ctx, event, r, state); err != nil {
+			uc.logger.Error().Err(err).Msgf("Failed to mark alert %s notified for rule %s", state, r.ID)
+		}
+	}
+}
+
+// recordTransition пишет переход состояния алерта в TimescaleDB (alert_state_transitions).
+func (uc *EvaluateRulesUseCase) recordTransition(ctx context.Context, event *domain.Event, r domain.Rule, state domain.AlertState) {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to marshal event for alert transition")
+		return
+	}
+	userIDInt, err := strconv.Atoi(event.UserID)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to convert UserID to int")
+		return
+	}
+
+	err = uc.timeScaleRepo.InsertAlertTransition(ctx, timescale_repository.AlertTransition{
+		Timestamp:   time.Now(),
 		UserID:      userIDInt,
+		ProjectId:   event.ProjectId,
+		RuleID:      r.ID,
+		RuleName:    r.Name,
 		ServiceName: event.ServiceName,
-		Timestamp:   time.Now(),
+		Environment: event.Environment,
+		Instance:    r.AlertInstance(event),
+		State:       state,
 		Log:         raw,
-		EventType:   event.EventType,
-		UsedRules:   triggeredRuleNames,
-		Language:    event.Language,
-		ActionUsed:  triggered,
-		ProjectId:   event.ProjectId,
-		Engine:      ENGINE,
+	})
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to insert alert transition into TimescaleDB")
+	}
+}
+
+// inCooldown проверяет cooldown сработавшего правила.
+// true – алерт подавлен; иначе в event.SuppressedCount добавляется число подавленных с прошлого алерта,
+// а занятое окно запоминается в ev до отправки (см. Release).
+// При ошибке Redis алерт не подавляется: лучше лишнее сообщение, чем потерянное.
+func (uc *EvaluateRulesUseCase) inCooldown(ctx context.Context, ev *Evaluation, r domain.Rule, evaluator *RuleConditionEvaluator) bool {
+	if r.CooldownSec <= 0 || uc.alertCooldown == nil {
+		return false
 	}
 
-	err = uc.timeScaleRepo.InsertLog(ctx, logEntry)
+	fingerprint := evaluator.dedupFingerprint(ev.event, r.DedupKey)
+	allowed, suppressed, lease, err := uc.alertCooldown.Acquire(ctx, r.ID, fingerprint, time.Duration(r.CooldownSec)*time.Second)
 	if err != nil {
-		uc.logger.Error().Err(err).Msg("Failed to insert log into TimescaleDB")
-		// В зависимости от требований, можно не прерывать выполнение
-		// return err
+		uc.logger.Error().Err(err).Msgf("Cooldown check failed for rule %s, dispatching anyway", r.ID)
+		return false
 	}
+	if !allowed {
+		return true
+	}
+	ev.event.SuppressedCount += suppressed
+	ev.cooldowns = append(ev.cooldowns, lease)
+	return false
+}
 
-	return nil
+// Release освобождает окна cooldown, занятые Evaluate, если действия так и не отправлены
+// (доставка исчерпала повторы и событие уходит в DLQ): иначе окно подавило бы следующие алерты правила.
+func (uc *EvaluateRulesUseCase) Release(ctx context.Context, ev *Evaluation) {
+	if ev.dispatched || uc.alertCooldown == nil {
+		return
+	}
+	for _, lease := range ev.cooldowns {
+		if err := uc.alertCooldown.Release(ctx, lease); err != nil {
+			uc.logger.Error().Err(err).Msgf("Failed to release cooldown of rule %s", lease.RuleID)
+		}
+	}
+	ev.cooldowns = nil
+}
+
+// getCompiledRules возвращает скомпилированные правила для (user, service, project).
+// Правила берутся из in-process LRU без обращения к Redis и разбора JSON на каждом событии;
+// при промахе читаются из Redis или Postgres и компилируются заново.
+func (uc *EvaluateRulesUseCase) getCompiledRules(ctx context.Context, userID, serviceName, projectId string) ([]CompiledRule, error) {
+	key := userID + ":" + serviceName + ":" + projectId
+	now := time.Now()
+	if rules, ok := uc.compiledRules.get(key, now); ok {
+		return rules, nil
+	}
+
+	rules, err := uc.loadRules(ctx, userID, serviceName, projectId)
+	if err != nil {
+		return nil, err
+	}
+	compiled := CompileRules(rules)
+	uc.compiledRules.put(key, compiled, now)
+	return compiled, nil
+}
+
+// InvalidateUser сбрасывает правила пользователя userID в памяти и в Redis.
+// Вызывается RuleChangeListener при изменении правил через public API.
+// Redis сбрасывается первым: иначе событие между двумя шагами вернуло бы в память старые правила.
+func (uc *EvaluateRulesUseCase) InvalidateUser(ctx context.Context, userID string) (int, error) {
+	var deleted int
+	if uc.redisCache != nil {
+		n, err := uc.redisCache.InvalidateUser(ctx, userID)
+		if err != nil {
+			return n, err
+		}
+		deleted = n
+	}
+	return deleted + uc.compiledRules.removePrefix(userID+":"), nil
+}
+
+// InvalidateAll сбрасывает правила всех пользователей в памяти и в Redis.
+func (uc *EvaluateRulesUseCase) InvalidateAll(ctx context.Context) (int, error) {
+	var deleted int
+	if uc.redisCache != nil {
+		n, err := uc.redisCache.InvalidateAll(ctx)
+		if err != nil {
+			return n, err
+		}
+		deleted = n
+	}
+	return deleted + uc.compiledRules.clear(), nil
 }
 
-func (uc *EvaluateRulesUseCase) GetRulesByUserAndServiceAndProjectId(ctx context.Context, userID, serviceName, projectId string) ([]domain.Rule, error) {
+// loadRules достаёт правила из Redis, а при промахе – из Postgres (и кладёт их в Redis).
+func (uc *EvaluateRulesUseCase) loadRules(ctx context.Context, userID, serviceName, projectId string) ([]domain.Rule, error) {
 	// Сначала пробуем получить правила из кеша
 	if uc.redisCache != nil {
 		if cachedRules, err := uc.redisCache.GetRules(ctx, userID, serviceName, projectId); err == nil && cachedRules != nil {
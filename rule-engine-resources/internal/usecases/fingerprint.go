@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"rule-engine-resources/internal/domain"
+)
+
+const (
+	// stackFramesField – специальное поле dedup_key: верхние кадры стека без адресов и аргументов
+	stackFramesField = "stack_frames"
+	// stackFramesDepth – сколько верхних кадров стека участвует в отпечатке
+	stackFramesDepth = 5
+)
+
+var hexAddrRe = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+
+// dedupFingerprint считает отпечаток события по dedup_key правила.
+// dedup_key – пути полей через "+" ("error_message+service_name"), те же, что и в условиях,
+// плюс "stack_frames". Пустой dedup_key даёт пустой отпечаток (cooldown на всё правило).
+func (rce *RuleConditionEvaluator) dedupFingerprint(e *domain.Event, dedupKey string) string {
+	if dedupKey == "" {
+		return ""
+	}
+
+	h := sha1.New()
+	for _, p := range strings.Split(dedupKey, "+") {
+		p = strings.TrimSpace(p)
+		var val interface{}
+		if p == stackFramesField {
+			val = strings.Join(topStackFrames(e.StackTrace, stackFramesDepth), "\n")
+		} else {
+			val = rce.getDynamicField(e, p)
+		}
+		fmt.Fprintf(h, "%s=%v\x00", p, val)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// topStackFrames возвращает до n верхних кадров стека в нормализованном виде:
+// без заголовка горутины, строк с файлами, аргументов вызова и адресов,
+// чтобы один и тот же путь падения давал одинаковый результат между запусками.
+func topStackFrames(stack string, n int) []string {
+	frames := make([]string, 0, n)
+	for _, line := range strings.Split(stack, "\n") {
+		if len(frames) == n {
+			break
+		}
+		// строки с файлом в стеке Go идут с отступом: "\t/path/file.go:12 +0x1d"
+		if strings.HasPrefix(line, "\t") {
+			continue
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "goroutine ") || strings.HasPrefix(line, "created by ") {
+			continue
+		}
+		if i := strings.LastIndex(line, "("); i > 0 && strings.HasSuffix(line, ")") {
+			line = line[:i]
+		}
+		frames = append(frames, hexAddrRe.ReplaceAllString(line, "0x?"))
+	}
+	return frames
+}
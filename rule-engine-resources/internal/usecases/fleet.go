@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"rule-engine-resources/internal/domain"
+)
+
+// fleetParams – разобранный value fleet-оператора:
+// {"where": {"operator": "gt", "value": 80}, "threshold": 0.5, "compare": "gt", "min_instances": 2}
+type fleetParams struct {
+	whereOp      domain.ConditionOperator // gt / gte / lt / lte / eq / neq, не нужен для avg_across
+	whereValue   float64
+	threshold    float64
+	compare      domain.ConditionOperator // gt / gte / lt / lte, по умолчанию gte
+	minInstances int                      // меньше живых экземпляров – условие не выполнено
+}
+
+func parseFleetParams(op domain.ConditionOperator, v interface{}) (fleetParams, error) {
+	valMap, ok := v.(map[string]interface{})
+	if !ok {
+		return fleetParams{}, fmt.Errorf("invalid 'value' (expected map[string]interface{})")
+	}
+
+	threshold, ok := toFloat(valMap["threshold"])
+	if !ok {
+		return fleetParams{}, fmt.Errorf("invalid 'threshold' %v", valMap["threshold"])
+	}
+	params := fleetParams{
+		threshold:    threshold,
+		compare:      domain.OpGTE,
+		minInstances: 1,
+	}
+
+	if c, ok := valMap["compare"]; ok {
+		cs, _ := c.(string)
+		switch domain.ConditionOperator(cs) {
+		case domain.OpGT, domain.OpGTE, domain.OpLT, domain.OpLTE:
+			params.compare = domain.ConditionOperator(cs)
+		default:
+			return fleetParams{}, fmt.Errorf("invalid 'compare' %v (expected gt, gte, lt or lte)", c)
+		}
+	}
+	if m, ok := valMap["min_instances"]; ok {
+		n, ok := toFloat(m)
+		if !ok || n < 1 {
+			return fleetParams{}, fmt.Errorf("invalid 'min_instances' %v", m)
+		}
+		params.minInstances = int(n)
+	}
+
+	if op == domain.OpAvgAcross {
+		return params, nil
+	}
+	where, ok := valMap["where"].(map[string]interface{})
+	if !ok {
+		return fleetParams{}, fmt.Errorf("invalid 'where' (expected {\"operator\": ..., \"value\": ...})")
+	}
+	ws, _ := where["operator"].(string)
+	switch domain.ConditionOperator(ws) {
+	case domain.OpGT, domain.OpGTE, domain.OpLT, domain.OpLTE, domain.OpEQ, domain.OpNEQ:
+		params.whereOp = domain.ConditionOperator(ws)
+	default:
+		return fleetParams{}, fmt.Errorf("invalid 'where.operator' %v", where["operator"])
+	}
+	params.whereValue, ok = toFloat(where["value"])
+	if !ok {
+		return fleetParams{}, fmt.Errorf("invalid 'where.value' %v", where["value"])
+	}
+	return params, nil
+}
+
+// evaluateFleet проверяет fleet-условие: count_where / ratio_where / avg_across считаются по всем
+// живым экземплярам сервиса в окружении. Сэмпл события записан как последний сэмпл экземпляра
+// до проверки правил (recordSamples). Состояние флота сохраняется в e.Fleet и уходит вместе с алертом.
+func (rce *RuleConditionEvaluator) evaluateFleet(e *domain.Event, c domain.Condition) bool {
+	params, err := parseFleetParams(c.Operator, c.Value)
+	if err != nil {
+		rce.logger.Warn().Err(err).Msgf("%s condition: invalid value", c.Operator)
+		return false
+	}
+
+	if _, ok := toFloat(rce.getDynamicField(e, c.Field)); !ok {
+		rce.logger.Debug().Msgf("%s: field %s is missing or not numeric", c.Operator, c.Field)
+		return false
+	}
+
+	// Без Redis (dry-run) состояние флота не читается
+	if rce.fleetState == nil {
+		rce.logger.Debug().Msgf("%s is not evaluated without Redis fleet state", c.Operator)
+		return false
+	}
+
+	instances, err := rce.fleetState.List(context.Background(), e, c.Field)
+	if err != nil {
+		rce.logger.Error().Err(err).Msg("Fleet List failed")
+		return false
+	}
+	if len(instances) < params.minInstances {
+		rce.logger.Debug().Msgf("%s: %d live instances, need %d", c.Operator, len(instances), params.minInstances)
+		return false
+	}
+
+	st := domain.FleetStats{Instances: len(instances)}
+	sum := 0.0
+	for _, inst := range instances {
+		sum += inst.Value
+		if params.whereOp != "" && matchWhere(inst.Value, params.whereOp, params.whereValue) {
+			st.Matching++
+		}
+	}
+	st.Avg = sum / float64(len(instances))
+	st.Ratio = float64(st.Matching) / float64(len(instances))
+
+	var got float64
+	name := fmt.Sprintf("%s(%s %s %g)", c.Operator, c.Field, params.whereOp, params.whereValue)
+	switch c.Operator {
+	case domain.OpCountWhere:
+		got = float64(st.Matching)
+	case domain.OpRatioWhere:
+		got = st.Ratio
+	default: // OpAvgAcross
+		got = st.Avg
+		name = fmt.Sprintf("%s(%s)", c.Operator, c.Field)
+	}
+	e.SetFleetStats(name, st)
+
+	rce.logger.Debug().Msgf("%s check: field=%s instances=%d matching=%d avg=%g value=%g %s %g",
+		c.Operator, c.Field, st.Instances, st.Matching, st.Avg, got, params.compare, params.threshold)
+	return compareThreshold(got, params.compare, params.threshold)
+}
+
+// matchWhere проверяет значение экземпляра условием where.
+func matchWhere(v float64, op domain.ConditionOperator, target float64) bool {
+	switch op {
+	case domain.OpEQ:
+		return v == target
+	case domain.OpNEQ:
+		return v != target
+	default:
+		return compareThreshold(v, op, target)
+	}
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"testing"
+
+	"rule-engine-resources/internal/domain"
+)
+
+func TestParseFleetParams(t *testing.T) {
+	where := map[string]interface{}{"operator": "gt", "value": float64(80)}
+	tests := []struct {
+		name    string
+		op      domain.ConditionOperator
+		value   interface{}
+		want    fleetParams
+		wantErr bool
+	}{
+		{
+			name:  "count_where with defaults",
+			op:    domain.OpCountWhere,
+			value: map[string]interface{}{"where": where, "threshold": float64(3)},
+			want:  fleetParams{whereOp: domain.OpGT, whereValue: 80, threshold: 3, compare: domain.OpGTE, minInstances: 1},
+		},
+		{
+			name: "ratio_where with all fields",
+			op:   domain.OpRatioWhere,
+			value: map[string]interface{}{
+				"where":     map[string]interface{}{"operator": "neq", "value": "0"},
+				"threshold": 0.5, "compare": "gt", "min_instances": float64(4),
+			},
+			want: fleetParams{whereOp: domain.OpNEQ, whereValue: 0, threshold: 0.5, compare: domain.OpGT, minInstances: 4},
+		},
+		{
+			name:  "avg_across does not need where",
+			op:    domain.OpAvgAcross,
+			value: map[string]interface{}{"threshold": 70, "compare": "lt"},
+			want:  fleetParams{threshold: 70, compare: domain.OpLT, minInstances: 1},
+		},
+		{name: "not a map", op: domain.OpCountWhere, value: []interface{}{80}, wantErr: true},
+		{name: "missing threshold", op: domain.OpAvgAcross, value: map[string]interface{}{}, wantErr: true},
+		{name: "unsupported compare", op: domain.OpAvgAcross, value: map[string]interface{}{"threshold": 1, "compare": "eq"}, wantErr: true},
+		{name: "zero min_instances", op: domain.OpAvgAcross, value: map[string]interface{}{"threshold": 1, "min_instances": 0}, wantErr: true},
+		{name: "count_where without where", op: domain.OpCountWhere, value: map[string]interface{}{"threshold": 1}, wantErr: true},
+		{
+			name:    "unsupported where operator",
+			op:      domain.OpCountWhere,
+			value:   map[string]interface{}{"where": map[string]interface{}{"operator": "matches", "value": 1}, "threshold": 1},
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric where value",
+			op:      domain.OpRatioWhere,
+			value:   map[string]interface{}{"where": map[string]interface{}{"operator": "gt", "value": "high"}, "threshold": 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFleetParams(tt.op, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFleetParams(%s, %v) error = %v, wantErr %v", tt.op, tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseFleetParams(%s, %v) = %+v, want %+v", tt.op, tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+)
+
+// evaluateRateOver проверяет скорость изменения метрики экземпляра сервиса за window_minutes
+// (единиц в минуту, наклон прямой МНК по сэмплам окна) против threshold.
+func (rce *RuleConditionEvaluator) evaluateRateOver(e *domain.Event, c domain.Condition) bool {
+	params, err := parseWindowParams(c.Operator, c.Value)
+	if err != nil {
+		rce.logger.Warn().Err(err).Msg("rate_over condition: invalid value")
+		return false
+	}
+
+	samples, ok := rce.instanceSamples(e, c, params.window)
+	if !ok {
+		return false
+	}
+	if len(samples) < params.minSamples {
+		rce.logger.Debug().Msgf("rate_over: %d samples in window, need %d", len(samples), params.minSamples)
+		return false
+	}
+
+	rate, ok := slopePerMinute(samples)
+	if !ok {
+		rce.logger.Debug().Msg("rate_over: all samples have the same timestamp")
+		return false
+	}
+	e.SetAggregate(aggregateName(c, params), rate)
+
+	rce.logger.Debug().Msgf("rate_over check: field=%s instance=%s samples=%d rate=%g/min %s %g",
+		c.Field, e.InstanceID(), len(samples), rate, params.compare, params.threshold)
+	return compareThreshold(rate, params.compare, params.threshold)
+}
+
+// evaluateIncreasingFor проверяет, что метрика экземпляра сервиса строго росла
+// последние N сэмплов подряд (включая текущий). "value": {"samples": 6}
+// Сэмплы берутся из окна хранения, поэтому N сэмплов должны уложиться в MetricWindowMaxSec.
+func (rce *RuleConditionEvaluator) evaluateIncreasingFor(e *domain.Event, c domain.Condition) bool {
+	valMap, ok := c.Value.(map[string]interface{})
+	if !ok {
+		rce.logger.Warn().Msg("increasing_for condition has invalid 'value' (expected map[string]interface{})")
+		return false
+	}
+	n, ok := toFloat(valMap["samples"])
+	if !ok || n < 2 {
+		rce.logger.Warn().Msgf("increasing_for condition: invalid 'samples' %v (expected number >= 2)", valMap["samples"])
+		return false
+	}
+	need := int(n)
+
+	if rce.metricWindow == nil {
+		rce.logger.Debug().Msg("increasing_for is not evaluated without Redis metric window")
+		return false
+	}
+	samples, ok := rce.instanceSamples(e, c, rce.metricWindow.MaxWindow())
+	if !ok {
+		return false
+	}
+	if len(samples) < need {
+		rce.logger.Debug().Msgf("increasing_for: %d samples stored, need %d", len(samples), need)
+		return false
+	}
+
+	last := samples[len(samples)-need:]
+	for i := 1; i < len(last); i++ {
+		if last[i].Value <= last[i-1].Value {
+			rce.logger.Debug().Msgf("increasing_for: field=%s instance=%s not increasing at sample %d of %d", c.Field, e.InstanceID(), i+1, need)
+			return false
+		}
+	}
+	e.SetAggregate(fmt.Sprintf("%s(%s,%d)", c.Operator, c.Field, need), last[len(last)-1].Value-last[0].Value)
+
+	rce.logger.Debug().Msgf("increasing_for check: field=%s instance=%s increasing for %d samples", c.Field, e.InstanceID(), need)
+	return true
+}
+
+// instanceSamples возвращает сэмплы поля c.Field из окна экземпляра за window.
+// Сэмпл события записан в окно до проверки правил (recordSamples).
+func (rce *RuleConditionEvaluator) instanceSamples(e *domain.Event, c domain.Condition, window time.Duration) ([]domain.MetricSample, bool) {
+	if _, ok := toFloat(rce.getDynamicField(e, c.Field)); !ok {
+		rce.logger.Debug().Msgf("%s: field %s is missing or not numeric", c.Operator, c.Field)
+		return nil, false
+	}
+	if rce.metricWindow == nil {
+		rce.logger.Debug().Msgf("%s is not evaluated without Redis metric window", c.Operator)
+		return nil, false
+	}
+	samples, err := rce.metricWindow.RangeByInstance(context.Background(), e, c.Field, window)
+	if err != nil {
+		rce.logger.Error().Err(err).Msg("Metric window RangeByInstance failed")
+		return nil, false
+	}
+	return samples, true
+}
+
+// slopePerMinute – наклон прямой МНК value(t) по сэмплам, в единицах метрики в минуту.
+// ok=false, если у всех сэмплов одно время.
+func slopePerMinute(samples []domain.MetricSample) (float64, bool) {
+	slope, _, ok := linearFit(samples)
+	return slope, ok
+}
+
+// linearFit – прямая МНК value = intercept + slope*x, где x – минуты от первого сэмпла.
+// ok=false, если у всех сэмплов одно время.
+func linearFit(samples []domain.MetricSample) (slope, intercept float64, ok bool) {
+	t0 := samples[0].At
+	n := float64(len(samples))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.At.Sub(t0).Minutes()
+		sumX += x
+		sumY += s.Value
+		sumXY += x * s.Value
+		sumXX += x * x
+	}
+	den := n*sumXX - sumX*sumX
+	if den == 0 {
+		return 0, 0, false
+	}
+	slope = (n*sumXY - sumX*sumY) / den
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept, true
+}
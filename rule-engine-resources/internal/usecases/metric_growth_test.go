@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+)
+
+// floatEpsilon – допустимая погрешность сравнения результатов МНК.
+const floatEpsilon = 1e-9
+
+// samplesEvery собирает сэмплы с шагом step минут, начиная с фиксированного момента.
+func samplesEvery(step float64, values ...float64) []domain.MetricSample {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	samples := make([]domain.MetricSample, 0, len(values))
+	for i, v := range values {
+		samples = append(samples, domain.MetricSample{
+			At:    t0.Add(time.Duration(float64(i) * step * float64(time.Minute))),
+			Value: v,
+		})
+	}
+	return samples
+}
+
+func TestSlopePerMinute(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []domain.MetricSample
+		want    float64
+		wantOK  bool
+	}{
+		{name: "growing by 10 per minute", samples: samplesEvery(1, 0, 10, 20, 30), want: 10, wantOK: true},
+		{name: "samples every 30 seconds", samples: samplesEvery(0.5, 100, 105, 110), want: 10, wantOK: true},
+		{name: "falling", samples: samplesEvery(2, 50, 40, 30), want: -5, wantOK: true},
+		{name: "flat", samples: samplesEvery(1, 7, 7, 7), want: 0, wantOK: true},
+		{name: "noisy around a slope of 2", samples: samplesEvery(1, 0, 3, 4, 6), want: 1.9, wantOK: true},
+		{name: "single sample", samples: samplesEvery(1, 42), wantOK: false},
+		{name: "same timestamp", samples: samplesEvery(0, 1, 2, 3), wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := slopePerMinute(tt.samples)
+			if ok != tt.wantOK {
+				t.Fatalf("slopePerMinute ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && math.Abs(got-tt.want) > floatEpsilon {
+				t.Errorf("slopePerMinute = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinearFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		samples       []domain.MetricSample
+		wantSlope     float64
+		wantIntercept float64
+		wantOK        bool
+	}{
+		{name: "exact line", samples: samplesEvery(1, 5, 7, 9, 11), wantSlope: 2, wantIntercept: 5, wantOK: true},
+		{name: "two samples", samples: samplesEvery(10, 100, 200), wantSlope: 10, wantIntercept: 100, wantOK: true},
+		{name: "noisy", samples: samplesEvery(1, 0, 3, 4, 6), wantSlope: 1.9, wantIntercept: 0.4, wantOK: true},
+		{name: "flat", samples: samplesEvery(1, 3, 3), wantSlope: 0, wantIntercept: 3, wantOK: true},
+		{name: "same timestamp", samples: samplesEvery(0, 1, 5), wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slope, intercept, ok := linearFit(tt.samples)
+			if ok != tt.wantOK {
+				t.Fatalf("linearFit ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(slope-tt.wantSlope) > floatEpsilon || math.Abs(intercept-tt.wantIntercept) > floatEpsilon {
+				t.Errorf("linearFit = (%g, %g), want (%g, %g)", slope, intercept, tt.wantSlope, tt.wantIntercept)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+
+	"rule-engine-resources/internal/domain"
+)
+
+// collectStateful собирает условия дерева, которые копят состояние по сэмплам метрики:
+// оконные агрегаты, операторы роста, anomaly и fleet-условия.
+func collectStateful(node domain.LogicNode, out []domain.Condition) []domain.Condition {
+	for _, c := range node.Conditions {
+		switch c.Operator {
+		case domain.OpAvgOver, domain.OpMaxOver, domain.OpMinOver, domain.OpPercentileOver,
+			domain.OpRateOver, domain.OpIncreasingFor, domain.OpPredictBreach,
+			domain.OpAnomaly,
+			domain.OpCountWhere, domain.OpRatioWhere, domain.OpAvgAcross:
+			out = append(out, c)
+		}
+	}
+	for _, child := range node.Children {
+		out = collectStateful(child, out)
+	}
+	return out
+}
+
+// recordSamples записывает значения метрик события во все окна, нормы EWMA и состояние флота,
+// на которые ссылаются правила, до проверки условий. Проверка условия состояние только читает,
+// поэтому условие, до которого проверка не дошла (ранний выход AND/OR, правило вне расписания),
+// не оставляет в окнах дыр. Каждое окно получает сэмпл события один раз.
+func (rce *RuleConditionEvaluator) recordSamples(ctx context.Context, e *domain.Event, rules []CompiledRule) {
+	if rce.recorded == nil {
+		rce.recorded = make(map[string]struct{})
+	}
+	for i := range rules {
+		for _, c := range rules[i].stateful {
+			rce.recordSample(ctx, e, c)
+		}
+	}
+}
+
+func (rce *RuleConditionEvaluator) recordSample(ctx context.Context, e *domain.Event, c domain.Condition) {
+	var key string
+	var alpha float64
+	switch c.Operator {
+	case domain.OpAvgOver, domain.OpMaxOver, domain.OpMinOver, domain.OpPercentileOver:
+		key = "window|" + c.Field
+	case domain.OpRateOver, domain.OpIncreasingFor, domain.OpPredictBreach:
+		key = "instance|" + c.Field
+	case domain.OpAnomaly:
+		params, err := parseAnomalyParams(c.Value)
+		if err != nil {
+			return
+		}
+		// условия с разным alpha ведут разные нормы
+		alpha = params.alpha
+		key = "anomaly|" + c.Field + "|" + strconv.FormatFloat(alpha, 'g', -1, 64)
+	default: // fleet
+		key = "fleet|" + c.Field
+	}
+	if _, done := rce.recorded[key]; done {
+		return
+	}
+	rce.recorded[key] = struct{}{}
+
+	value, ok := toFloat(rce.getDynamicField(e, c.Field))
+	if !ok {
+		return
+	}
+
+	var err error
+	switch c.Operator {
+	case domain.OpAvgOver, domain.OpMaxOver, domain.OpMinOver, domain.OpPercentileOver:
+		if rce.metricWindow != nil {
+			err = rce.metricWindow.Add(ctx, e, c.Field, value)
+		}
+	case domain.OpRateOver, domain.OpIncreasingFor, domain.OpPredictBreach:
+		if rce.metricWindow != nil {
+			err = rce.metricWindow.AddByInstance(ctx, e, c.Field, value)
+		}
+	case domain.OpAnomaly:
+		if rce.anomalyBaseline != nil {
+			_, err = rce.anomalyBaseline.Observe(ctx, e, c.Field, value, alpha)
+		}
+	default:
+		if rce.fleetState != nil {
+			err = rce.fleetState.Update(ctx, e, c.Field, value)
+		}
+	}
+	if err != nil {
+		rce.logger.Error().Err(err).Msgf("Failed to record %s sample of %s", c.Operator, c.Field)
+	}
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+)
+
+// predictParams – разобранный value оператора predict_breach:
+// {"window_minutes": 30, "threshold": 8e9 | "fields.memory_limit_bytes", "horizon_minutes": 60, "min_samples": 5}
+type predictParams struct {
+	window         time.Duration
+	threshold      float64
+	thresholdField string // если задан – порог берётся из поля события (лимит контейнера)
+	horizon        time.Duration
+	minSamples     int
+}
+
+func parsePredictParams(v interface{}) (predictParams, error) {
+	valMap, ok := v.(map[string]interface{})
+	if !ok {
+		return predictParams{}, fmt.Errorf("invalid 'value' (expected map[string]interface{})")
+	}
+
+	minutes, ok := toFloat(valMap["window_minutes"])
+	if !ok || minutes <= 0 {
+		return predictParams{}, fmt.Errorf("invalid 'window_minutes' %v", valMap["window_minutes"])
+	}
+	horizon, ok := toFloat(valMap["horizon_minutes"])
+	if !ok || horizon <= 0 {
+		return predictParams{}, fmt.Errorf("invalid 'horizon_minutes' %v", valMap["horizon_minutes"])
+	}
+	params := predictParams{
+		window:     time.Duration(minutes * float64(time.Minute)),
+		horizon:    time.Duration(horizon * float64(time.Minute)),
+		minSamples: 5,
+	}
+
+	switch t := valMap["threshold"].(type) {
+	case string:
+		if strings.HasPrefix(t, "fields.") {
+			params.thresholdField = t
+			break
+		}
+		// число строкой ("8e9")
+		f, ok := toFloat(t)
+		if !ok {
+			return predictParams{}, fmt.Errorf("invalid 'threshold' %q (expected number or fields.* path)", t)
+		}
+		params.threshold = f
+	default:
+		f, ok := toFloat(t)
+		if !ok {
+			return predictParams{}, fmt.Errorf("invalid 'threshold' %v", t)
+		}
+		params.threshold = f
+	}
+
+	if m, ok := valMap["min_samples"]; ok {
+		n, ok := toFloat(m)
+		if !ok || n < 2 {
+			return predictParams{}, fmt.Errorf("invalid 'min_samples' %v (expected number >= 2)", m)
+		}
+		params.minSamples = int(n)
+	}
+	return params, nil
+}
+
+// evaluatePredictBreach строит прямую МНК по сэмплам экземпляра за window_minutes и проверяет,
+// что метрика дойдёт до порога быстрее чем за horizon_minutes. Если по прямой порог уже достигнут,
+// ETA = 0 и условие выполнено. Прогноз сохраняется в e.Predictions и уходит вместе с алертом.
+// Условие рассчитано на метрики, растущие к лимиту (память, горутины, диск).
+func (rce *RuleConditionEvaluator) evaluatePredictBreach(e *domain.Event, c domain.Condition) bool {
+	params, err := parsePredictParams(c.Value)
+	if err != nil {
+		rce.logger.Warn().Err(err).Msg("predict_breach condition: invalid value")
+		return false
+	}
+
+	threshold := params.threshold
+	if params.thresholdField != "" {
+		limit, ok := toFloat(rce.getDynamicField(e, params.thresholdField))
+		if !ok {
+			rce.logger.Debug().Msgf("predict_breach: threshold field %s is missing or not numeric", params.thresholdField)
+			return false
+		}
+		threshold = limit
+	}
+
+	samples, ok := rce.instanceSamples(e, c, params.window)
+	if !ok {
+		return false
+	}
+	if len(samples) < params.minSamples {
+		rce.logger.Debug().Msgf("predict_breach: %d samples in window, need %d", len(samples), params.minSamples)
+		return false
+	}
+
+	slope, intercept, ok := linearFit(samples)
+	if !ok {
+		rce.logger.Debug().Msg("predict_breach: all samples have the same timestamp")
+		return false
+	}
+
+	last := samples[len(samples)-1].At
+	current := intercept + slope*last.Sub(samples[0].At).Minutes()
+
+	eta, ok := breachEta(current, threshold, slope)
+	if !ok {
+		rce.logger.Debug().Msgf("predict_breach: field=%s instance=%s is not growing (rate=%g/min)", c.Field, e.InstanceID(), slope)
+		return false
+	}
+
+	etaDuration := time.Duration(eta * float64(time.Minute))
+	e.SetPrediction(c.Field, domain.BreachPrediction{
+		Value:         current,
+		Threshold:     threshold,
+		RatePerMinute: slope,
+		EtaMinutes:    eta,
+		EtaAt:         last.Add(etaDuration).UTC(),
+	})
+
+	rce.logger.Debug().Msgf("predict_breach check: field=%s instance=%s value=%g threshold=%g rate=%g/min eta=%s horizon=%s",
+		c.Field, e.InstanceID(), current, threshold, slope, etaDuration, params.horizon)
+	return etaDuration <= params.horizon
+}
+
+// breachEta – через сколько минут метрика со значением current, растущая на slope в минуту,
+// дойдёт до threshold. 0 – порог уже достигнут; ok=false – метрика не растёт и порог не достигнут.
+func breachEta(current, threshold, slope float64) (float64, bool) {
+	switch {
+	case current >= threshold:
+		return 0, true
+	case slope <= 0:
+		return 0, false
+	}
+	return (threshold - current) / slope, true
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParsePredictParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    predictParams
+		wantErr bool
+	}{
+		{
+			name:  "numeric threshold",
+			value: map[string]interface{}{"window_minutes": float64(30), "threshold": 8e9, "horizon_minutes": float64(60)},
+			want:  predictParams{window: 30 * time.Minute, threshold: 8e9, horizon: time.Hour, minSamples: 5},
+		},
+		{
+			name:  "threshold as a number string",
+			value: map[string]interface{}{"window_minutes": 10, "threshold": "8e9", "horizon_minutes": 15},
+			want:  predictParams{window: 10 * time.Minute, threshold: 8e9, horizon: 15 * time.Minute, minSamples: 5},
+		},
+		{
+			name: "threshold from event field",
+			value: map[string]interface{}{
+				"window_minutes": 30, "threshold": "fields.memory_limit_bytes", "horizon_minutes": 60, "min_samples": 3,
+			},
+			want: predictParams{window: 30 * time.Minute, thresholdField: "fields.memory_limit_bytes", horizon: time.Hour, minSamples: 3},
+		},
+		{
+			name:  "fractional minutes",
+			value: map[string]interface{}{"window_minutes": 0.5, "threshold": 1, "horizon_minutes": 1.5},
+			want:  predictParams{window: 30 * time.Second, threshold: 1, horizon: 90 * time.Second, minSamples: 5},
+		},
+		{name: "not a map", value: "30", wantErr: true},
+		{name: "missing window", value: map[string]interface{}{"threshold": 1, "horizon_minutes": 60}, wantErr: true},
+		{name: "zero window", value: map[string]interface{}{"window_minutes": 0, "threshold": 1, "horizon_minutes": 60}, wantErr: true},
+		{name: "missing horizon", value: map[string]interface{}{"window_minutes": 30, "threshold": 1}, wantErr: true},
+		{name: "negative horizon", value: map[string]interface{}{"window_minutes": 30, "threshold": 1, "horizon_minutes": -5}, wantErr: true},
+		{name: "missing threshold", value: map[string]interface{}{"window_minutes": 30, "horizon_minutes": 60}, wantErr: true},
+		{name: "threshold not a number or field", value: map[string]interface{}{"window_minutes": 30, "threshold": "memory_limit", "horizon_minutes": 60}, wantErr: true},
+		{name: "min_samples below two", value: map[string]interface{}{"window_minutes": 30, "threshold": 1, "horizon_minutes": 60, "min_samples": 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePredictParams(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePredictParams(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parsePredictParams(%v) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreachEta(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   float64
+		threshold float64
+		slope     float64
+		wantEta   float64
+		wantOK    bool
+	}{
+		{name: "growing towards threshold", current: 60, threshold: 100, slope: 2, wantEta: 20, wantOK: true},
+		{name: "already at threshold", current: 100, threshold: 100, slope: 2, wantEta: 0, wantOK: true},
+		{name: "above threshold and falling", current: 120, threshold: 100, slope: -5, wantEta: 0, wantOK: true},
+		{name: "flat below threshold", current: 60, threshold: 100, slope: 0, wantOK: false},
+		{name: "falling below threshold", current: 60, threshold: 100, slope: -1, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eta, ok := breachEta(tt.current, tt.threshold, tt.slope)
+			if ok != tt.wantOK {
+				t.Fatalf("breachEta(%g, %g, %g) ok = %v, want %v", tt.current, tt.threshold, tt.slope, ok, tt.wantOK)
+			}
+			if ok && math.Abs(eta-tt.wantEta) > floatEpsilon {
+				t.Errorf("breachEta(%g, %g, %g) = %g, want %g", tt.current, tt.threshold, tt.slope, eta, tt.wantEta)
+			}
+		})
+	}
+}
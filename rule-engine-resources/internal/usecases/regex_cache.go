@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// regexCacheSize – сколько скомпилированных регулярок держать в памяти.
+const regexCacheSize = 10000
+
+// regexCache – LRU скомпилированных регулярок оператора "matches" и матчеров silences по паттерну,
+// чтобы не компилировать их заново на каждом событии. Одинаковый паттерн разных правил компилируется один раз;
+// паттерны удалённых правил и истёкших silences со временем вытесняются.
+// Ошибка компиляции тоже кешируется: битый паттерн не перекомпилируется на горячем пути.
+type regexCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List               // от недавно использованных к давно
+	items    map[string]*list.Element // pattern -> *regexEntry
+}
+
+type regexEntry struct {
+	pattern string
+	re      *regexp.Regexp
+	err     error
+}
+
+func newRegexCache(capacity int) *regexCache {
+	return &regexCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// get возвращает скомпилированную регулярку pattern.
+func (rc *regexCache) get(pattern string) (*regexp.Regexp, error) {
+	rc.mu.Lock()
+	if el, ok := rc.items[pattern]; ok {
+		rc.ll.MoveToFront(el)
+		entry := el.Value.(*regexEntry)
+		rc.mu.Unlock()
+		return entry.re, entry.err
+	}
+	rc.mu.Unlock()
+
+	// компиляция – вне блокировки; параллельная компиляция того же паттерна безвредна
+	re, err := regexp.Compile(pattern)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if _, ok := rc.items[pattern]; !ok {
+		rc.items[pattern] = rc.ll.PushFront(&regexEntry{pattern: pattern, re: re, err: err})
+		if rc.ll.Len() > rc.capacity {
+			oldest := rc.ll.Back()
+			rc.ll.Remove(oldest)
+			delete(rc.items, oldest.Value.(*regexEntry).pattern)
+		}
+	}
+	return re, err
+}
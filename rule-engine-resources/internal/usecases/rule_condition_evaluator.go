@@ -2,22 +2,44 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"rule-engine-resources/internal/dataproviders/redis_repository"
 	"rule-engine-resources/internal/domain"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/rs/zerolog"
 )
 
 // RuleConditionEvaluator отвечает за проверку одиночного условия (Condition) на событии (Event).
 type RuleConditionEvaluator struct {
 	redisCounter *redis_repository.RedisRepeatCounter
-	logger       *zerolog.Logger
+	metricWindow *redis_repository.RedisMetricWindow
+	// anomalyBaseline – нормы метрик для anomaly
+	anomalyBaseline *redis_repository.RedisAnomalyBaseline
+	// fleetState – последние сэмплы экземпляров для count_where, ratio_where, avg_across
+	fleetState *redis_repository.RedisFleetState
+	regexCache *regexCache
+	logger     *zerolog.Logger
+
+	// recorded – окна, в которые сэмпл текущего события уже записан (см. recordSamples)
+	recorded map[string]struct{}
 }
 type ConditionOperator string
 
+// NewRuleConditionEvaluator создаёт evaluator со своим кешем регулярок.
+// redisCounter может быть nil – тогда repeat_over не считается (dry-run, бенчмарки).
+// Окно метрик, нормы и состояние флота не подключаются, поэтому avg_over, anomaly, count_where и т.д. тоже всегда false.
+func NewRuleConditionEvaluator(redisCounter *redis_repository.RedisRepeatCounter, logger *zerolog.Logger) *RuleConditionEvaluator {
+	return &RuleConditionEvaluator{
+		redisCounter: redisCounter,
+		regexCache:   newRegexCache(regexCacheSize),
+		logger:       logger,
+	}
+}
+
 // Evaluate проверяет, выполняется ли условие cond для события e.
 // Поддерживаются все операторы, описанные в domain.
 func (rce *RuleConditionEvaluator) Evaluate(e *domain.Event, c domain.Condition, r domain.Rule) bool {
@@ -44,6 +66,30 @@ func (rce *RuleConditionEvaluator) Evaluate(e *domain.Event, c domain.Condition,
 		return inList(val, c.Value, false)
 	case domain.OpCont:
 		return evaluateContains(val, c.Value)
+	case domain.OpNotCont:
+		return evaluateNotContains(val, c.Value)
+	case domain.OpIContains:
+		return evaluateIContains(val, c.Value)
+	case domain.OpStartsWith:
+		return evaluateStringFunc(val, c.Value, strings.HasPrefix)
+	case domain.OpEndsWith:
+		return evaluateStringFunc(val, c.Value, strings.HasSuffix)
+	case domain.OpMatches:
+		return rce.evaluateMatches(val, c.Value, r)
+	case domain.OpExists:
+		return val != nil
+	case domain.OpNotExists:
+		return val == nil
+	case domain.OpVersionGT:
+		return rce.evaluateVersion(val, c.Value, 1)
+	case domain.OpVersionGTE:
+		return rce.evaluateVersion(val, c.Value, 1, 0)
+	case domain.OpVersionLT:
+		return rce.evaluateVersion(val, c.Value, -1)
+	case domain.OpVersionLTE:
+		return rce.evaluateVersion(val, c.Value, -1, 0)
+	case domain.OpVersionInRange:
+		return rce.evaluateVersionInRange(val, c.Value)
 	case domain.OpRepeatOver:
 		// Пример: "value": { "threshold": 3, "minutes": 1 }
 		valMap, ok := c.Value.(map[string]interface{})
@@ -83,23 +129,49 @@ func (rce *RuleConditionEvaluator) Evaluate(e *domain.Event, c domain.Condition,
 			return false
 		}
 
+		// Необязательный group_by: ["error_message"], ["fields.customer_id"], ["tags.region"]
+		groupKey, ok := rce.makeGroupKey(e, valMap["group_by"])
+		if !ok {
+			rce.logger.Warn().Msg("repeat_over condition: invalid type for 'group_by' (expected list of strings)")
+			return false
+		}
+
+		// Без счётчика (dry-run) состояние в Redis не читается и не меняется
+		if rce.redisCounter == nil {
+			rce.logger.Debug().Msg("repeat_over is not evaluated without Redis counter")
+			return false
+		}
+
 		// Получаем счетчик за окно времени minutes
-		cnt, err := rce.redisCounter.CountInWindow(context.Background(), e, r, minutes)
+		cnt, err := rce.redisCounter.CountInWindow(context.Background(), e, r, minutes, groupKey)
 		if err != nil {
 			rce.logger.Error().Err(err).Msg("CountInWindow failed")
 			return false
 		}
 		e.RepeatCount = cnt
+		e.GroupKey = groupKey
 
-		rce.logger.Debug().Msgf("repeat_over check: count=%d, threshold=%d", cnt, threshold)
+		rce.logger.Debug().Msgf("repeat_over check: count=%d, threshold=%d, group=%q", cnt, threshold, groupKey)
 		return cnt >= threshold
+	case domain.OpAvgOver, domain.OpMaxOver, domain.OpMinOver, domain.OpPercentileOver:
+		return rce.evaluateWindow(e, c)
+	case domain.OpRateOver:
+		return rce.evaluateRateOver(e, c)
+	case domain.OpIncreasingFor:
+		return rce.evaluateIncreasingFor(e, c)
+	case domain.OpAnomaly:
+		return rce.evaluateAnomaly(e, c)
+	case domain.OpPredictBreach:
+		return rce.evaluatePredictBreach(e, c)
+	case domain.OpCountWhere, domain.OpRatioWhere, domain.OpAvgAcross:
+		return rce.evaluateFleet(e, c)
 	default:
 		return false
 	}
 }
 
 // getDynamicField(evt, "fields.memory_alloc_bytes") – вытягивает значение
-// Считаем, что всё, что не "user_id"/"service_name" – внутри evt.Fields
+// Считаем, что всё, что не поле верхнего уровня (user_id, service_name, version, ...), tags.* или context.* – внутри evt.Fields
 func (rce *RuleConditionEvaluator) getDynamicField(evt *domain.Event, fieldPath string) interface{} {
 
 	rce.logger.Debug().Msgf("getDynamicField: fieldPath=%q", fieldPath)
@@ -128,6 +200,25 @@ func (rce *RuleConditionEvaluator) getDynamicField(evt *domain.Event, fieldPath
 		return nil
 	}
 
+	// environment, version, go_version и другие поля верхнего уровня события
+	if get, ok := eventFields[parts[0]]; ok {
+		if len(parts) == 1 {
+			return get(evt)
+		}
+		rce.logger.Debug().Msgf("getDynamicField: %s has more sub-parts => nil", parts[0])
+		return nil
+	}
+
+	// tags.<key> => значение тега "key=value"
+	if parts[0] == "tags" {
+		return getTag(evt, strings.TrimPrefix(fieldPath, "tags."))
+	}
+
+	// context.<path> => разобранный ContextJson
+	if parts[0] == "context" {
+		return rce.getContextField(evt, fieldPath)
+	}
+
 	// остальное => fields
 	if evt.Fields == nil {
 		rce.logger.Debug().Msg("getDynamicField: evt.Fields == nil => return nil")
@@ -146,6 +237,78 @@ func (rce *RuleConditionEvaluator) getDynamicField(evt *domain.Event, fieldPath
 	return rce.traverseMapDebug(evt.Fields, subParts)
 }
 
+// eventFields – поля верхнего уровня события, доступные в условиях по имени (кроме user_id и service_name).
+var eventFields = map[string]func(e *domain.Event) interface{}{
+	"environment":   func(e *domain.Event) interface{} { return e.Environment },
+	"error_message": func(e *domain.Event) interface{} { return e.ErrorMessage },
+	"version":       func(e *domain.Event) interface{} { return e.Version },
+	"go_version":    func(e *domain.Event) interface{} { return e.GoVersion },
+	"os":            func(e *domain.Event) interface{} { return e.Os },
+	"arch":          func(e *domain.Event) interface{} { return e.Arch },
+	"event_type":    func(e *domain.Event) interface{} { return e.EventType },
+	"level":         func(e *domain.Event) interface{} { return e.Level },
+	"event_message": func(e *domain.Event) interface{} { return e.EventMessage },
+	"timestamp":     func(e *domain.Event) interface{} { return e.Timestamp },
+	"language":      func(e *domain.Event) interface{} { return e.Language },
+}
+
+// ResolveField возвращает значение поля так, как его видит условие (для трассировки dry-run).
+func (rce *RuleConditionEvaluator) ResolveField(e *domain.Event, field string) interface{} {
+	return rce.getDynamicField(e, field)
+}
+
+// makeGroupKey собирает ключ группы для repeat_over из значений полей, перечисленных в group_by.
+// Например, group_by=["error_message","tags.region"] => "error_message=timeout|tags.region=eu-west-1".
+// Пустой group_by даёт пустой ключ (все события правила считаются вместе).
+func (rce *RuleConditionEvaluator) makeGroupKey(e *domain.Event, groupBy interface{}) (string, bool) {
+	var paths []string
+	switch g := groupBy.(type) {
+	case nil:
+		return "", true
+	case []string:
+		paths = g
+	case []interface{}:
+		for _, p := range g {
+			ps, ok := p.(string)
+			if !ok {
+				return "", false
+			}
+			paths = append(paths, ps)
+		}
+	default:
+		return "", false
+	}
+
+	parts := make([]string, 0, len(paths))
+	for _, p := range paths {
+		val := rce.getDynamicField(e, p)
+		if val == nil {
+			val = ""
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", p, val))
+	}
+	return strings.Join(parts, "|"), true
+}
+
+// getContextField извлекает значение из ContextJson по dot-path (например, "context.user_info.vip").
+// Если JSON битый или пути нет, возвращается nil.
+func (rce *RuleConditionEvaluator) getContextField(e *domain.Event, fieldPath string) interface{} {
+	ctxMap, err := e.ContextMap()
+	if err != nil {
+		rce.logger.Warn().Err(err).Msg("getContextField: failed to parse context_json")
+		return nil
+	}
+	return rce.traverseMapDebug(ctxMap, strings.Split(strings.TrimPrefix(fieldPath, "context."), "."))
+}
+
+// getTag возвращает значение тега "key=value" по ключу или nil, если тега нет.
+func getTag(e *domain.Event, key string) interface{} {
+	if v, ok := e.TagMap()[key]; ok {
+		return v
+	}
+	return nil
+}
+
 func (rce *RuleConditionEvaluator) traverseMapDebug(cur interface{}, keys []string) interface{} {
 	if len(keys) == 0 {
 		rce.logger.Debug().Msgf("traverseMapDebug: no more keys => return %+v", cur)
@@ -293,3 +456,137 @@ func evaluateContains(fieldVal, condVal interface{}) bool {
 	}
 	return false
 }
+
+// evaluateNotContains – отрицание contains.
+func evaluateNotContains(fieldVal, condVal interface{}) bool {
+	return !evaluateContains(fieldVal, condVal)
+}
+
+// evaluateIContains – contains без учёта регистра: подстрока для строки, элемент для списка.
+func evaluateIContains(fieldVal, condVal interface{}) bool {
+	cvStr, ok := condVal.(string)
+	if !ok {
+		return false
+	}
+	if fvStr, ok := fieldVal.(string); ok {
+		return strings.Contains(strings.ToLower(fvStr), strings.ToLower(cvStr))
+	}
+	for _, s := range toStrings(fieldVal) {
+		if strings.EqualFold(s, cvStr) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateStringFunc применяет строковую проверку к строке или к любому элементу списка строк.
+func evaluateStringFunc(fieldVal, condVal interface{}, fn func(s, cond string) bool) bool {
+	cvStr, ok := condVal.(string)
+	if !ok {
+		return false
+	}
+	for _, s := range toStrings(fieldVal) {
+		if fn(s, cvStr) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateMatches проверяет значение поля регулярным выражением RE2 из condVal.
+// Регулярка берётся из LRU-кеша, битый паттерн логируется и даёт false.
+func (rce *RuleConditionEvaluator) evaluateMatches(fieldVal, condVal interface{}, r domain.Rule) bool {
+	pattern, ok := condVal.(string)
+	if !ok {
+		rce.logger.Warn().Msgf("matches condition: invalid 'value' type %T (expected string)", condVal)
+		return false
+	}
+	re, err := rce.regexCache.get(pattern)
+	if err != nil {
+		rce.logger.Error().Err(err).Msgf("matches condition: invalid regex %q in rule %s", pattern, r.ID)
+		return false
+	}
+	for _, s := range toStrings(fieldVal) {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// toStrings приводит строку или список строк к []string; остальные типы дают nil.
+func toStrings(v interface{}) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	case []interface{}:
+		out := make([]string, 0, len(t))
+		for _, el := range t {
+			if s, ok := el.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// parseVersion разбирает semver-строку ("1.10.0", "v1.4", "go1.22.3").
+func parseVersion(v interface{}) (*semver.Version, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return nil, false
+	}
+	ver, err := semver.NewVersion(strings.TrimPrefix(s, "go"))
+	if err != nil {
+		return nil, false
+	}
+	return ver, true
+}
+
+// compareVersions сравнивает версии по semver: -1, 0 или 1; при неразборчивых версиях ok=false.
+func compareVersions(a, b interface{}) (int, bool) {
+	av, aok := parseVersion(a)
+	bv, bok := parseVersion(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	return av.Compare(bv), true
+}
+
+// evaluateVersion проверяет semver-сравнение fieldVal с condVal; accept – допустимые результаты Compare.
+func (rce *RuleConditionEvaluator) evaluateVersion(fieldVal, condVal interface{}, accept ...int) bool {
+	cmp, ok := compareVersions(fieldVal, condVal)
+	if !ok {
+		rce.logger.Debug().Msgf("version compare: cannot parse %v or %v as semver", fieldVal, condVal)
+		return false
+	}
+	for _, a := range accept {
+		if cmp == a {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateVersionInRange проверяет, что версия попадает в диапазон вида ">=1.4.0 <2.0.0".
+func (rce *RuleConditionEvaluator) evaluateVersionInRange(fieldVal, condVal interface{}) bool {
+	rng, ok := condVal.(string)
+	if !ok {
+		rce.logger.Warn().Msgf("version_in_range condition: invalid 'value' type %T (expected string)", condVal)
+		return false
+	}
+	constraint, err := semver.NewConstraint(rng)
+	if err != nil {
+		rce.logger.Error().Err(err).Msgf("version_in_range condition: invalid range %q", rng)
+		return false
+	}
+	ver, ok := parseVersion(fieldVal)
+	if !ok {
+		rce.logger.Debug().Msgf("version_in_range: cannot parse %v as semver", fieldVal)
+		return false
+	}
+	return constraint.Check(ver)
+}
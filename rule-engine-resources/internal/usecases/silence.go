@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+)
+
+type SilenceRepository interface {
+	GetActiveSilences(ctx context.Context, projectID string) ([]domain.Silence, error)
+}
+
+// silenceSet – silences проекта события. Загружаются один раз, при первом сработавшем правиле:
+// события без срабатываний Redis не читают.
+type silenceSet struct {
+	uc        *EvaluateRulesUseCase
+	projectID string
+	loaded    bool
+	items     []domain.Silence
+}
+
+func (uc *EvaluateRulesUseCase) newSilenceSet(projectID string) *silenceSet {
+	return &silenceSet{uc: uc, projectID: projectID}
+}
+
+// match возвращает silence, под который попадает сработавшее правило r.
+func (ss *silenceSet) match(ctx context.Context, e *domain.Event, r domain.Rule, now time.Time) (domain.Silence, bool) {
+	if !ss.loaded {
+		ss.items = ss.uc.activeSilences(ctx, ss.projectID)
+		ss.loaded = true
+	}
+	for _, s := range ss.items {
+		if now.Before(s.EndsAt) && ss.uc.silenceMatches(s, e, r) {
+			return s, true
+		}
+	}
+	return domain.Silence{}, false
+}
+
+// activeSilences читает silences проекта из Redis, при промахе – из Postgres с записью в Redis.
+// Ошибка чтения алерты не глушит.
+func (uc *EvaluateRulesUseCase) activeSilences(ctx context.Context, projectID string) []domain.Silence {
+	if uc.silenceRepo == nil || uc.silenceCache == nil {
+		return nil
+	}
+
+	silences, ok, err := uc.silenceCache.GetSilences(ctx, projectID)
+	if err == nil && ok {
+		return silences
+	}
+
+	silences, err = uc.silenceRepo.GetActiveSilences(ctx, projectID)
+	if err != nil {
+		uc.logger.Error().Err(err).Msgf("Failed to fetch silences for project=%s", projectID)
+		return nil
+	}
+	if err := uc.silenceCache.SetSilences(ctx, projectID, silences); err != nil {
+		uc.logger.Warn().Err(err).Msgf("Failed to cache silences for project=%s", projectID)
+	}
+	return silences
+}
+
+// silenceMatches проверяет все заданные условия silence. Silence без условий не глушит ничего:
+// public API такой не создаёт, а заглушить весь проект – задача окна обслуживания.
+func (uc *EvaluateRulesUseCase) silenceMatches(s domain.Silence, e *domain.Event, r domain.Rule) bool {
+	m := s.Matchers
+	if m.RuleID == "" && m.ServiceName == "" && m.Environment == "" && m.ErrorMessage == "" && len(m.Tags) == 0 {
+		return false
+	}
+	if m.RuleID != "" && m.RuleID != r.ID {
+		return false
+	}
+	if m.ServiceName != "" && m.ServiceName != e.ServiceName {
+		return false
+	}
+	if m.Environment != "" && m.Environment != e.Environment {
+		return false
+	}
+	if m.ErrorMessage != "" {
+		re, err := uc.regexCache.get(m.ErrorMessage)
+		if err != nil {
+			uc.logger.Warn().Err(err).Msgf("Silence %s has invalid error_message pattern", s.ID)
+			return false
+		}
+		if !re.MatchString(e.ErrorMessage) {
+			return false
+		}
+	}
+	if len(m.Tags) > 0 {
+		tags := e.TagMap()
+		for k, v := range m.Tags {
+			if tv, ok := tags[k]; !ok || tv != v {
+				return false
+			}
+		}
+	}
+	return true
+}
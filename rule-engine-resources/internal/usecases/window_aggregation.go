@@ -0,0 +1,173 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"rule-engine-resources/internal/domain"
+)
+
+// windowParams – разобранный value оконного оператора:
+// {"window_minutes": 5, "threshold": 80, "compare": "gte", "p": 95, "min_samples": 3}
+type windowParams struct {
+	window     time.Duration
+	threshold  float64
+	compare    domain.ConditionOperator // gt / gte / lt / lte, по умолчанию gte
+	p          float64                  // только для percentile_over
+	minSamples int                      // меньше сэмплов в окне – условие не выполнено
+}
+
+func parseWindowParams(op domain.ConditionOperator, v interface{}) (windowParams, error) {
+	valMap, ok := v.(map[string]interface{})
+	if !ok {
+		return windowParams{}, fmt.Errorf("invalid 'value' (expected map[string]interface{})")
+	}
+
+	minutes, ok := toFloat(valMap["window_minutes"])
+	if !ok || minutes <= 0 {
+		return windowParams{}, fmt.Errorf("invalid 'window_minutes' %v", valMap["window_minutes"])
+	}
+	threshold, ok := toFloat(valMap["threshold"])
+	if !ok {
+		return windowParams{}, fmt.Errorf("invalid 'threshold' %v", valMap["threshold"])
+	}
+	params := windowParams{
+		window:     time.Duration(minutes * float64(time.Minute)),
+		threshold:  threshold,
+		compare:    domain.OpGTE,
+		minSamples: 1,
+	}
+
+	if c, ok := valMap["compare"]; ok {
+		cs, _ := c.(string)
+		switch domain.ConditionOperator(cs) {
+		case domain.OpGT, domain.OpGTE, domain.OpLT, domain.OpLTE:
+			params.compare = domain.ConditionOperator(cs)
+		default:
+			return windowParams{}, fmt.Errorf("invalid 'compare' %v (expected gt, gte, lt or lte)", c)
+		}
+	}
+	if m, ok := valMap["min_samples"]; ok {
+		n, ok := toFloat(m)
+		if !ok || n < 1 {
+			return windowParams{}, fmt.Errorf("invalid 'min_samples' %v", m)
+		}
+		params.minSamples = int(n)
+	}
+	if op == domain.OpRateOver && params.minSamples < 2 {
+		// скорость определена минимум по двум точкам
+		params.minSamples = 2
+	}
+	if op == domain.OpPercentileOver {
+		p, ok := toFloat(valMap["p"])
+		if !ok || p < 0 || p > 100 {
+			return windowParams{}, fmt.Errorf("invalid 'p' %v (expected 0..100)", valMap["p"])
+		}
+		params.p = p
+	}
+	return params, nil
+}
+
+// evaluateWindow проверяет оконный оператор: агрегат по окну (service, environment, metric) в Redis
+// сравнивается с threshold. Сэмпл события записан в окно до проверки правил (recordSamples).
+// Посчитанный агрегат сохраняется в e.Aggregates и уходит вместе с алертом.
+func (rce *RuleConditionEvaluator) evaluateWindow(e *domain.Event, c domain.Condition) bool {
+	params, err := parseWindowParams(c.Operator, c.Value)
+	if err != nil {
+		rce.logger.Warn().Err(err).Msgf("%s condition: invalid value", c.Operator)
+		return false
+	}
+
+	if _, ok := toFloat(rce.getDynamicField(e, c.Field)); !ok {
+		rce.logger.Debug().Msgf("%s: field %s is missing or not numeric", c.Operator, c.Field)
+		return false
+	}
+
+	// Без Redis (dry-run) окно не читается
+	if rce.metricWindow == nil {
+		rce.logger.Debug().Msgf("%s is not evaluated without Redis metric window", c.Operator)
+		return false
+	}
+
+	samples, err := rce.metricWindow.Range(context.Background(), e, c.Field, params.window)
+	if err != nil {
+		rce.logger.Error().Err(err).Msg("Metric window Range failed")
+		return false
+	}
+	if len(samples) < params.minSamples {
+		rce.logger.Debug().Msgf("%s: %d samples in window, need %d", c.Operator, len(samples), params.minSamples)
+		return false
+	}
+
+	agg := aggregateSamples(c.Operator, samples, params.p)
+	e.SetAggregate(aggregateName(c, params), agg)
+
+	rce.logger.Debug().Msgf("%s check: field=%s samples=%d value=%g %s %g", c.Operator, c.Field, len(samples), agg, params.compare, params.threshold)
+	return compareThreshold(agg, params.compare, params.threshold)
+}
+
+// aggregateName – ключ агрегата в e.Aggregates: "avg_over(fields.cpu_percent,5m)", "percentile_over(fields.rss,10m,p95)".
+func aggregateName(c domain.Condition, params windowParams) string {
+	name := fmt.Sprintf("%s(%s,%gm", c.Operator, c.Field, params.window.Minutes())
+	if c.Operator == domain.OpPercentileOver {
+		name += fmt.Sprintf(",p%g", params.p)
+	}
+	return name + ")"
+}
+
+// aggregateSamples считает агрегат окна. samples не пустой.
+func aggregateSamples(op domain.ConditionOperator, samples []domain.MetricSample, p float64) float64 {
+	switch op {
+	case domain.OpMaxOver:
+		max := math.Inf(-1)
+		for _, s := range samples {
+			max = math.Max(max, s.Value)
+		}
+		return max
+	case domain.OpMinOver:
+		min := math.Inf(1)
+		for _, s := range samples {
+			min = math.Min(min, s.Value)
+		}
+		return min
+	case domain.OpPercentileOver:
+		return percentile(samples, p)
+	default: // OpAvgOver
+		sum := 0.0
+		for _, s := range samples {
+			sum += s.Value
+		}
+		return sum / float64(len(samples))
+	}
+}
+
+// percentile – p-й перцентиль с линейной интерполяцией между соседними значениями.
+func percentile(samples []domain.MetricSample, p float64) float64 {
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = s.Value
+	}
+	sort.Float64s(values)
+
+	rank := p / 100 * float64(len(values)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return values[lo] + (values[hi]-values[lo])*(rank-float64(lo))
+}
+
+// compareThreshold сравнивает агрегат с порогом оператором gt / gte / lt / lte.
+func compareThreshold(v float64, op domain.ConditionOperator, threshold float64) bool {
+	switch op {
+	case domain.OpGT:
+		return v > threshold
+	case domain.OpLT:
+		return v < threshold
+	case domain.OpLTE:
+		return v <= threshold
+	default: // OpGTE
+		return v >= threshold
+	}
+}
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS alert_state_transitions (
+    id           SERIAL       NOT NULL,
+    timestamp    TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    user_id      INT          NOT NULL,
+    project_id   VARCHAR      NOT NULL,
+    rule_id      VARCHAR      NOT NULL,
+    rule_name    VARCHAR      NOT NULL,
+    service_name VARCHAR      NOT NULL,
+    environment  VARCHAR      NOT NULL,
+    instance     VARCHAR      NOT NULL,
+    state        VARCHAR(16)  NOT NULL, -- pending / firing / resolved / inactive
+    log          JSONB        NOT NULL,
+    PRIMARY KEY (id, timestamp)
+);
+
+SELECT create_hypertable('alert_state_transitions', 'timestamp', if_not_exists => TRUE);
+
+CREATE INDEX IF NOT EXISTS alert_state_transitions_rule_idx
+    ON alert_state_transitions (rule_id, service_name, environment, instance, timestamp DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS alert_state_transitions;
+-- +goose StatementEnd